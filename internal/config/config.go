@@ -69,6 +69,9 @@ type PathsConfig struct {
 type ExcelConfig struct {
 	GoogleAPIKey   string
 	ScraperTimeout time.Duration
+
+	// Number of imported files kept on the downloads dir, 0 keeps all of them
+	ArchiveRetention int
 }
 
 type LoggingConfig struct {
@@ -178,6 +181,8 @@ func load() (*Config, error) {
 		Excel: ExcelConfig{
 			GoogleAPIKey:   googleAPIKey,
 			ScraperTimeout: getEnvAsDuration("SCRAPER_TIMEOUT", 30*time.Second),
+
+			ArchiveRetention: getEnvAsInt("ARCHIVE_RETENTION", 30),
 		},
 
 		Logging: LoggingConfig{
@@ -236,6 +241,14 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+func getEnvAsInt(key string, defaultValue int) int {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.Atoi(valueStr); err == nil && value >= 0 {
+		return value
+	}
+	return defaultValue
+}
+
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	valueStr := getEnv(key, "")
 	if value, err := time.ParseDuration(valueStr); err == nil {
@@ -0,0 +1,126 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	utils "github.com/elias-gill/poliplanner2/internal/http"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	render "github.com/elias-gill/poliplanner2/internal/render/html"
+	academicService "github.com/elias-gill/poliplanner2/internal/service/academic"
+	"github.com/elias-gill/poliplanner2/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+const maxUploadSize = 1 << 20 // 1 MiB
+
+// Handler handles the academic calendar screen, where admins load the holidays and days
+// without classes.
+type Handler struct {
+	tmpl            *render.TemplateManager
+	calendarService *academicService.CalendarService
+}
+
+// NewHandler constructs a new Handler instance.
+func NewHandler(tmpl *render.TemplateManager, calendarService *academicService.CalendarService) *Handler {
+	return &Handler{
+		tmpl:            tmpl,
+		calendarService: calendarService,
+	}
+}
+
+// Routes sets up the HTTP router for the calendar endpoints.
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.index)
+	r.Post("/import", h.importFile)
+	r.Delete("/{id}", h.delete)
+
+	return r
+}
+
+// index lists every day off loaded, along with the admin import form.
+func (h *Handler) index(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	days, err := h.calendarService.ListDaysOff(ctx)
+	if err != nil {
+		logger.Error("cannot list days off", "error", err)
+		utils.Redirect(w, r, "/500")
+		return
+	}
+
+	data := map[string]any{
+		"DaysOff": days,
+	}
+
+	if err := h.tmpl.RenderPage(w, "calendar/index.html", data); err != nil {
+		logger.Error("cannot render academic calendar", "error", err)
+	}
+}
+
+// importFile loads an ICS or CSV file sent as the "file" form value. When "replace" is set
+// the days loaded before are removed. Expects the admin key as bearer token.
+func (h *Handler) importFile(w http.ResponseWriter, r *http.Request) {
+	if !utils.IsAdminRequest(r) {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Calendar file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	replace := r.FormValue("replace") == "true"
+
+	added, err := h.calendarService.ImportFile(r.Context(), header.Filename, file, replace)
+	if err != nil {
+		if errors.Is(err, academicService.ErrInvalidCalendar) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		logger.Error("cannot import academic calendar", "file", header.Filename, "error", err)
+		http.Error(w, "Import failed", http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info("academic calendar imported", "file", header.Filename, "added", added, "replace", replace)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintf(w, "%d", added)
+}
+
+// delete removes a day off. Expects the admin key as bearer token.
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request) {
+	if !utils.IsAdminRequest(r) {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	id, err := utils.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.calendarService.DeleteDayOff(r.Context(), academic.DayOffID(id)); err != nil {
+		logger.Error("cannot delete day off", "id", id, "error", err)
+		http.Error(w, "Delete failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
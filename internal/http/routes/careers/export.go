@@ -0,0 +1,108 @@
+package careers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/calendar"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+// exportFilename builds the download name from the career code and the active filters
+// (eg: "examenes-IIN-sem3").
+func exportFilename(calendar *academic.CareerExamCalendarView) string {
+	parts := []string{"examenes", calendar.Career.Code}
+	if f := calendar.Filter; f.Semester != 0 {
+		parts = append(parts, "sem"+strconv.Itoa(f.Semester))
+	}
+	if f := calendar.Filter; f.Plan != "" {
+		parts = append(parts, "plan"+f.Plan)
+	}
+	if f := calendar.Filter; f.Emphasis != "" {
+		parts = append(parts, f.Emphasis)
+	}
+
+	name := strings.Join(parts, "-")
+	return strings.Map(func(r rune) rune {
+		if r == '"' || r == '/' || r == '\\' || r < ' ' {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// writeCSV writes one row per exam, including the ones without date.
+func writeCSV(w io.Writer, calendar *academic.CareerExamCalendarView) error {
+	out := csv.NewWriter(w)
+
+	err := out.Write([]string{
+		"Materia", "Sección", "Turno", "Semestre", "Planes", "Énfasis",
+		"Examen", "Fecha", "Hora", "Aula", "Revisión",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, e := range calendar.Exams {
+		var date, hour, revision string
+		if e.Date != nil {
+			date = e.Date.Format("2006-01-02")
+			hour = e.Date.Format("15:04")
+		}
+		if e.Revision != nil {
+			revision = e.Revision.Format("2006-01-02 15:04")
+		}
+
+		err := out.Write([]string{
+			e.CourseName,
+			e.Section,
+			e.Shift,
+			strconv.Itoa(e.Semester),
+			strings.Join(e.Plans, " "),
+			strings.Join(e.Emphases, " "),
+			e.Label(),
+			date,
+			hour,
+			e.Room.String(),
+			revision,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// writeICS writes an iCalendar file with one event per dated exam.
+func writeICS(w io.Writer, exams *academic.CareerExamCalendarView, now time.Time) error {
+	out := calendar.NewICSWriter(w, "Examenes", "Exámenes "+exams.Career.Code)
+
+	for _, e := range exams.Exams {
+		if e.Date == nil {
+			continue
+		}
+
+		description := fmt.Sprintf("Sección %s - %s", e.Section, e.Shift)
+		if e.Revision != nil {
+			description += "\nRevisión: " + e.Revision.Format("02/01/2006 15:04")
+		}
+
+		out.Line("BEGIN:VEVENT")
+		out.Line(fmt.Sprintf("UID:%d-%s-%d@poliplanner", e.CourseID, e.Type, e.Instance))
+		out.Time("DTSTAMP", now)
+		out.Time("DTSTART", *e.Date)
+		out.Time("DTEND", e.Date.Add(academic.ExamDuration))
+		out.Text("SUMMARY", e.Label()+" - "+e.CourseName)
+		out.Text("LOCATION", e.Room.String())
+		out.Text("DESCRIPTION", description)
+		out.Line("END:VEVENT")
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,212 @@
+package careers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	utils "github.com/elias-gill/poliplanner2/internal/http"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	render "github.com/elias-gill/poliplanner2/internal/render/html"
+	academicService "github.com/elias-gill/poliplanner2/internal/service/academic"
+	"github.com/elias-gill/poliplanner2/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+// Handler handles HTTP requests related to the career wide pages.
+type Handler struct {
+	tmpl          *render.TemplateManager
+	careerService *academicService.CareerService
+}
+
+// NewHandler constructs a new Handler instance.
+func NewHandler(tmpl *render.TemplateManager, careerService *academicService.CareerService) *Handler {
+	return &Handler{
+		tmpl:          tmpl,
+		careerService: careerService,
+	}
+}
+
+// Routes sets up the HTTP router for the careers endpoints.
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.index)
+	r.Get("/{code}/exams", h.exams)
+	r.Get("/{code}/exams.ics", h.examsICS)
+	r.Get("/{code}/exams.csv", h.examsCSV)
+
+	return r
+}
+
+// index renders the list of careers, each one linking to its exam calendar.
+func (h *Handler) index(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	careers, err := h.careerService.ListCareers(ctx)
+	if err != nil {
+		logger.Error("cannot list careers", "error", err)
+		utils.Redirect(w, r, "/500")
+		return
+	}
+
+	data := map[string]any{
+		"Careers": careers,
+	}
+
+	if err := h.tmpl.RenderPage(w, "careers/index.html", data); err != nil {
+		logger.Error("cannot render careers index", "error", err)
+	}
+}
+
+// exams renders the exam calendar of a career. Accepts the "semestre", "plan" and "enfasis"
+// filters and a "view" query param to choose between the list ("list", default) and the
+// month grid ("month").
+func (h *Handler) exams(w http.ResponseWriter, r *http.Request) {
+	calendar, ok := h.getCalendar(w, r)
+	if !ok {
+		return
+	}
+
+	view := r.URL.Query().Get("view")
+	if view != "month" {
+		view = "list"
+	}
+
+	base := "/careers/" + url.PathEscape(calendar.Career.Code) + "/exams"
+	query := filterQuery(calendar.Filter)
+
+	data := map[string]any{
+		"Calendar": calendar,
+		"View":     view,
+		"ListURL":  base + withView(query, "list"),
+		"MonthURL": base + withView(query, "month"),
+		"ICSURL":   base + ".ics" + encode(query),
+		"CSVURL":   base + ".csv" + encode(query),
+	}
+
+	if err := h.tmpl.RenderPage(w, "careers/exams.html", data); err != nil {
+		logger.Error("cannot render career exams", "code", calendar.Career.Code, "error", err)
+	}
+}
+
+// examsICS downloads the exam calendar of a career as an iCalendar file, using the same
+// filters as the page.
+func (h *Handler) examsICS(w http.ResponseWriter, r *http.Request) {
+	calendar, ok := h.getCalendar(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+exportFilename(calendar)+`.ics"`)
+
+	if err := writeICS(w, calendar, time.Now()); err != nil {
+		logger.Error("cannot write career exams ics", "code", calendar.Career.Code, "error", err)
+	}
+}
+
+// examsCSV downloads the exam calendar of a career as a CSV file, using the same filters as
+// the page.
+func (h *Handler) examsCSV(w http.ResponseWriter, r *http.Request) {
+	calendar, ok := h.getCalendar(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+exportFilename(calendar)+`.csv"`)
+
+	if err := writeCSV(w, calendar); err != nil {
+		logger.Error("cannot write career exams csv", "code", calendar.Career.Code, "error", err)
+	}
+}
+
+// getCalendar loads the exam calendar requested by the URL. Writes the error response and
+// returns false when it cannot be loaded.
+func (h *Handler) getCalendar(w http.ResponseWriter, r *http.Request) (*academic.CareerExamCalendarView, bool) {
+	code, err := url.PathUnescape(chi.URLParam(r, "code"))
+	if err != nil {
+		utils.Redirect(w, r, "/404")
+		return nil, false
+	}
+
+	filter, err := parseFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, "Filtro inválido", http.StatusBadRequest)
+		return nil, false
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	calendar, err := h.careerService.GetExamCalendar(ctx, code, filter)
+	if err != nil {
+		if errors.Is(err, academicService.ErrCareerNotFound) {
+			utils.Redirect(w, r, "/404")
+			return nil, false
+		}
+		logger.Error("cannot get career exams", "code", code, "error", err)
+		utils.Redirect(w, r, "/500")
+		return nil, false
+	}
+
+	return calendar, true
+}
+
+// ==================
+//  Helper functions
+// ==================
+
+func parseFilter(query url.Values) (academic.CareerExamFilter, error) {
+	filter := academic.CareerExamFilter{
+		Plan:     query.Get("plan"),
+		Emphasis: query.Get("enfasis"),
+	}
+
+	if s := query.Get("semestre"); s != "" {
+		semester, err := strconv.Atoi(s)
+		if err != nil || semester < 0 {
+			return filter, errors.New("invalid semester")
+		}
+		filter.Semester = semester
+	}
+
+	return filter, nil
+}
+
+func filterQuery(filter academic.CareerExamFilter) url.Values {
+	query := url.Values{}
+	if filter.Semester != 0 {
+		query.Set("semestre", strconv.Itoa(filter.Semester))
+	}
+	if filter.Plan != "" {
+		query.Set("plan", filter.Plan)
+	}
+	if filter.Emphasis != "" {
+		query.Set("enfasis", filter.Emphasis)
+	}
+	return query
+}
+
+func withView(query url.Values, view string) string {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = v
+	}
+	if view != "list" {
+		q.Set("view", view)
+	}
+	return encode(q)
+}
+
+func encode(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	return "?" + query.Encode()
+}
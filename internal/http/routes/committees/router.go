@@ -0,0 +1,117 @@
+package committees
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	utils "github.com/elias-gill/poliplanner2/internal/http"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	render "github.com/elias-gill/poliplanner2/internal/render/html"
+	academicService "github.com/elias-gill/poliplanner2/internal/service/academic"
+	"github.com/elias-gill/poliplanner2/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+// Handler handles HTTP requests related to the exam committees lookup.
+type Handler struct {
+	tmpl            *render.TemplateManager
+	examinerService *academicService.ExaminerService
+}
+
+// NewHandler constructs a new Handler instance.
+func NewHandler(tmpl *render.TemplateManager, examinerService *academicService.ExaminerService) *Handler {
+	return &Handler{
+		tmpl:            tmpl,
+		examinerService: examinerService,
+	}
+}
+
+// Routes sets up the HTTP router for the committees endpoints.
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.index)
+	r.Get("/search", h.search)
+	r.Get("/{id}", h.examinerDetail)
+
+	return r
+}
+
+// index renders the examiners search. Accepts an optional "q" query param to pre-filter the
+// list.
+func (h *Handler) index(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	query := r.URL.Query().Get("q")
+
+	examiners, err := h.examinerService.ListExaminers(ctx, query)
+	if err != nil {
+		logger.Error("cannot list examiners", "error", err)
+		utils.Redirect(w, r, "/500")
+		return
+	}
+
+	data := map[string]any{
+		"Query":     query,
+		"Examiners": examiners,
+	}
+
+	if err := h.tmpl.RenderPage(w, "committees/index.html", data); err != nil {
+		logger.Error("cannot render committees index", "error", err)
+	}
+}
+
+// search renders the filtered examiners list, used by the live search.
+func (h *Handler) search(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	query := r.URL.Query().Get("q")
+
+	examiners, err := h.examinerService.ListExaminers(ctx, query)
+	if err != nil {
+		logger.Error("cannot search examiners", "query", query, "error", err)
+		http.Error(w, "Error al buscar mesas examinadoras", http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]any{
+		"Query":     query,
+		"Examiners": examiners,
+	}
+
+	if err := h.tmpl.RenderPartial(w, "committees/index.html", "committees/examiner_list", data); err != nil {
+		logger.Error("cannot render examiners list partial", "error", err)
+		http.Error(w, "Error al renderizar la plantilla", http.StatusInternalServerError)
+	}
+}
+
+// examinerDetail renders every final exam where the examiner sits on the committee.
+func (h *Handler) examinerDetail(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.Redirect(w, r, "/404")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	examiner, err := h.examinerService.GetExaminer(ctx, academic.ExaminerID(id))
+	if err != nil {
+		if errors.Is(err, academicService.ErrExaminerNotFound) {
+			utils.Redirect(w, r, "/404")
+			return
+		}
+		logger.Error("cannot get examiner detail", "id", id, "error", err)
+		utils.Redirect(w, r, "/500")
+		return
+	}
+
+	if err := h.tmpl.RenderPage(w, "committees/detail.html", examiner); err != nil {
+		logger.Error("cannot render examiner detail", "id", id, "error", err)
+	}
+}
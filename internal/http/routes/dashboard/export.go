@@ -0,0 +1,53 @@
+package dashboard
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/calendar"
+	scheduleModel "github.com/elias-gill/poliplanner2/internal/model/schedule"
+)
+
+// writeClassesICS writes an iCalendar file with one weekly event per class. Holidays and days
+// without classes are excluded from the recurrences with EXDATE, and also listed as all-day
+// events so they show up on the calendar.
+func writeClassesICS(w io.Writer, id scheduleModel.ScheduleID, classes *scheduleModel.ClassCalendarView, now time.Time) error {
+	out := calendar.NewICSWriter(w, "Horario", "Clases "+classes.Title)
+
+	for i, e := range classes.Events {
+		out.Line("BEGIN:VEVENT")
+		out.Line(fmt.Sprintf("UID:%d-%d-%s@poliplanner", id, i, e.Start.Format("20060102T1504")))
+		out.Time("DTSTAMP", now)
+		out.Time("DTSTART", e.Start)
+		out.Time("DTEND", e.End)
+		out.Line("RRULE:FREQ=WEEKLY;UNTIL=" + calendar.FormatICSTime(e.Until))
+
+		if len(e.Exclusions) > 0 {
+			dates := make([]string, len(e.Exclusions))
+			for j, ex := range e.Exclusions {
+				dates[j] = calendar.FormatICSTime(ex)
+			}
+			out.Line("EXDATE:" + strings.Join(dates, ","))
+		}
+
+		out.Text("SUMMARY", e.Course)
+		out.Text("LOCATION", e.Room.String())
+		out.Line("END:VEVENT")
+	}
+
+	for _, d := range classes.DaysOff {
+		out.Line("BEGIN:VEVENT")
+		out.Line(fmt.Sprintf("UID:dia-sin-clase-%d@poliplanner", d.ID))
+		out.Time("DTSTAMP", now)
+		out.Line("DTSTART;VALUE=DATE:" + d.Dates.Start.Format("20060102"))
+		// The end of all-day events is exclusive
+		out.Line("DTEND;VALUE=DATE:" + d.Dates.End.AddDate(0, 0, 1).Format("20060102"))
+		out.Text("SUMMARY", d.Kind.String()+": "+d.Description)
+		out.Line("TRANSP:TRANSPARENT")
+		out.Line("END:VEVENT")
+	}
+
+	return out.Close()
+}
@@ -2,8 +2,10 @@ package dashboard
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	utils "github.com/elias-gill/poliplanner2/internal/http"
 	"github.com/elias-gill/poliplanner2/internal/http/cookie"
@@ -41,6 +43,9 @@ func (h *Handler) Routes() chi.Router {
 
 	r.Get("/", h.dashboard)
 	r.Get("/{id}", h.dashboardSchedule)
+	r.Get("/{id}/classes.ics", h.classesICS)
+	r.Get("/{id}/today", h.today)
+	r.Get("/{id}/today.json", h.todayJSON)
 
 	return r
 }
@@ -148,6 +153,33 @@ func (h *Handler) dashboardSchedule(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// classesICS downloads the classes of the schedule as recurring calendar events.
+func (h *Handler) classesICS(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := utils.MustExtractUserID(r)
+
+	scheduleID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.Redirect(w, r, "/404")
+		return
+	}
+
+	classes, err := h.scheduleService.GetClassCalendar(ctx, userID, scheduleModel.ScheduleID(scheduleID))
+	if err != nil {
+		if !errors.Is(err, schedule.ErrNotFound) && !errors.Is(err, schedule.ErrPermissionDenied) {
+			logger.Error("Failed to build class calendar", "scheduleID", scheduleID, "error", err)
+		}
+		h.handleOverviewError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="clases-%d.ics"`, scheduleID))
+	if err := writeClassesICS(w, scheduleModel.ScheduleID(scheduleID), classes, time.Now()); err != nil {
+		logger.Error("Failed to write class calendar", "scheduleID", scheduleID, "error", err)
+	}
+}
+
 // handleOverviewError logs errors and triggers appropriate HTMX redirects using utils.Redirect.
 func (h *Handler) handleOverviewError(w http.ResponseWriter, r *http.Request, err error) {
 	switch {
@@ -0,0 +1,143 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	utils "github.com/elias-gill/poliplanner2/internal/http"
+	scheduleModel "github.com/elias-gill/poliplanner2/internal/model/schedule"
+	"github.com/elias-gill/poliplanner2/internal/service/schedule"
+	"github.com/elias-gill/poliplanner2/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+// today renders the "today" widget of the schedule. It is polled by the dashboard to keep the
+// countdown up to date.
+func (h *Handler) today(w http.ResponseWriter, r *http.Request) {
+	view, ok := h.loadToday(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.tmpl.RenderPartial(w, "dashboard/index.html", "dashboard/schedule_today", view)
+	if err != nil {
+		logger.Error("Failed to render today partial", "error", err)
+	}
+}
+
+// todayJSON returns the "today" widget as JSON. Times are absolute, so clients that read it
+// from the offline cache can still recalculate the countdown.
+func (h *Handler) todayJSON(w http.ResponseWriter, r *http.Request) {
+	view, ok := h.loadToday(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(newTodayResponse(view)); err != nil {
+		logger.Error("Failed to encode today response", "error", err)
+	}
+}
+
+func (h *Handler) loadToday(w http.ResponseWriter, r *http.Request) (*scheduleModel.TodayView, bool) {
+	userID := utils.MustExtractUserID(r)
+
+	scheduleID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.Redirect(w, r, "/404")
+		return nil, false
+	}
+
+	now := time.Now().In(timezone.ParaguayTZ)
+	view, err := h.scheduleService.GetToday(r.Context(), userID, scheduleModel.ScheduleID(scheduleID), now)
+	if err != nil {
+		if !errors.Is(err, schedule.ErrNotFound) && !errors.Is(err, schedule.ErrPermissionDenied) {
+			logger.Error("Failed to build today view", "scheduleID", scheduleID, "error", err)
+		}
+		h.handleOverviewError(w, r, err)
+		return nil, false
+	}
+
+	return view, true
+}
+
+type todayResponse struct {
+	Now       time.Time       `json:"now"`
+	DayOff    *dayOffResponse `json:"day_off"`
+	Remaining []classResponse `json:"remaining"`
+	Next      *classResponse  `json:"next"`
+	Exams     []examResponse  `json:"exams"`
+}
+
+type dayOffResponse struct {
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+}
+
+type classResponse struct {
+	Course     string    `json:"course"`
+	Room       string    `json:"room"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	InProgress bool      `json:"in_progress"`
+}
+
+type examResponse struct {
+	Course   string    `json:"course"`
+	Label    string    `json:"label"`
+	Date     time.Time `json:"date"`
+	HasHour  bool      `json:"has_hour"`
+	Room     string    `json:"room"`
+	DaysLeft int       `json:"days_left"`
+}
+
+func newTodayResponse(view *scheduleModel.TodayView) todayResponse {
+	res := todayResponse{
+		Now:       view.Now,
+		Remaining: make([]classResponse, len(view.Remaining)),
+		Exams:     make([]examResponse, len(view.Exams)),
+	}
+
+	if view.DayOff != nil {
+		res.DayOff = &dayOffResponse{
+			Kind:        view.DayOff.Kind.String(),
+			Description: view.DayOff.Description,
+		}
+	}
+
+	for i, c := range view.Remaining {
+		res.Remaining[i] = newClassResponse(c)
+	}
+
+	if view.Next != nil {
+		next := newClassResponse(*view.Next)
+		res.Next = &next
+	}
+
+	for i, e := range view.Exams {
+		res.Exams[i] = examResponse{
+			Course:   e.Course,
+			Label:    e.Label,
+			Date:     e.Date,
+			HasHour:  e.HasHour,
+			Room:     e.Room,
+			DaysLeft: e.DaysLeft,
+		}
+	}
+
+	return res
+}
+
+func newClassResponse(c scheduleModel.ClassOccurrenceView) classResponse {
+	return classResponse{
+		Course:     c.Course,
+		Room:       c.Room.String(),
+		Start:      c.Start,
+		End:        c.End,
+		InProgress: c.InProgress,
+	}
+}
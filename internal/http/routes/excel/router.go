@@ -2,7 +2,10 @@ package excel
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"html"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,31 +13,39 @@ import (
 
 	"github.com/elias-gill/poliplanner2/internal/config"
 	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	utils "github.com/elias-gill/poliplanner2/internal/http"
 	"github.com/elias-gill/poliplanner2/internal/infrastructure/source"
 	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	excelModel "github.com/elias-gill/poliplanner2/internal/model/excel"
 	render "github.com/elias-gill/poliplanner2/internal/render/html"
 	"github.com/elias-gill/poliplanner2/internal/service/excel"
 	"github.com/elias-gill/poliplanner2/logger"
 	"github.com/go-chi/chi/v5"
 )
 
-const maxUploadSize = 8 << 20 // 8 MiB
+const (
+	maxUploadSize = 8 << 20 // 8 MiB
+	maxLayoutSize = 1 << 20 // 1 MiB
+)
 
 type Handler struct {
-	tmpl         *render.TemplateManager
-	excelService *excel.ExcelService
-	syncService  *excel.SyncService
+	tmpl          *render.TemplateManager
+	excelService  *excel.ExcelService
+	syncService   *excel.SyncService
+	layoutService *excel.LayoutService
 }
 
 func NewHandler(
 	tmpl *render.TemplateManager,
 	excelService *excel.ExcelService,
 	syncService *excel.SyncService,
+	layoutService *excel.LayoutService,
 ) *Handler {
 	return &Handler{
-		tmpl:         tmpl,
-		excelService: excelService,
-		syncService:  syncService,
+		tmpl:          tmpl,
+		excelService:  excelService,
+		syncService:   syncService,
+		layoutService: layoutService,
 	}
 }
 
@@ -44,12 +55,22 @@ func (h *Handler) Routes() chi.Router {
 	r.Get("/", h.syncForm)
 	r.Post("/sync", h.sync)
 	r.Get("/list", h.listVersions) // <-- Nuevo endpoint para listar las versiones
+	r.Get("/versions/{id}/report", h.versionReport)
+	r.Get("/versions/{a}/diff/{b}", h.diffVersions)
+	r.Post("/versions/{id}/rollback", h.rollback)
+	r.Post("/versions/{id}/reparse", h.reparse)
+	r.Get("/headers", h.listUnmatchedHeaders)
+	r.Get("/headers/{id}", h.layoutDraft)
+	r.Get("/headers/{id}/draft.json", h.downloadLayoutDraft)
+	r.Get("/layouts", h.listLayouts)
+	r.Post("/layouts", h.uploadLayout)
+	r.Post("/layouts/{kind}/{name}/enable", h.enableLayout)
+	r.Post("/layouts/{kind}/{name}/disable", h.disableLayout)
 
 	return r
 }
 
 type handlerConfig struct {
-	updateKey      string
 	scraperTimeout time.Duration
 }
 
@@ -57,7 +78,6 @@ type handlerConfig struct {
 func (h *Handler) getConfig() handlerConfig {
 	cfg := config.Get()
 	return handlerConfig{
-		updateKey:      cfg.Security.UpdateKey,
 		scraperTimeout: cfg.Excel.ScraperTimeout,
 	}
 }
@@ -74,7 +94,7 @@ func (h *Handler) syncForm(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) sync(w http.ResponseWriter, r *http.Request) {
-	if !h.isAuthorized(r.Header.Get("Authorization")) {
+	if !utils.IsAdminRequest(r) {
 		http.Error(w, "Unauthorized", http.StatusForbidden)
 		return
 	}
@@ -102,9 +122,23 @@ func (h *Handler) listVersions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Each version with a snapshot is compared against the previous one that has it too
+	previous := make(map[excelModel.SheetVersionID]excelModel.SheetVersionID)
+	var newer *excelModel.SheetVersion
+	for _, v := range versions {
+		if !v.HasSnapshot {
+			continue
+		}
+		if newer != nil {
+			previous[newer.ID] = v.ID
+		}
+		newer = v
+	}
+
 	data := map[string]any{
 		"Versions": versions,
 		"LastSync": lastSync,
+		"Previous": previous,
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -113,11 +147,346 @@ func (h *Handler) listVersions(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// versionReport renders the import report of a version, loaded when it is expanded on the
+// list.
+func (h *Handler) versionReport(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid version", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.excelService.GetReport(r.Context(), excelModel.SheetVersionID(id))
+	if err != nil {
+		if errors.Is(err, excel.ErrNoReport) {
+			http.Error(w, "La versión no tiene reporte de importación", http.StatusNotFound)
+			return
+		}
+		logger.Error("Error getting import report", "version", id, "error", err)
+		http.Error(w, "No se pudo obtener el reporte de importación", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := h.tmpl.RenderPartial(w, "excel/list-versions.html", "excel/import_report", report); err != nil {
+		logger.Error("Cannot render import report", "error", err)
+	}
+}
+
+// diffVersions lists what changed on the academic data from version {a} to version {b}.
+func (h *Handler) diffVersions(w http.ResponseWriter, r *http.Request) {
+	from, errA := strconv.ParseInt(chi.URLParam(r, "a"), 10, 64)
+	to, errB := strconv.ParseInt(chi.URLParam(r, "b"), 10, 64)
+	if errA != nil || errB != nil {
+		utils.Redirect(w, r, "/404")
+		return
+	}
+
+	diff, err := h.excelService.DiffVersions(r.Context(), excelModel.SheetVersionID(from), excelModel.SheetVersionID(to))
+	if err != nil {
+		switch {
+		case errors.Is(err, excel.ErrNoSheetVersion):
+			utils.Redirect(w, r, "/404")
+		case errors.Is(err, excel.ErrNoSnapshot):
+			http.Error(w, "Solo se pueden comparar versiones importadas correctamente", http.StatusUnprocessableEntity)
+		default:
+			logger.Error("Error comparing excel versions", "from", from, "to", to, "error", err)
+			utils.Redirect(w, r, "/500")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := h.tmpl.RenderPage(w, "excel/version-diff.html", diff); err != nil {
+		logger.Error("Cannot render version-diff template", "error", err)
+	}
+}
+
+// rollback restores the academic data of the current period to an earlier version. A rollback
+// that removes courses saved on schedules of students answers with a conflict telling how
+// many, and has to be sent again with "confirm=1".
+func (h *Handler) rollback(w http.ResponseWriter, r *http.Request) {
+	if !utils.IsAdminRequest(r) {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid version", http.StatusBadRequest)
+		return
+	}
+
+	confirmed := r.URL.Query().Get("confirm") == "1"
+
+	version, err := h.excelService.Rollback(r.Context(), excelModel.SheetVersionID(id), confirmed)
+	if err != nil {
+		var impactErr excel.RollbackImpactError
+		switch {
+		case errors.As(err, &impactErr):
+			http.Error(w, fmt.Sprintf(
+				"Se eliminarían %d secciones agregadas después de la versión #%d, guardadas en %d horarios de estudiantes. Esas secciones se quitan de los horarios.",
+				impactErr.Impact.RemovedCourses, id, impactErr.Impact.AffectedSchedules), http.StatusConflict)
+		case errors.Is(err, excel.ErrNoSheetVersion):
+			http.Error(w, "La versión no existe", http.StatusNotFound)
+		case errors.Is(err, excel.ErrNoSnapshot):
+			http.Error(w, "Solo se pueden restaurar versiones importadas correctamente", http.StatusUnprocessableEntity)
+		case errors.Is(err, excel.ErrRollbackPeriod):
+			http.Error(w, "La versión pertenece a otro período", http.StatusUnprocessableEntity)
+		default:
+			logger.Error("Error restoring excel version", "version", id, "error", err)
+			http.Error(w, "Rollback failed: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	respondHTML(w, http.StatusOK, fmt.Sprintf("Versión #%d restaurada como #%d", id, version.ID))
+}
+
+// reparse imports again the archived file of a version.
+func (h *Handler) reparse(w http.ResponseWriter, r *http.Request) {
+	if !utils.IsAdminRequest(r) {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid version", http.StatusBadRequest)
+		return
+	}
+
+	version, err := h.excelService.Reparse(r.Context(), excelModel.SheetVersionID(id))
+	if err != nil {
+		switch {
+		case errors.Is(err, excel.ErrNoSheetVersion):
+			http.Error(w, "La versión no existe", http.StatusNotFound)
+		case errors.Is(err, excel.ErrNoArchive):
+			http.Error(w, "El archivo de la versión ya no está guardado", http.StatusUnprocessableEntity)
+		case version != nil:
+			// The attempt is on the history, with its report
+			http.Error(w, fmt.Sprintf("La versión #%d falló: %s", version.ID, err), http.StatusBadRequest)
+		default:
+			logger.Error("Error reparsing excel version", "version", id, "error", err)
+			http.Error(w, "Reparse failed: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	respondHTML(w, http.StatusOK, fmt.Sprintf("Versión #%d procesada de nuevo como #%d", id, version.ID))
+}
+
+// listUnmatchedHeaders lists the sheet headers that no layout matched.
+func (h *Handler) listUnmatchedHeaders(w http.ResponseWriter, r *http.Request) {
+	headers, err := h.excelService.ListUnmatchedHeaders(r.Context())
+	if err != nil {
+		logger.Error("Error listing unmatched headers", "error", err)
+		http.Error(w, "No se pudieron obtener los encabezados sin formato", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := h.tmpl.RenderPage(w, "excel/unmatched-headers.html", headers); err != nil {
+		logger.Error("Cannot render unmatched-headers template", "error", err)
+	}
+}
+
+// layoutDraft compares an unmatched header with the closest layout and shows a draft layout
+// for it.
+func (h *Handler) layoutDraft(w http.ResponseWriter, r *http.Request) {
+	draft, ok := h.getLayoutDraft(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := h.tmpl.RenderPage(w, "excel/layout-draft.html", draft); err != nil {
+		logger.Error("Cannot render layout-draft template", "error", err)
+	}
+}
+
+// downloadLayoutDraft serves the draft layout of an unmatched header as a layout file.
+func (h *Handler) downloadLayoutDraft(w http.ResponseWriter, r *http.Request) {
+	draft, ok := h.getLayoutDraft(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="formato_%d.json"`, draft.Header.ID))
+	fmt.Fprint(w, draft.JSON)
+}
+
+// layoutGroup lists the parser layouts of a kind of workbook.
+type layoutGroup struct {
+	Kind    string // Kind on the URLs, "schedules" or "labs"
+	Title   string
+	Layouts []*excelModel.ParserLayout
+}
+
+// listLayouts lists the parser layouts of every kind of workbook, with the forms to upload and
+// enable them.
+func (h *Handler) listLayouts(w http.ResponseWriter, r *http.Request) {
+	var groups []layoutGroup
+
+	for _, kind := range []string{"schedules", "labs"} {
+		sourceKind, _ := parseLayoutKind(kind)
+
+		layouts, err := h.layoutService.ListLayouts(r.Context(), sourceKind)
+		if err != nil {
+			logger.Error("Error listing parser layouts", "kind", kind, "error", err)
+			http.Error(w, "No se pudieron obtener los formatos", http.StatusInternalServerError)
+			return
+		}
+
+		groups = append(groups, layoutGroup{Kind: kind, Title: sourceKind.String(), Layouts: layouts})
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := h.tmpl.RenderPage(w, "excel/layouts.html", groups); err != nil {
+		logger.Error("Cannot render layouts template", "error", err)
+	}
+}
+
+// uploadLayout validates an uploaded layout file and saves it enabled, or only validates it
+// when the form asks for it.
+func (h *Handler) uploadLayout(w http.ResponseWriter, r *http.Request) {
+	if !utils.IsAdminRequest(r) {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxLayoutSize)
+	if err := r.ParseMultipartForm(maxLayoutSize); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	kind, ok := parseLayoutKind(r.FormValue("kind"))
+	if !ok {
+		http.Error(w, "Invalid kind, must be schedules or labs", http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Layout file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, "Cannot read the layout file", http.StatusBadRequest)
+		return
+	}
+
+	// Without a name the layout keeps the one of the file, replacing the layout with that name
+	name := strings.TrimSpace(r.FormValue("name"))
+	if name == "" {
+		name = header.Filename
+	}
+
+	var layout *excelModel.ParserLayout
+	if r.FormValue("validate") == "true" {
+		layout, err = h.layoutService.Validate(kind, name, data)
+	} else {
+		layout, err = h.layoutService.Upload(r.Context(), kind, name, data)
+	}
+	if errors.Is(err, excel.ErrInvalidLayout) {
+		http.Error(w, "Could not validate the layout: "+err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		logger.Error("Error uploading parser layout", "name", name, "error", err)
+		http.Error(w, "Could not save the layout: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if r.FormValue("validate") == "true" {
+		respondHTML(w, http.StatusOK, fmt.Sprintf("El formato %s es válido, con %d columnas", html.EscapeString(layout.Name), len(layout.Headers)))
+		return
+	}
+	respondHTML(w, http.StatusOK, fmt.Sprintf("Formato %s guardado y habilitado", html.EscapeString(layout.Name)))
+}
+
+func (h *Handler) enableLayout(w http.ResponseWriter, r *http.Request) {
+	h.setLayoutEnabled(w, r, true)
+}
+
+func (h *Handler) disableLayout(w http.ResponseWriter, r *http.Request) {
+	h.setLayoutEnabled(w, r, false)
+}
+
 // ==================== Helper methods ====================
 
-func (h *Handler) isAuthorized(authHeader string) bool {
-	cfg := h.getConfig()
-	return strings.TrimSpace(authHeader) == "Bearer "+cfg.updateKey
+// setLayoutEnabled enables or disables the layout on the URL.
+func (h *Handler) setLayoutEnabled(w http.ResponseWriter, r *http.Request, enabled bool) {
+	if !utils.IsAdminRequest(r) {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	kind, ok := parseLayoutKind(chi.URLParam(r, "kind"))
+	if !ok {
+		http.Error(w, "Invalid kind, must be schedules or labs", http.StatusBadRequest)
+		return
+	}
+	name := chi.URLParam(r, "name")
+
+	err := h.layoutService.SetEnabled(r.Context(), kind, name, enabled)
+	if err != nil {
+		switch {
+		case errors.Is(err, excel.ErrNoLayout):
+			http.Error(w, "El formato no existe", http.StatusNotFound)
+		case errors.Is(err, excel.ErrLastLayout):
+			http.Error(w, "No se puede deshabilitar el único formato habilitado", http.StatusUnprocessableEntity)
+		default:
+			logger.Error("Error changing parser layout state", "name", name, "error", err)
+			http.Error(w, "Could not change the layout: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	state := "deshabilitado"
+	if enabled {
+		state = "habilitado"
+	}
+	respondHTML(w, http.StatusOK, fmt.Sprintf("Formato %s %s", html.EscapeString(name), state))
+}
+
+// parseLayoutKind reads the kind of workbook of the layouts from the URLs and forms.
+func parseLayoutKind(kind string) (excelModel.SourceKind, bool) {
+	switch kind {
+	case "schedules":
+		return excelModel.SourceSchedules, true
+	case "labs":
+		return excelModel.SourceLabs, true
+	}
+	return 0, false
+}
+
+// getLayoutDraft builds the draft of the header on the URL, writing the error response when
+// it fails.
+func (h *Handler) getLayoutDraft(w http.ResponseWriter, r *http.Request) (*excelModel.LayoutDraft, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.Redirect(w, r, "/404")
+		return nil, false
+	}
+
+	draft, err := h.excelService.LayoutDraft(r.Context(), excelModel.UnmatchedHeaderID(id))
+	if err != nil {
+		if errors.Is(err, excel.ErrNoUnmatchedHeader) {
+			utils.Redirect(w, r, "/404")
+			return nil, false
+		}
+		logger.Error("Error drafting layout", "header", id, "error", err)
+		utils.Redirect(w, r, "/500")
+		return nil, false
+	}
+
+	return draft, true
 }
 
 func (h *Handler) handleUpload(w http.ResponseWriter, r *http.Request) {
@@ -153,7 +522,27 @@ func (h *Handler) handleUpload(w http.ResponseWriter, r *http.Request) {
 		Date:     time.Now().In(timezone.ParaguayTZ),
 	})
 
-	if err := h.excelService.PersistSource(r.Context(), src); err != nil {
+	labs := r.FormValue("kind") == "labs"
+
+	if r.FormValue("dryRun") == "true" {
+		if labs {
+			http.Error(w, "Only schedule files can be validated", http.StatusBadRequest)
+			return
+		}
+		h.handleDryRun(w, r, src)
+		return
+	}
+
+	if labs {
+		err = h.excelService.PersistLabSource(r.Context(), src)
+	} else {
+		err = h.excelService.PersistSource(r.Context(), src)
+	}
+	if errors.Is(err, excel.ErrAlreadyImported) {
+		http.Error(w, "El archivo ya fue importado ("+err.Error()+"), se puede procesar de nuevo desde el historial", http.StatusConflict)
+		return
+	}
+	if err != nil {
 		http.Error(w, "Could not process the file: "+err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -161,6 +550,20 @@ func (h *Handler) handleUpload(w http.ResponseWriter, r *http.Request) {
 	respondHTML(w, http.StatusOK, "File processed successfully")
 }
 
+// handleDryRun validates the uploaded file and renders the report, without importing it.
+func (h *Handler) handleDryRun(w http.ResponseWriter, r *http.Request, src source.ScheduleSource) {
+	report, err := h.excelService.DryRun(r.Context(), src)
+	if err != nil {
+		http.Error(w, "Could not read the file: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := h.tmpl.RenderPartial(w, "excel/sync-form.html", "excel/dry_run_report", report); err != nil {
+		logger.Error("Cannot render dry run report", "error", err)
+	}
+}
+
 func (h *Handler) handleSync(w http.ResponseWriter, r *http.Request) {
 	cfg := h.getConfig()
 	ctx, cancel := context.WithTimeout(r.Context(), cfg.scraperTimeout)
@@ -0,0 +1,191 @@
+package periods
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	utils "github.com/elias-gill/poliplanner2/internal/http"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	render "github.com/elias-gill/poliplanner2/internal/render/html"
+	academicService "github.com/elias-gill/poliplanner2/internal/service/academic"
+	"github.com/elias-gill/poliplanner2/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+const dateLayout = "2006-01-02"
+
+// Handler handles the admin screen of the academic period boundaries.
+type Handler struct {
+	tmpl          *render.TemplateManager
+	periodService *academicService.PeriodService
+}
+
+// NewHandler constructs a new Handler instance.
+func NewHandler(tmpl *render.TemplateManager, periodService *academicService.PeriodService) *Handler {
+	return &Handler{
+		tmpl:          tmpl,
+		periodService: periodService,
+	}
+}
+
+// Routes sets up the HTTP router for the periods endpoints.
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.index)
+	r.Post("/", h.save)
+
+	return r
+}
+
+// boundaryField is a pair of date inputs of the period form.
+type boundaryField struct {
+	Name  string // Prefix of the input names
+	Label string
+	Start string
+	End   string
+}
+
+type periodRow struct {
+	Period academic.Period
+	Fields []boundaryField
+}
+
+// index renders every period along with its boundaries, and an empty form to load the next
+// one.
+func (h *Handler) index(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	periods, err := h.periodService.ListAllPeriods(ctx)
+	if err != nil {
+		logger.Error("cannot list periods", "error", err)
+		utils.Redirect(w, r, "/500")
+		return
+	}
+
+	current, err := h.periodService.CalculateCurrentPeriod(ctx)
+	if err != nil {
+		logger.Error("cannot calculate current period", "error", err)
+		utils.Redirect(w, r, "/500")
+		return
+	}
+
+	rows := make([]periodRow, len(periods))
+	for i, p := range periods {
+		rows[i] = periodRow{Period: p, Fields: boundaryFields(p)}
+	}
+
+	data := map[string]any{
+		"Periods":       rows,
+		"CurrentPeriod": current,
+		"NewFields":     boundaryFields(academic.Period{}),
+		"Year":          time.Now().In(timezone.ParaguayTZ).Year(),
+	}
+
+	if err := h.tmpl.RenderPage(w, "periods/index.html", data); err != nil {
+		logger.Error("cannot render periods", "error", err)
+	}
+}
+
+// save stores the boundaries of a period. Expects "year", "semester" and the date pairs of
+// boundaryFields as form values, and the admin key as bearer token.
+func (h *Handler) save(w http.ResponseWriter, r *http.Request) {
+	if !utils.IsAdminRequest(r) {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	period, err := parsePeriod(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id, err := h.periodService.SaveBoundaries(r.Context(), period)
+	if err != nil {
+		if errors.Is(err, academicService.ErrInvalidBoundaries) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		logger.Error("cannot save period boundaries", "period", period.String(), "error", err)
+		http.Error(w, "Save failed", http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info("period boundaries saved", "period", id, "start", period.Span.Start, "end", period.Span.End)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ==================== Helpers ====================
+
+var errInvalidDate = errors.New("invalid date, expected YYYY-MM-DD")
+
+func boundaryFields(p academic.Period) []boundaryField {
+	return []boundaryField{
+		{Name: "periodo", Label: "Período", Start: formatDate(p.Span.Start), End: formatDate(p.Span.End)},
+		{Name: "clases", Label: "Clases", Start: formatDate(p.Classes.Start), End: formatDate(p.Classes.End)},
+		{Name: "parciales", Label: "Parciales", Start: formatDate(p.Partials.Start), End: formatDate(p.Partials.End)},
+		{Name: "finales", Label: "Finales", Start: formatDate(p.Finals.Start), End: formatDate(p.Finals.End)},
+	}
+}
+
+func parsePeriod(r *http.Request) (academic.Period, error) {
+	year, err := strconv.Atoi(r.FormValue("year"))
+	if err != nil || year < 2000 {
+		return academic.Period{}, errors.New("invalid year")
+	}
+
+	semester, err := strconv.Atoi(r.FormValue("semester"))
+	if err != nil {
+		return academic.Period{}, errors.New("invalid semester")
+	}
+
+	period := academic.Period{Year: year, Semester: academic.YearSemester(semester)}
+
+	ranges := map[string]*academic.DateRange{
+		"periodo":   &period.Span,
+		"clases":    &period.Classes,
+		"parciales": &period.Partials,
+		"finales":   &period.Finals,
+	}
+	for name, rng := range ranges {
+		if rng.Start, err = parseDate(r.FormValue(name + "_inicio")); err != nil {
+			return academic.Period{}, err
+		}
+		if rng.End, err = parseDate(r.FormValue(name + "_fin")); err != nil {
+			return academic.Period{}, err
+		}
+	}
+
+	return period, nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.ParseInLocation(dateLayout, value, timezone.ParaguayTZ)
+	if err != nil {
+		return time.Time{}, errInvalidDate
+	}
+
+	return t, nil
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
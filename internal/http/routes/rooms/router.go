@@ -0,0 +1,155 @@
+package rooms
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	utils "github.com/elias-gill/poliplanner2/internal/http"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	render "github.com/elias-gill/poliplanner2/internal/render/html"
+	academicService "github.com/elias-gill/poliplanner2/internal/service/academic"
+	"github.com/elias-gill/poliplanner2/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+// Handler handles HTTP requests related to the rooms directory.
+type Handler struct {
+	tmpl        *render.TemplateManager
+	roomService *academicService.RoomService
+}
+
+// NewHandler constructs a new Handler instance.
+func NewHandler(tmpl *render.TemplateManager, roomService *academicService.RoomService) *Handler {
+	return &Handler{
+		tmpl:        tmpl,
+		roomService: roomService,
+	}
+}
+
+// Routes sets up the HTTP router for the rooms endpoints.
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.index)
+	r.Get("/free", h.freeRooms)
+	r.Get("/{code}", h.roomDetail)
+
+	return r
+}
+
+// RoomBuildingGroup groups the rooms index by building for the directory page.
+type RoomBuildingGroup struct {
+	Building string
+	Rooms    []academic.RoomSummaryView
+}
+
+// index renders the rooms directory along with the free rooms finder.
+func (h *Handler) index(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	rooms, err := h.roomService.ListRooms(ctx)
+	if err != nil {
+		logger.Error("cannot list rooms", "error", err)
+		utils.Redirect(w, r, "/500")
+		return
+	}
+
+	// Rooms are already sorted by building
+	var groups []RoomBuildingGroup
+	for _, room := range rooms {
+		if len(groups) == 0 || groups[len(groups)-1].Building != room.Building {
+			groups = append(groups, RoomBuildingGroup{Building: room.Building})
+		}
+		groups[len(groups)-1].Rooms = append(groups[len(groups)-1].Rooms, room)
+	}
+
+	now := time.Now().In(timezone.ParaguayTZ)
+	data := map[string]any{
+		"Groups": groups,
+		"Date":   now.Format("2006-01-02"),
+		"Hour":   now.Format("15:04"),
+	}
+
+	if err := h.tmpl.RenderPage(w, "rooms/index.html", data); err != nil {
+		logger.Error("cannot render rooms index", "error", err)
+	}
+}
+
+// freeRooms renders the list of rooms without classes nor exams at the requested moment.
+// Accepts "date" (YYYY-MM-DD) and "hour" (HH:MM) query params, using the current time for
+// missing values.
+func (h *Handler) freeRooms(w http.ResponseWriter, r *http.Request) {
+	now := time.Now().In(timezone.ParaguayTZ)
+	at := now
+
+	dateStr := r.URL.Query().Get("date")
+	hourStr := r.URL.Query().Get("hour")
+	if dateStr != "" || hourStr != "" {
+		if dateStr == "" {
+			dateStr = now.Format("2006-01-02")
+		}
+		if hourStr == "" {
+			hourStr = now.Format("15:04")
+		}
+
+		parsed, err := time.ParseInLocation("2006-01-02 15:04", dateStr+" "+hourStr, timezone.ParaguayTZ)
+		if err != nil {
+			http.Error(w, "Fecha u hora inválida", http.StatusBadRequest)
+			return
+		}
+		at = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	rooms, err := h.roomService.FreeRooms(ctx, at)
+	if err != nil {
+		logger.Error("cannot list free rooms", "at", at, "error", err)
+		http.Error(w, "Error al buscar aulas libres", http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]any{
+		"Rooms": rooms,
+		"At":    at,
+		"Day":   academic.WeekDay(at.Weekday()),
+	}
+
+	if err := h.tmpl.RenderPartial(w, "rooms/index.html", "rooms/free_rooms", data); err != nil {
+		logger.Error("cannot render free rooms partial", "error", err)
+		http.Error(w, "Error al renderizar la plantilla", http.StatusInternalServerError)
+	}
+}
+
+// roomDetail renders the weekly occupancy grid and the exams of a single room.
+func (h *Handler) roomDetail(w http.ResponseWriter, r *http.Request) {
+	code, err := url.PathUnescape(chi.URLParam(r, "code"))
+	if err != nil {
+		utils.Redirect(w, r, "/404")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	room, err := h.roomService.GetRoom(ctx, code)
+	if err != nil {
+		if errors.Is(err, academicService.ErrRoomNotFound) {
+			utils.Redirect(w, r, "/404")
+			return
+		}
+		logger.Error("cannot get room detail", "code", code, "error", err)
+		utils.Redirect(w, r, "/500")
+		return
+	}
+
+	if err := h.tmpl.RenderPage(w, "rooms/detail.html", room); err != nil {
+		logger.Error("cannot render room detail", "code", code, "error", err)
+	}
+}
@@ -29,6 +29,7 @@ type Handler struct {
 	careerService     *academicSrvs.CareerService
 	courseService     *academicSrvs.CourseService
 	curriculumService *academicSrvs.CurriculumService
+	periodService     *academicSrvs.PeriodService
 }
 
 func NewHandler(
@@ -37,6 +38,7 @@ func NewHandler(
 	careerService *academicSrvs.CareerService,
 	courseService *academicSrvs.CourseService,
 	curriculumService *academicSrvs.CurriculumService,
+	periodService *academicSrvs.PeriodService,
 ) *Handler {
 	return &Handler{
 		tmpl:              tmpl,
@@ -44,6 +46,7 @@ func NewHandler(
 		careerService:     careerService,
 		courseService:     courseService,
 		curriculumService: curriculumService,
+		periodService:     periodService,
 	}
 }
 
@@ -80,9 +83,25 @@ func (h *Handler) index(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	periods, err := h.periodService.ListPeriods(ctx)
+	if err != nil {
+		logger.Error("cannot list periods for index schedule page", "error", err)
+		utils.Redirect(w, r, "/500")
+		return
+	}
+
+	current, err := h.periodService.CalculateCurrentPeriod(ctx)
+	if err != nil {
+		logger.Error("cannot calculate current period for index schedule page", "error", err)
+		utils.Redirect(w, r, "/500")
+		return
+	}
+
 	data := map[string]any{
-		"Title":   "Crear Horario",
-		"Careers": careers,
+		"Title":         "Crear Horario",
+		"Careers":       careers,
+		"Periods":       periods,
+		"CurrentPeriod": current,
 	}
 
 	if err := h.tmpl.RenderPage(w, "schedules/index.html", data); err != nil {
@@ -107,6 +126,17 @@ func (h *Handler) listCurriculum(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 300*time.Millisecond)
 	defer cancel()
 
+	period, current, err := h.requestedPeriod(ctx, r)
+	if err != nil {
+		if errors.Is(err, errInvalidPeriod) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		logger.Error("cannot resolve requested period", "error", err)
+		utils.Redirect(w, r, "/500")
+		return
+	}
+
 	curriculum, err := h.curriculumService.GetCurriculum(ctx, academic.CareerID(careerID))
 	if err != nil {
 		logger.Error("cannot retrieve curriculum subjects", "error", err)
@@ -120,6 +150,8 @@ func (h *Handler) listCurriculum(w http.ResponseWriter, r *http.Request) {
 		"Levels":     curriculum.Levels,
 		"Subjects":   curriculum.Subjects,
 		"Semesters":  curriculum.Semesters,
+		"PeriodID":   period,
+		"ReadOnly":   !current,
 	}
 
 	// FIX: cuando renderizo el partial, deberia de redirigir a la pagina principal de la que
@@ -146,17 +178,39 @@ func (h *Handler) listOffering(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
 	defer cancel()
 
-	courses, err := h.courseService.GetOfferings(ctx, academic.CurriculumID(mallaID))
+	period, current, err := h.requestedPeriod(ctx, r)
+	if err != nil {
+		if errors.Is(err, errInvalidPeriod) {
+			http.Error(w, "Periodo inválido", http.StatusBadRequest)
+			return
+		}
+		logger.Error("cannot resolve requested period", "error", err)
+		http.Error(w, "Error al obtener secciones", http.StatusInternalServerError)
+		return
+	}
+
+	courses, err := h.courseService.GetOfferings(ctx, academic.CurriculumID(mallaID), period)
 	if err != nil {
 		logger.Error("cannot get course offerings", "malla_id", mallaID, "error", err)
 		http.Error(w, "Error al obtener secciones", http.StatusInternalServerError)
 		return
 	}
 
+	// The modality filter is only useful when at least one section has virtual sessions
+	hasVirtual := false
+	for _, c := range courses {
+		if m := c.Modality(); m == academic.ModalityVirtual || m == academic.ModalityHybrid {
+			hasVirtual = true
+			break
+		}
+	}
+
 	data := map[string]any{
 		"Courses":     courses,
 		"CareerCode":  careerCode,
 		"SubjectName": subjectName,
+		"HasVirtual":  hasVirtual,
+		"ReadOnly":    !current,
 	}
 
 	if err := h.tmpl.RenderPartial(w, "schedules/index.html", "course_offerings", data); err != nil {
@@ -359,3 +413,39 @@ func (h *Handler) downloadPDF(w http.ResponseWriter, r *http.Request) {
 		logger.Error("error al generar o escribir el PDF", "schedule_id", scheduleID, "error", err)
 	}
 }
+
+// ======================================
+// =              Helpers               =
+// ======================================
+
+var errInvalidPeriod = errors.New("invalid period")
+
+// requestedPeriod returns the period selected with the "period" query param, defaulting to the
+// current one. Also reports if it is the current period, as only those courses can be added
+// to a new schedule.
+func (h *Handler) requestedPeriod(ctx context.Context, r *http.Request) (academic.PeriodID, bool, error) {
+	current, err := h.periodService.CalculateCurrentPeriod(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	raw := r.URL.Query().Get("period")
+	if raw == "" {
+		return current, true, nil
+	}
+
+	id, err := utils.ParseID(raw)
+	if err != nil {
+		return 0, false, errInvalidPeriod
+	}
+
+	period, err := h.periodService.GetPeriod(ctx, academic.PeriodID(id))
+	if err != nil {
+		if errors.Is(err, academicSrvs.ErrPeriodNotFound) {
+			return 0, false, errInvalidPeriod
+		}
+		return 0, false, err
+	}
+
+	return period.ID, period.ID == current, nil
+}
@@ -0,0 +1,64 @@
+package schedules
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	sqlite "github.com/elias-gill/poliplanner2/internal/infrastructure/persistence/sqlite/academic"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/persistence/sqlite/sqlitetest"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	academicSrvs "github.com/elias-gill/poliplanner2/internal/service/academic"
+)
+
+func TestRequestedPeriod(t *testing.T) {
+	ctx := context.Background()
+	periods := sqlite.NewPeriodRepository(sqlitetest.Open(t))
+	h := &Handler{periodService: academicSrvs.NewPeriodService(periods)}
+
+	current, err := h.periodService.CalculateCurrentPeriod(ctx)
+	if err != nil {
+		t.Fatalf("CalculateCurrentPeriod() error = %v", err)
+	}
+	older, err := periods.Upsert(ctx, academic.Period{Year: 2020, Semester: academic.FirstSemester})
+	if err != nil {
+		t.Fatalf("cannot create period: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		period    academic.PeriodID
+		isCurrent bool
+		invalid   bool
+	}{
+		{name: "default", query: "", period: current, isCurrent: true},
+		{name: "current", query: "?period=" + strconv.FormatInt(int64(current), 10), period: current, isCurrent: true},
+		{name: "older", query: "?period=" + strconv.FormatInt(int64(older), 10), period: older},
+		{name: "not a number", query: "?period=abc", invalid: true},
+		{name: "not an id", query: "?period=0", invalid: true},
+		{name: "unknown", query: "?period=9999", invalid: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/subjects"+tc.query, nil)
+
+			period, isCurrent, err := h.requestedPeriod(ctx, r)
+			if tc.invalid {
+				if !errors.Is(err, errInvalidPeriod) {
+					t.Errorf("requestedPeriod() error = %v; want errInvalidPeriod", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("requestedPeriod() error = %v", err)
+			}
+			if period != tc.period || isCurrent != tc.isCurrent {
+				t.Errorf("requestedPeriod() = %d, %v; want %d, %v", period, isCurrent, tc.period, tc.isCurrent)
+			}
+		})
+	}
+}
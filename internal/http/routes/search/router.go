@@ -0,0 +1,76 @@
+package search
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	utils "github.com/elias-gill/poliplanner2/internal/http"
+	render "github.com/elias-gill/poliplanner2/internal/render/html"
+	academicService "github.com/elias-gill/poliplanner2/internal/service/academic"
+	"github.com/elias-gill/poliplanner2/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+// Handler handles HTTP requests related to the global search.
+type Handler struct {
+	tmpl          *render.TemplateManager
+	searchService *academicService.SearchService
+}
+
+// NewHandler constructs a new Handler instance.
+func NewHandler(tmpl *render.TemplateManager, searchService *academicService.SearchService) *Handler {
+	return &Handler{
+		tmpl:          tmpl,
+		searchService: searchService,
+	}
+}
+
+// Routes sets up the HTTP router for the search endpoints.
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.index)
+	r.Get("/results", h.results)
+
+	return r
+}
+
+// index renders the search page. Accepts an optional "q" query param with the search text.
+func (h *Handler) index(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	query := r.URL.Query().Get("q")
+
+	results, err := h.searchService.Search(ctx, query)
+	if err != nil {
+		logger.Error("cannot search", "query", query, "error", err)
+		utils.Redirect(w, r, "/500")
+		return
+	}
+
+	if err := h.tmpl.RenderPage(w, "search/index.html", results); err != nil {
+		logger.Error("cannot render search page", "error", err)
+	}
+}
+
+// results renders the grouped results, used by the live search.
+func (h *Handler) results(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	query := r.URL.Query().Get("q")
+
+	results, err := h.searchService.Search(ctx, query)
+	if err != nil {
+		logger.Error("cannot search", "query", query, "error", err)
+		http.Error(w, "Error al buscar", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.tmpl.RenderPartial(w, "search/index.html", "search/results", results); err != nil {
+		logger.Error("cannot render search results partial", "error", err)
+		http.Error(w, "Error al renderizar la plantilla", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,189 @@
+package teachers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	utils "github.com/elias-gill/poliplanner2/internal/http"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	render "github.com/elias-gill/poliplanner2/internal/render/html"
+	academicService "github.com/elias-gill/poliplanner2/internal/service/academic"
+	"github.com/elias-gill/poliplanner2/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+// Handler handles HTTP requests related to the teachers directory.
+type Handler struct {
+	tmpl           *render.TemplateManager
+	teacherService *academicService.TeacherService
+}
+
+// NewHandler constructs a new Handler instance.
+func NewHandler(tmpl *render.TemplateManager, teacherService *academicService.TeacherService) *Handler {
+	return &Handler{
+		tmpl:           tmpl,
+		teacherService: teacherService,
+	}
+}
+
+// Routes sets up the HTTP router for the teachers endpoints.
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.index)
+	r.Get("/search", h.search)
+	r.Get("/{id}", h.teacherDetail)
+
+	// Admin
+	r.Get("/merges", h.mergeProposals)
+	r.Post("/merges", h.merge)
+
+	return r
+}
+
+// index renders the teachers directory. Accepts an optional "q" query param to pre-filter
+// the list.
+func (h *Handler) index(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	query := r.URL.Query().Get("q")
+
+	teachers, err := h.teacherService.ListTeachers(ctx, query)
+	if err != nil {
+		logger.Error("cannot list teachers", "error", err)
+		utils.Redirect(w, r, "/500")
+		return
+	}
+
+	data := map[string]any{
+		"Query":    query,
+		"Teachers": teachers,
+	}
+
+	if err := h.tmpl.RenderPage(w, "teachers/index.html", data); err != nil {
+		logger.Error("cannot render teachers index", "error", err)
+	}
+}
+
+// search renders the filtered teachers list, used by the directory live search.
+func (h *Handler) search(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	query := r.URL.Query().Get("q")
+
+	teachers, err := h.teacherService.ListTeachers(ctx, query)
+	if err != nil {
+		logger.Error("cannot search teachers", "query", query, "error", err)
+		http.Error(w, "Error al buscar docentes", http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]any{
+		"Query":    query,
+		"Teachers": teachers,
+	}
+
+	if err := h.tmpl.RenderPartial(w, "teachers/index.html", "teachers/teacher_list", data); err != nil {
+		logger.Error("cannot render teachers list partial", "error", err)
+		http.Error(w, "Error al renderizar la plantilla", http.StatusInternalServerError)
+	}
+}
+
+// teacherDetail renders the profile of a single teacher.
+func (h *Handler) teacherDetail(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ParseID(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.Redirect(w, r, "/404")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
+	defer cancel()
+
+	teacher, err := h.teacherService.GetTeacher(ctx, academic.TeacherID(id))
+	if err != nil {
+		if errors.Is(err, academicService.ErrTeacherNotFound) {
+			utils.Redirect(w, r, "/404")
+			return
+		}
+		logger.Error("cannot get teacher detail", "id", id, "error", err)
+		utils.Redirect(w, r, "/500")
+		return
+	}
+
+	if err := h.tmpl.RenderPage(w, "teachers/detail.html", teacher); err != nil {
+		logger.Error("cannot render teacher detail", "id", id, "error", err)
+	}
+}
+
+// mergeProposals renders the admin review screen with the teacher records that look
+// duplicated.
+func (h *Handler) mergeProposals(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	proposals, err := h.teacherService.ProposeMerges(ctx)
+	if err != nil {
+		logger.Error("cannot propose teacher merges", "error", err)
+		utils.Redirect(w, r, "/500")
+		return
+	}
+
+	data := map[string]any{
+		"Proposals": proposals,
+	}
+
+	if err := h.tmpl.RenderPage(w, "teachers/merges.html", data); err != nil {
+		logger.Error("cannot render teacher merges", "error", err)
+	}
+}
+
+// merge accepts a merge proposal. Expects the "survivor" ID and one or more "duplicate" IDs
+// as form values, and the admin key as bearer token.
+func (h *Handler) merge(w http.ResponseWriter, r *http.Request) {
+	if !utils.IsAdminRequest(r) {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	survivor, err := utils.ParseID(r.FormValue("survivor"))
+	if err != nil {
+		http.Error(w, "Invalid survivor id", http.StatusBadRequest)
+		return
+	}
+
+	ids, err := utils.ParseIDList(r.Form["duplicate"])
+	if err != nil {
+		http.Error(w, "Invalid duplicate id", http.StatusBadRequest)
+		return
+	}
+
+	duplicates := make([]academic.TeacherID, len(ids))
+	for i, id := range ids {
+		duplicates[i] = academic.TeacherID(id)
+	}
+
+	err = h.teacherService.Merge(r.Context(), academic.TeacherID(survivor), duplicates)
+	if err != nil {
+		switch {
+		case errors.Is(err, academicService.ErrInvalidMerge), errors.Is(err, academicService.ErrTeacherNotFound):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			logger.Error("cannot merge teachers", "survivor", survivor, "duplicates", duplicates, "error", err)
+			http.Error(w, "Merge failed", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	logger.Info("teachers merged", "survivor", survivor, "duplicates", duplicates)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/elias-gill/poliplanner2/internal/config"
 	"github.com/elias-gill/poliplanner2/internal/model/user"
 )
 
@@ -63,6 +64,16 @@ func IsHtmx(r *http.Request) bool {
 	return r.Header.Get("HX-Request") == "true"
 }
 
+// ========================================
+// =        Authorization helpers         =
+// ========================================
+
+// IsAdminRequest reports if the request carries the admin key as a bearer token. Admin pages
+// are public, only their actions are protected with this check.
+func IsAdminRequest(r *http.Request) bool {
+	return strings.TrimSpace(r.Header.Get("Authorization")) == "Bearer "+config.Get().Security.UpdateKey
+}
+
 // ========================================
 // =          Validation helpers          =
 // ========================================
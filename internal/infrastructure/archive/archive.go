@@ -0,0 +1,86 @@
+// Package archive keeps the raw bytes of the imported workbooks on disk, named after the
+// SHA-256 of their content. The same file downloaded twice is stored only once.
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+var ErrInvalidHash = errors.New("invalid archive hash")
+
+type Archive struct {
+	dir string
+}
+
+func New(dir string) *Archive {
+	return &Archive{dir: dir}
+}
+
+// Store copies the content into the archive and returns its hash and size. The content is
+// written to a temporary file first, so a failed copy never leaves a partial file behind a
+// valid hash.
+func (a *Archive) Store(r io.Reader) (string, int64, error) {
+	if err := os.MkdirAll(a.dir, 0o755); err != nil {
+		return "", 0, fmt.Errorf("cannot create archive dir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(a.dir, "upload-*")
+	if err != nil {
+		return "", 0, fmt.Errorf("cannot create archive file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, h), r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("cannot write archive file: %w", err)
+	}
+
+	hash := hex.EncodeToString(h.Sum(nil))
+	if err := os.Rename(tmp.Name(), a.path(hash)); err != nil {
+		return "", 0, fmt.Errorf("cannot move archive file: %w", err)
+	}
+
+	return hash, size, nil
+}
+
+// Open returns the content stored under the hash.
+func (a *Archive) Open(hash string) (io.ReadCloser, error) {
+	if !validHash(hash) {
+		return nil, ErrInvalidHash
+	}
+	return os.Open(a.path(hash))
+}
+
+// Remove deletes the content stored under the hash. Removing a missing file is not an error.
+func (a *Archive) Remove(hash string) error {
+	if !validHash(hash) {
+		return ErrInvalidHash
+	}
+	if err := os.Remove(a.path(hash)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (a *Archive) path(hash string) string {
+	return filepath.Join(a.dir, hash)
+}
+
+// validHash keeps the hashes read from the database from escaping the archive dir.
+func validHash(hash string) bool {
+	if len(hash) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
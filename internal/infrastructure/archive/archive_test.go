@@ -0,0 +1,67 @@
+package archive
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestStore(t *testing.T) {
+	a := New(t.TempDir())
+
+	hash, size, err := a.Store(strings.NewReader("planilla"))
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	// sha256 of "planilla"
+	if want := "42f460e70de323afc82623f28fce6e97ead6fc045f0d41af6ec90a94dfc3f9bb"; hash != want {
+		t.Errorf("hash = %s; want %s", hash, want)
+	}
+	if size != int64(len("planilla")) {
+		t.Errorf("size = %d; want %d", size, len("planilla"))
+	}
+
+	// The same content is stored under the same name
+	again, _, err := a.Store(strings.NewReader("planilla"))
+	if err != nil || again != hash {
+		t.Errorf("Store of the same content = %s, %v; want %s", again, err, hash)
+	}
+
+	entries, _ := os.ReadDir(a.dir)
+	if len(entries) != 1 {
+		t.Errorf("archive has %d files; want 1", len(entries))
+	}
+
+	f, err := a.Open(hash)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	content, _ := io.ReadAll(f)
+	f.Close()
+	if string(content) != "planilla" {
+		t.Errorf("content = %q; want %q", content, "planilla")
+	}
+
+	if err := a.Remove(hash); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := a.Remove(hash); err != nil {
+		t.Errorf("Remove of a missing file failed: %v", err)
+	}
+	if _, err := a.Open(hash); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Open after Remove error = %v; want ErrNotExist", err)
+	}
+}
+
+func TestInvalidHash(t *testing.T) {
+	a := New(t.TempDir())
+
+	for _, hash := range []string{"", "../poliplanner.db", strings.Repeat("z", 64)} {
+		if _, err := a.Open(hash); !errors.Is(err, ErrInvalidHash) {
+			t.Errorf("Open(%q) error = %v; want ErrInvalidHash", hash, err)
+		}
+	}
+}
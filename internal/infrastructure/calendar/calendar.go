@@ -0,0 +1,76 @@
+// Package calendar reads the holidays and days without classes of the academic calendar
+// from the files uploaded by the admins. ICS and CSV files are supported.
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported calendar format, expected .ics or .csv")
+	ErrEmptyCalendar     = errors.New("the calendar has no days off")
+)
+
+// Parse reads the days off of the file, choosing the format from its extension.
+func Parse(filename string, r io.Reader) ([]academic.DayOff, error) {
+	var (
+		days []academic.DayOff
+		err  error
+	)
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".ics", ".ical":
+		days, err = ParseICS(r)
+	case ".csv":
+		days, err = ParseCSV(r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	if len(days) == 0 {
+		return nil, ErrEmptyCalendar
+	}
+
+	return days, nil
+}
+
+// guessKind classifies a day off from its description. Anything that is not explicitly a
+// recess is taken as a holiday, which is what most public calendars contain.
+func guessKind(description string) academic.DayOffKind {
+	normalized := academic.NormalizeName(description)
+
+	switch {
+	case strings.Contains(normalized, "receso"), strings.Contains(normalized, "vacaciones"):
+		return academic.DayOffRecess
+	case strings.Contains(normalized, "sin clases"), strings.Contains(normalized, "asueto"):
+		return academic.DayOffOther
+	}
+	return academic.DayOffHoliday
+}
+
+func newDayOff(start, end time.Time, description string, kind academic.DayOffKind) (academic.DayOff, error) {
+	if end.Before(start) {
+		return academic.DayOff{}, fmt.Errorf("%q ends before it starts", description)
+	}
+
+	return academic.DayOff{
+		Dates:       academic.DateRange{Start: start, End: end},
+		Description: description,
+		Kind:        kind,
+	}, nil
+}
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, timezone.ParaguayTZ)
+}
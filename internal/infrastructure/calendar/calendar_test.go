@@ -0,0 +1,108 @@
+package calendar
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+func TestParseICS(t *testing.T) {
+	content := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20260501",
+		"DTEND;VALUE=DATE:20260502",
+		"SUMMARY:Día del Trabajador",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20260713",
+		"DTEND;VALUE=DATE:20260725",
+		"SUMMARY:Receso de invierno\\, sin",
+		"  clases",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20260815T040000Z",
+		"SUMMARY:Fundación de Asunción",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	days, err := ParseICS(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("ParseICS() error = %v", err)
+	}
+
+	expected := []struct {
+		start, end  string
+		description string
+		kind        academic.DayOffKind
+	}{
+		{"2026-05-01", "2026-05-01", "Día del Trabajador", academic.DayOffHoliday},
+		{"2026-07-13", "2026-07-24", "Receso de invierno, sin clases", academic.DayOffRecess},
+		{"2026-08-15", "2026-08-15", "Fundación de Asunción", academic.DayOffHoliday},
+	}
+
+	if len(days) != len(expected) {
+		t.Fatalf("ParseICS() returned %d days; want %d", len(days), len(expected))
+	}
+	for i, want := range expected {
+		got := days[i]
+		if s := got.Dates.Start.Format(time.DateOnly); s != want.start {
+			t.Errorf("day %d start = %s; want %s", i, s, want.start)
+		}
+		if e := got.Dates.End.Format(time.DateOnly); e != want.end {
+			t.Errorf("day %d end = %s; want %s", i, e, want.end)
+		}
+		if got.Description != want.description || got.Kind != want.kind {
+			t.Errorf("day %d = %q (%v); want %q (%v)", i, got.Description, got.Kind, want.description, want.kind)
+		}
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	content := "\ufeffFecha;Hasta;Descripción;Tipo\n" +
+		"01/03/2026;;Día de los Héroes;\n" +
+		"2026-04-02;2026-04-03;Semana Santa;receso\n" +
+		";;;\n" +
+		"2026-09-29;;Victoria de Boquerón;0\n"
+
+	days, err := ParseCSV(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("ParseCSV() error = %v", err)
+	}
+
+	if len(days) != 3 {
+		t.Fatalf("ParseCSV() returned %d days; want 3 (empty rows are skipped)", len(days))
+	}
+	if d := days[0]; d.Dates.Start.Format(time.DateOnly) != "2026-03-01" || !d.Dates.End.Equal(d.Dates.Start) {
+		t.Errorf("first day = %v to %v; want March 1", d.Dates.Start, d.Dates.End)
+	}
+	if d := days[1]; d.Kind != academic.DayOffRecess || d.Dates.Days() != 2 {
+		t.Errorf("second day = %v, %d days; want a recess of 2 days", d.Kind, d.Dates.Days())
+	}
+
+	invalid := []string{
+		"fecha,descripcion\n2026-13-01,Mal\n",
+		"fecha,descripcion,tipo\n2026-01-01,Año nuevo,nacional\n",
+		"desde,hasta,descripcion\n2026-02-10,2026-02-01,Al revés\n",
+		"dia,nombre\n2026-01-01,Año nuevo\n",
+	}
+	for _, content := range invalid {
+		if _, err := ParseCSV(strings.NewReader(content)); err == nil {
+			t.Errorf("ParseCSV(%q) succeeded; want an error", content)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if _, err := Parse("feriados.xlsx", strings.NewReader("")); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Parse(.xlsx) error = %v; want ErrUnsupportedFormat", err)
+	}
+	if _, err := Parse("feriados.CSV", strings.NewReader("fecha,descripcion\n")); !errors.Is(err, ErrEmptyCalendar) {
+		t.Errorf("Parse(empty csv) error = %v; want ErrEmptyCalendar", err)
+	}
+}
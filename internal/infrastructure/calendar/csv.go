@@ -0,0 +1,146 @@
+package calendar
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+var errMissingColumns = errors.New(`csv needs a "fecha" or "desde" column and a "descripcion" column`)
+
+// csvDateLayouts are the date formats accepted on CSV files.
+var csvDateLayouts = []string{"2006-01-02", "02/01/2006", "2/1/2006"}
+
+// ParseCSV reads a CSV file with a header row. The recognized columns are:
+//
+//   - fecha or desde: first day (required)
+//   - hasta: last day, inclusive. Defaults to the first day
+//   - descripcion: name of the holiday (required)
+//   - tipo: "feriado", "receso", "otro" or their numbers 0, 1 and 2. Guessed from the
+//     description when missing
+//
+// Both comma and semicolon separators are accepted.
+func ParseCSV(r io.Reader) ([]academic.DayOff, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read csv: %w", err)
+	}
+
+	text := strings.TrimPrefix(string(content), "\ufeff")
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = detectSeparator(text)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read csv: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[academic.NormalizeName(name)] = i
+	}
+
+	startCol, ok := columns["fecha"]
+	if !ok {
+		startCol, ok = columns["desde"]
+	}
+	descCol, hasDesc := columns["descripcion"]
+	if !ok || !hasDesc {
+		return nil, errMissingColumns
+	}
+	endCol, hasEnd := columns["hasta"]
+	kindCol, hasKind := columns["tipo"]
+
+	var days []academic.DayOff
+	for i, record := range records[1:] {
+		line := i + 2
+		field := func(col int) string {
+			if col < len(record) {
+				return strings.TrimSpace(record[col])
+			}
+			return ""
+		}
+
+		if field(startCol) == "" && field(descCol) == "" {
+			continue
+		}
+
+		start, err := parseCSVDate(field(startCol))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		end := start
+		if hasEnd && field(endCol) != "" {
+			if end, err = parseCSVDate(field(endCol)); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+
+		description := field(descCol)
+		kind := guessKind(description)
+		if hasKind && field(kindCol) != "" {
+			if kind, err = parseKind(field(kindCol)); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+
+		d, err := newDayOff(start, end, description, kind)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		days = append(days, d)
+	}
+
+	return days, nil
+}
+
+// detectSeparator picks ";" when the header uses it, as spreadsheets do on spanish locales.
+func detectSeparator(text string) rune {
+	header, _, _ := strings.Cut(text, "\n")
+	if strings.Count(header, ";") > strings.Count(header, ",") {
+		return ';'
+	}
+	return ','
+}
+
+func parseCSVDate(value string) (time.Time, error) {
+	for _, layout := range csvDateLayouts {
+		if t, err := time.ParseInLocation(layout, value, timezone.ParaguayTZ); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD or DD/MM/YYYY", value)
+}
+
+func parseKind(value string) (academic.DayOffKind, error) {
+	if n, err := strconv.Atoi(value); err == nil {
+		if n >= int(academic.DayOffHoliday) && n <= int(academic.DayOffOther) {
+			return academic.DayOffKind(n), nil
+		}
+	}
+
+	switch academic.NormalizeName(value) {
+	case "feriado":
+		return academic.DayOffHoliday, nil
+	case "receso":
+		return academic.DayOffRecess, nil
+	case "otro", "sin clases":
+		return academic.DayOffOther, nil
+	}
+
+	return 0, fmt.Errorf("invalid type %q, expected feriado, receso or otro", value)
+}
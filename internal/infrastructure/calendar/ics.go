@@ -0,0 +1,168 @@
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+// ParseICS reads every VEVENT of an iCalendar file as a day off. All-day events use the
+// exclusive DTEND of the spec, so an event from the 1st to the 3rd covers the 1st and the
+// 2nd. Recurrence rules are not expanded, each occurrence must be its own event.
+func ParseICS(r io.Reader) ([]academic.DayOff, error) {
+	lines, err := unfoldICSLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		days    []academic.DayOff
+		inEvent bool
+		event   map[string]icsProperty
+	)
+
+	for i, line := range lines {
+		switch {
+		case line == "BEGIN:VEVENT":
+			inEvent = true
+			event = make(map[string]icsProperty)
+
+		case line == "END:VEVENT":
+			if !inEvent {
+				return nil, fmt.Errorf("line %d: END:VEVENT without BEGIN", i+1)
+			}
+			inEvent = false
+
+			d, err := eventDayOff(event)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			days = append(days, d)
+
+		case inEvent:
+			prop := parseICSProperty(line)
+			if _, seen := event[prop.name]; !seen {
+				event[prop.name] = prop
+			}
+		}
+	}
+
+	return days, nil
+}
+
+type icsProperty struct {
+	name   string
+	params string // Raw parameters (eg: "VALUE=DATE")
+	value  string
+}
+
+func parseICSProperty(line string) icsProperty {
+	name, value, _ := strings.Cut(line, ":")
+	name, params, _ := strings.Cut(name, ";")
+	return icsProperty{name: strings.ToUpper(name), params: strings.ToUpper(params), value: value}
+}
+
+func eventDayOff(event map[string]icsProperty) (academic.DayOff, error) {
+	summary := unescapeICSText(event["SUMMARY"].value)
+	if summary == "" {
+		summary = "Sin clases"
+	}
+
+	startProp, ok := event["DTSTART"]
+	if !ok {
+		return academic.DayOff{}, fmt.Errorf("event %q has no DTSTART", summary)
+	}
+
+	start, allDay, err := parseICSDate(startProp)
+	if err != nil {
+		return academic.DayOff{}, err
+	}
+
+	end := start
+	if endProp, ok := event["DTEND"]; ok {
+		var endAllDay bool
+		if end, endAllDay, err = parseICSDate(endProp); err != nil {
+			return academic.DayOff{}, err
+		}
+
+		// The end of all-day events is exclusive, timed events ending at midnight too
+		if (endAllDay || allDay || isMidnight(endProp)) && end.After(start) {
+			end = end.AddDate(0, 0, -1)
+		}
+	}
+
+	return newDayOff(start, end, summary, guessKind(summary))
+}
+
+// parseICSDate returns the day of a DATE or DATE-TIME value in Paraguay time, and whether it
+// was a DATE.
+func parseICSDate(prop icsProperty) (time.Time, bool, error) {
+	value := strings.TrimSpace(prop.value)
+
+	if len(value) == 8 {
+		t, err := time.ParseInLocation("20060102", value, timezone.ParaguayTZ)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %s %q: %w", prop.name, value, err)
+		}
+		return t, true, nil
+	}
+
+	var (
+		t   time.Time
+		err error
+	)
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse("20060102T150405Z", value)
+	} else {
+		// Floating and TZID times are assumed to be local, which is the case of every
+		// calendar published by the university
+		t, err = time.ParseInLocation("20060102T150405", value, timezone.ParaguayTZ)
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s %q: %w", prop.name, value, err)
+	}
+
+	t = t.In(timezone.ParaguayTZ)
+	return day(t.Year(), t.Month(), t.Day()), false, nil
+}
+
+func isMidnight(prop icsProperty) bool {
+	return strings.Contains(prop.value, "T000000")
+}
+
+// unfoldICSLines splits the content in logical lines, joining the ones folded with a
+// leading space or tab.
+func unfoldICSLines(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read ics: %w", err)
+	}
+
+	return lines, nil
+}
+
+var icsUnescaper = strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`)
+
+func unescapeICSText(s string) string {
+	return strings.TrimSpace(icsUnescaper.Replace(s))
+}
@@ -0,0 +1,93 @@
+package calendar
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+)
+
+// ICSWriter writes iCalendar (RFC 5545) files. Lines are folded at 75 octets and end with
+// CRLF as the spec requires.
+type ICSWriter struct {
+	out *bufio.Writer
+}
+
+// NewICSWriter starts a calendar with the given display name.
+func NewICSWriter(w io.Writer, product, name string) *ICSWriter {
+	ics := &ICSWriter{out: bufio.NewWriter(w)}
+
+	ics.Line("BEGIN:VCALENDAR")
+	ics.Line("VERSION:2.0")
+	ics.Line("PRODID:-//PoliPlanner//" + product + "//ES")
+	ics.Line("CALSCALE:GREGORIAN")
+	ics.Line("METHOD:PUBLISH")
+	ics.Text("X-WR-CALNAME", name)
+
+	return ics
+}
+
+// Line writes a raw content line.
+func (w *ICSWriter) Line(s string) {
+	w.out.WriteString(foldICSLine(s))
+	w.out.WriteString("\r\n")
+}
+
+// Text writes a property with a text value, escaping its special characters.
+func (w *ICSWriter) Text(name, value string) {
+	w.Line(name + ":" + EscapeICSText(value))
+}
+
+// Time writes a DATE-TIME property in UTC, so calendar clients do not need the timezone
+// definition.
+func (w *ICSWriter) Time(name string, t time.Time) {
+	w.Line(name + ":" + FormatICSTime(t))
+}
+
+// Close ends the calendar and flushes the output.
+func (w *ICSWriter) Close() error {
+	w.Line("END:VCALENDAR")
+	return w.out.Flush()
+}
+
+// FormatICSTime formats t as an UTC DATE-TIME value.
+func FormatICSTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+var icsEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// EscapeICSText escapes the characters with special meaning on iCalendar text values.
+func EscapeICSText(s string) string {
+	return icsEscaper.Replace(s)
+}
+
+// foldICSLine splits lines longer than 75 octets, continuing them on the next line with a
+// leading space. Never splits a multi-byte character.
+func foldICSLine(s string) string {
+	const limit = 75
+
+	if len(s) <= limit {
+		return s
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+
+	return b.String()
+}
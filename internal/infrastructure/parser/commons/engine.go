@@ -1,204 +1,280 @@
 package commons
 
 import (
-	"io"
-	"os"
+	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
 	"strings"
-	"unicode"
 
 	"github.com/elias-gill/poliplanner2/internal/config"
 	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/exceptions"
 	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/layout"
-	"github.com/xuri/excelize/v2"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/tabular"
 )
 
-type parserType int
+type FieldSetter[T any] func(item *T, value string)
 
-const (
-	ScheduleParser = iota
-	LabsParser
-)
+// SheetKind describes a kind of workbook for the engine: where its layouts are and how the
+// cells of a row fill its DTO. A new kind of sheet only needs its own SheetKind.
+type SheetKind[T any] struct {
+	// Directory under parser/layout with the JSON layouts of the kind
+	LayoutDir string
 
-type FieldSetter[T any] func(item *T, value string)
+	// A row with a cell containing any of these is the header of the sheet
+	HeaderKeywords []string
+
+	// Setters by layout header name. Headers without a setter are ignored.
+	Setters map[string]FieldSetter[T]
+
+	// Headers that layouts can name without a setter, for the columns that are matched but
+	// not read, like the item number
+	Placeholders []string
+
+	// Sheets that are parsed, nil parses every sheet with a name
+	ShouldParse func(name string) bool
+
+	// Called after the cells of a row were set, with its number on the sheet starting from 1
+	FinishRow func(item *T, row int)
+}
 
 type BaseExcelEngine[T any] struct {
-	Layouts        []layout.Layout
-	File           *excelize.File
-	SheetNames     []string
-	CurrentSheet   int
-	HeaderKeywords []string
-	FieldSetters   map[string]FieldSetter[T]
+	Layouts      []layout.Layout
+	Book         tabular.Workbook
+	SheetNames   []string
+	CurrentSheet int
+	Kind         SheetKind[T]
+
+	// Rows with text before the header of the last sheet streamed, like titles and notes
+	IgnoredRows []IgnoredRow
 }
 
-func NewBaseEngine[T any](file io.ReadCloser, t parserType, headerKeywords []string, setters map[string]FieldSetter[T]) (*BaseExcelEngine[T], error) {
-	path := filepath.Join(config.Get().Paths.BaseDir, "internal", "infrastructure", "parser", "layout", "schedules")
-	if t == LabsParser {
-		path = filepath.Join(config.Get().Paths.BaseDir, "internal", "infrastructure", "parser", "layout", "labs")
-	}
+// IgnoredRow is a row of a sheet with text that is not read as an item.
+type IgnoredRow struct {
+	Row  int
+	Text string // Text of the cells, shortened
+}
+
+// Longest text of an IgnoredRow
+const maxIgnoredText = 60
 
-	loader := layout.NewJsonLayoutLoader(path)
-	layouts, err := loader.LoadJsonLayouts()
+// LoadLayouts loads the JSON layouts of a kind, from its directory under parser/layout.
+func LoadLayouts(layoutDir string) ([]layout.Layout, error) {
+	path := filepath.Join(config.Get().Paths.BaseDir, "internal", "infrastructure", "parser", "layout", layoutDir)
+
+	layouts, err := layout.NewJsonLayoutLoader(path).LoadJsonLayouts()
 	if err != nil {
 		return nil, exceptions.NewExcelParserConfigurationException("Failed to load layouts", err)
 	}
+	return layouts, nil
+}
 
-	engine := &BaseExcelEngine[T]{
-		Layouts:        layouts,
-		HeaderKeywords: headerKeywords,
-		CurrentSheet:   -1,
-		FieldSetters:   setters,
+// CheckHeaders validates that every header of the layout has a setter or is a placeholder of
+// the kind, so none of its columns is silently dropped.
+func (k SheetKind[T]) CheckHeaders(l *layout.Layout) error {
+	var unknown []string
+	for _, header := range l.Headers {
+		if _, ok := k.Setters[header]; !ok && !slices.Contains(k.Placeholders, header) {
+			unknown = append(unknown, header)
+		}
 	}
-
-	if err := engine.PrepareFile(file); err != nil {
-		return nil, err
+	if len(unknown) == 0 {
+		return nil
 	}
-	return engine, nil
+
+	known := slices.Concat(slices.Collect(maps.Keys(k.Setters)), k.Placeholders)
+	slices.Sort(known)
+	return fmt.Errorf("unknown headers %s, the known ones are: %s",
+		strings.Join(unknown, ", "), strings.Join(known, ", "))
 }
 
-func (e *BaseExcelEngine[T]) PrepareFile(file io.ReadCloser) error {
-	if e.File != nil {
-		e.Close()
+// NewBaseEngine parses the sheets of the kind of the workbook with the given layouts. The
+// engine closes the workbook.
+func NewBaseEngine[T any](book tabular.Workbook, kind SheetKind[T], layouts []layout.Layout) (*BaseExcelEngine[T], error) {
+	if len(layouts) == 0 {
+		book.Close()
+		return nil, exceptions.NewExcelParserConfigurationException("No layouts enabled for "+kind.LayoutDir, nil)
 	}
 
-	f, err := excelize.OpenReader(file, excelize.Options{
-		UnzipSizeLimit:    25 << 20,
-		UnzipXMLSizeLimit: 8 << 20,
-	})
-	if err != nil {
-		if os.IsNotExist(err) {
-			return exceptions.NewExcelParserConfigurationException("Cannot read source", err)
-		}
-		return exceptions.NewExcelParserInputException("Error reading source: ", err)
+	if kind.ShouldParse == nil {
+		kind.ShouldParse = func(name string) bool { return strings.TrimSpace(name) != "" }
 	}
 
-	e.File = f
-	e.SheetNames = f.GetSheetList()
-	e.CurrentSheet = -1
-	return nil
+	return &BaseExcelEngine[T]{
+		Layouts:      layouts,
+		Book:         book,
+		SheetNames:   book.SheetNames(),
+		CurrentSheet: -1,
+		Kind:         kind,
+	}, nil
 }
 
 func (e *BaseExcelEngine[T]) Close() {
-	if e.File != nil {
-		e.File.Close()
-		e.File = nil
+	if e.Book != nil {
+		e.Book.Close()
+		e.Book = nil
 	}
 }
 
-func (e *BaseExcelEngine[T]) NextSheet(shouldParseFn func(string) bool) bool {
+// NextSheet moves to the next sheet of the kind, returning false when there are no more.
+func (e *BaseExcelEngine[T]) NextSheet() bool {
 	e.CurrentSheet++
 	for e.CurrentSheet < len(e.SheetNames) {
-		name := e.SheetNames[e.CurrentSheet]
-		if !shouldParseFn(name) {
-			e.CurrentSheet++
-			continue
+		if e.Kind.ShouldParse(e.SheetNames[e.CurrentSheet]) {
+			return true
 		}
-		return true
+		e.CurrentSheet++
 	}
 	return false
 }
 
-func (e *BaseExcelEngine[T]) ParseSheetStream(sheetName string, acquireItem func() *T, releaseItem func(*T)) ([]T, error) {
+// IgnoredSheets returns the sheets of the workbook that NextSheet skips.
+func (e *BaseExcelEngine[T]) IgnoredSheets() []string {
+	var ignored []string
+	for _, name := range e.SheetNames {
+		if !e.Kind.ShouldParse(name) {
+			ignored = append(ignored, name)
+		}
+	}
+	return ignored
+}
+
+// CurrentSheetName returns the name of the sheet selected by NextSheet as written on the
+// workbook, or an empty string when there is none.
+func (e *BaseExcelEngine[T]) CurrentSheetName() string {
+	if e.CurrentSheet < 0 || e.CurrentSheet >= len(e.SheetNames) {
+		return ""
+	}
+	return e.SheetNames[e.CurrentSheet]
+}
+
+// StreamCurrentSheet streams the sheet selected by NextSheet, see StreamSheet.
+func (e *BaseExcelEngine[T]) StreamCurrentSheet(fn func(item *T) error) (*layout.Match, error) {
+	name := e.CurrentSheetName()
+	if name == "" {
+		return nil, exceptions.NewExcelParserException("No current sheet selected", nil)
+	}
+	return e.StreamSheet(name, fn)
+}
+
+// ParseCurrentSheet parses the sheet selected by NextSheet, returning its name as written on
+// the workbook. The match is nil when the header was not found or did not match.
+func (e *BaseExcelEngine[T]) ParseCurrentSheet() (string, []T, *layout.Match, error) {
+	name := e.CurrentSheetName()
+	if name == "" {
+		return "", nil, nil, exceptions.NewExcelParserException("No current sheet selected", nil)
+	}
+
+	items, lay, err := e.ParseSheetStream(name)
+	return name, items, lay, err
+}
+
+// ParseSheetStream parses the rows that follow the header of the sheet, returning them along
+// with the matched layout. The whole sheet is kept in memory, imports use StreamSheet.
+func (e *BaseExcelEngine[T]) ParseSheetStream(sheetName string) ([]T, *layout.Match, error) {
 	items := make([]T, 0, 250)
 
-	stream, err := e.File.Rows(sheetName)
+	lay, err := e.StreamSheet(sheetName, func(item *T) error {
+		items = append(items, *item)
+		return nil
+	})
+	if err != nil {
+		return nil, lay, err
+	}
+	return items, lay, nil
+}
+
+// StreamSheet reads the rows that follow the header of the sheet one at a time, calling fn
+// with each of them as soon as its cells are set. Only the current row is kept in memory:
+// the item is reused for the next row, so fn has to copy whatever it keeps. Reading stops at
+// the first error returned by fn. Returns the layout matched by the header, or a
+// MissingHeaderException when the sheet has no header.
+func (e *BaseExcelEngine[T]) StreamSheet(sheetName string, fn func(item *T) error) (*layout.Match, error) {
+	stream, err := e.Book.Rows(sheetName)
 	if err != nil {
 		return nil, exceptions.NewExcelParserInputException("Sheet not found: "+sheetName, err)
 	}
 	defer stream.Close()
 
-	var lowerHeader []string
-	var lay *layout.Layout
-	var startingCell int
+	var match *layout.Match
+	var setters []FieldSetter[T] // Setter of each column, nil for the columns not read
+	var zero T
+	item := new(T)
+	rowNumber := 0
+	e.IgnoredRows = nil
 
 	for stream.Next() {
+		rowNumber++
 		row, err := stream.Columns()
 		if err != nil {
-			return nil, exceptions.NewExcelParserInputException("Error reading row", err)
+			return match, exceptions.NewExcelParserInputException("Error reading row", err)
 		}
 
 		if len(row) == 0 || e.IsEmptyRow(row) {
 			continue
 		}
 
-		if lay == nil {
+		if match == nil {
 			if e.IsHeaderRow(row) {
-				lowerHeader = e.BuildLowerHeader(row)
-				startingCell = e.CalculateStartingCell(row)
-				l, err := e.FindFittingLayout(lowerHeader)
+				m, err := e.MatchLayout(row, rowNumber)
 				if err != nil {
 					return nil, err
 				}
-				lay = l
+				match = m
+
+				setters = make([]FieldSetter[T], len(m.Columns))
+				for i, field := range m.Columns {
+					setters[i] = e.Kind.Setters[field]
+				}
+			} else {
+				e.IgnoredRows = append(e.IgnoredRows, IgnoredRow{Row: rowNumber, Text: rowText(row)})
 			}
 			continue
 		}
 
-		if e.IsEmptyRow(row) {
-			break
-		}
-
-		item := acquireItem()
-		current := startingCell - 1
-
-		for _, field := range lay.Headers {
-			current++
-			if current >= len(row) {
+		*item = zero
+		for i, setter := range setters {
+			if i >= len(row) {
 				break
 			}
-			val := row[current]
-			if len(val) == 0 {
+			if setter == nil || len(row[i]) == 0 {
 				continue
 			}
-			if setter, ok := e.FieldSetters[field]; ok {
-				setter(item, val)
-			}
+			setter(item, row[i])
 		}
 
-		items = append(items, *item)
-		releaseItem(item)
-	}
+		if e.Kind.FinishRow != nil {
+			e.Kind.FinishRow(item, rowNumber)
+		}
 
-	if lay == nil {
-		return nil, exceptions.NewLayoutMatchException("No header row found in sheet: " + sheetName)
+		if err := fn(item); err != nil {
+			return match, err
+		}
 	}
-	return items, nil
-}
 
-func (e *BaseExcelEngine[T]) FindFittingLayout(lowerHeader []string) (*layout.Layout, error) {
-	for i := range e.Layouts {
-		if e.LayoutMatches(&e.Layouts[i], lowerHeader) {
-			return &e.Layouts[i], nil
-		}
+	if match == nil {
+		return nil, exceptions.NewMissingHeaderException(sheetName)
 	}
-	return nil, exceptions.NewLayoutMatchException("No matching layout found for sheet")
+	return match, nil
 }
 
-func (e *BaseExcelEngine[T]) LayoutMatches(l *layout.Layout, lower []string) bool {
-	cellIdx, hdrIdx := 0, 0
-	for hdrIdx < len(l.Headers) && cellIdx < len(lower) {
-		val := lower[cellIdx]
-		cellIdx++
-		if val == "" {
-			continue
-		}
-		patterns, ok := l.Patterns[l.Headers[hdrIdx]]
-		if !ok {
-			return false
+// MatchLayout returns the layout of the kind that best fits the header row, and the header
+// assigned to each of its columns. RowNumber is the number of the row on the sheet. Fails
+// with a LayoutMatchException holding the row when no layout is close enough.
+func (e *BaseExcelEngine[T]) MatchLayout(row []string, rowNumber int) (*layout.Match, error) {
+	match, ok := layout.BestMatch(e.Layouts, row)
+	if !ok {
+		if match == nil {
+			return nil, exceptions.NewLayoutMatchException("No matching layout found for sheet", rowNumber, row)
 		}
-		match := false
-		for _, p := range patterns {
-			if strings.Contains(val, p) {
-				match = true
-				break
-			}
-		}
-		if !match {
-			return false
-		}
-		hdrIdx++
+		return nil, exceptions.NewLayoutMatchException(fmt.Sprintf(
+			"No matching layout found for sheet, the closest is %s with %.0f%%",
+			match.Layout.FileName, match.Score*100,
+		), rowNumber, row)
 	}
-	return hdrIdx == len(l.Headers)
+
+	match.Row = rowNumber
+	return match, nil
 }
 
 func (e *BaseExcelEngine[T]) IsHeaderRow(row []string) bool {
@@ -208,7 +284,7 @@ func (e *BaseExcelEngine[T]) IsHeaderRow(row []string) bool {
 			continue
 		}
 		lowerVal := strings.ToLower(trimmed)
-		for _, keyword := range e.HeaderKeywords {
+		for _, keyword := range e.Kind.HeaderKeywords {
 			if strings.Contains(lowerVal, keyword) {
 				return true
 			}
@@ -217,32 +293,27 @@ func (e *BaseExcelEngine[T]) IsHeaderRow(row []string) bool {
 	return false
 }
 
-func (e *BaseExcelEngine[T]) IsEmptyRow(row []string) bool {
+// rowText joins the cells of the row with text, shortened to maxIgnoredText characters.
+func rowText(row []string) string {
+	var cells []string
 	for _, val := range row {
-		if len(strings.TrimSpace(val)) != 0 {
-			return false
+		if trimmed := strings.Join(strings.Fields(val), " "); trimmed != "" {
+			cells = append(cells, trimmed)
 		}
 	}
-	return true
-}
 
-func (e *BaseExcelEngine[T]) BuildLowerHeader(row []string) []string {
-	lower := make([]string, len(row))
-	for i, val := range row {
-		lower[i] = strings.ToLower(strings.TrimSpace(val))
+	text := []rune(strings.Join(cells, " | "))
+	if len(text) > maxIgnoredText {
+		return string(text[:maxIgnoredText-1]) + "…"
 	}
-	return lower
+	return string(text)
 }
 
-func (e *BaseExcelEngine[T]) CalculateStartingCell(row []string) int {
-	for i, val := range row {
-		if len(val) > 0 {
-			for _, r := range val {
-				if !unicode.IsSpace(r) {
-					return i
-				}
-			}
+func (e *BaseExcelEngine[T]) IsEmptyRow(row []string) bool {
+	for _, val := range row {
+		if len(strings.TrimSpace(val)) != 0 {
+			return false
 		}
 	}
-	return 0
+	return true
 }
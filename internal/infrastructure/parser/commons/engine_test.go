@@ -0,0 +1,180 @@
+package commons
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/exceptions"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/layout"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/tabular"
+	"github.com/xuri/excelize/v2"
+)
+
+// room is a minimal DTO, any struct can be parsed with its own setters
+type room struct {
+	Row     int
+	Subject string
+	Lab     string
+}
+
+func TestParseSheetStream(t *testing.T) {
+	f := excelize.NewFile()
+	f.SetSheetName("Sheet1", "Notas")
+	f.SetCellValue("Notas", "A1", "Sin tabla")
+
+	f.NewSheet("Fisica")
+	rows := [][]any{
+		{"Laboratorios", nil, "  Primer   semestre "},
+		{},
+		{"Item", "Dpto.", "Asignatura", "Nivel", "Semestre", "Carrera", "Plan", "Turno", "Sección", "Laboratorio", "Día", "Horario", "Tít", "Apellido", "Nombre", "Correo"},
+		{1, "DCB", "Fisica I", 1, 2, "IIN", "2023", "M", "LA", "Lab 1", "Lunes", "07:30 - 09:00"},
+		{2, "DCB", "Fisica II", 1, 4, "IIN", "2023", "M", "LA", "Lab 2", "Martes", "07:30 - 09:00"},
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		f.SetSheetRow("Fisica", cell, &row)
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("cannot build workbook: %v", err)
+	}
+
+	engine, err := NewBaseEngine(openBook(t, buf.Bytes()), SheetKind[room]{
+		LayoutDir:      "labs",
+		HeaderKeywords: []string{"item"},
+		Setters: map[string]FieldSetter[room]{
+			"asignatura":  func(r *room, v string) { r.Subject = v },
+			"laboratorio": func(r *room, v string) { r.Lab = v },
+		},
+		FinishRow: func(r *room, row int) { r.Row = row },
+	}, labLayouts(t))
+	if err != nil {
+		t.Fatalf("cannot create engine: %v", err)
+	}
+	defer engine.Close()
+
+	if !engine.NextSheet() {
+		t.Fatal("expected the notes sheet")
+	}
+	name, _, _, err := engine.ParseCurrentSheet()
+	var missing exceptions.MissingHeaderException
+	if name != "Notas" || !errors.As(err, &missing) {
+		t.Errorf("ParseCurrentSheet() = %q, %v; want a MissingHeaderException for Notas", name, err)
+	}
+
+	if !engine.NextSheet() {
+		t.Fatal("expected the labs sheet")
+	}
+	name, items, lay, err := engine.ParseCurrentSheet()
+	if err != nil {
+		t.Fatalf("cannot parse sheet %s: %v", name, err)
+	}
+	if lay == nil || lay.Layout.FileName != "laboratorios_por_sesion.json" {
+		t.Errorf("matched layout = %+v; want laboratorios_por_sesion.json", lay)
+	}
+
+	want := []room{
+		{Row: 4, Subject: "Fisica I", Lab: "Lab 1"},
+		{Row: 5, Subject: "Fisica II", Lab: "Lab 2"},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("got %d rows; want %d", len(items), len(want))
+	}
+	for i := range want {
+		if items[i] != want[i] {
+			t.Errorf("row %d = %+v; want %+v", i, items[i], want[i])
+		}
+	}
+
+	// The title is not read, and the empty rows are not reported
+	ignored := []IgnoredRow{{Row: 1, Text: "Laboratorios | Primer semestre"}}
+	if !reflect.DeepEqual(engine.IgnoredRows, ignored) {
+		t.Errorf("IgnoredRows = %+v; want %+v", engine.IgnoredRows, ignored)
+	}
+	if got := rowText([]string{strings.Repeat("a", 70)}); len([]rune(got)) != maxIgnoredText {
+		t.Errorf("rowText() has %d characters; want %d", len([]rune(got)), maxIgnoredText)
+	}
+
+	if engine.NextSheet() {
+		t.Error("expected no more sheets")
+	}
+}
+
+func TestStreamSheetUnmatchedHeader(t *testing.T) {
+	f := excelize.NewFile()
+	header := []any{"Item", "Código", "Descripción", "Responsable", "Estado"}
+	f.SetSheetRow("Sheet1", "A2", &header)
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("cannot build workbook: %v", err)
+	}
+
+	engine, err := NewBaseEngine(openBook(t, buf.Bytes()), SheetKind[room]{
+		LayoutDir:      "labs",
+		HeaderKeywords: []string{"item"},
+	}, labLayouts(t))
+	if err != nil {
+		t.Fatalf("cannot create engine: %v", err)
+	}
+	defer engine.Close()
+
+	engine.NextSheet()
+	_, err = engine.StreamCurrentSheet(func(*room) error { return nil })
+
+	// The header row is kept to draft a layout for it
+	var layoutErr exceptions.LayoutMatchException
+	if !errors.As(err, &layoutErr) {
+		t.Fatalf("err = %v; want a LayoutMatchException", err)
+	}
+	want := []string{"Item", "Código", "Descripción", "Responsable", "Estado"}
+	if layoutErr.Row != 2 || !reflect.DeepEqual(layoutErr.Header, want) {
+		t.Errorf("row %d %q; want row 2 %q", layoutErr.Row, layoutErr.Header, want)
+	}
+}
+
+func TestCheckHeaders(t *testing.T) {
+	kind := SheetKind[room]{
+		Setters: map[string]FieldSetter[room]{
+			"asignatura":  func(r *room, v string) { r.Subject = v },
+			"laboratorio": func(r *room, v string) { r.Lab = v },
+		},
+		Placeholders: []string{"item"},
+	}
+
+	known := &layout.Layout{Headers: []string{"item", "asignatura", "laboratorio"}}
+	if err := kind.CheckHeaders(known); err != nil {
+		t.Errorf("CheckHeaders(%q) = %v; want nil", known.Headers, err)
+	}
+
+	unknown := &layout.Layout{Headers: []string{"item", "asignatura", "aula", "docente"}}
+	err := kind.CheckHeaders(unknown)
+	want := "unknown headers aula, docente, the known ones are: asignatura, item, laboratorio"
+	if err == nil || err.Error() != want {
+		t.Errorf("CheckHeaders(%q) = %v; want %q", unknown.Headers, err, want)
+	}
+}
+
+func openBook(t *testing.T, data []byte) tabular.Workbook {
+	t.Helper()
+
+	book, err := tabular.Open(bytes.NewReader(data), "labs.xlsx")
+	if err != nil {
+		t.Fatalf("cannot open workbook: %v", err)
+	}
+	return book
+}
+
+func labLayouts(t *testing.T) []layout.Layout {
+	t.Helper()
+
+	layouts, err := LoadLayouts("labs")
+	if err != nil {
+		t.Fatalf("cannot load layouts: %v", err)
+	}
+	return layouts
+}
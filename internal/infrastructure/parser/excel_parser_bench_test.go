@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"os"
+	"path"
+	"runtime"
+	"runtime/metrics"
+	"testing"
+
+	"github.com/elias-gill/poliplanner2/internal/config"
+)
+
+// BenchmarkStreamSheets parses the test workbooks the same way the import does, reporting the
+// peak of live heap seen while the rows are read.
+func BenchmarkStreamSheets(b *testing.B) {
+	for _, name := range []string{"stripped_excel.xlsx", "real_test_excel.xlsx"} {
+		b.Run(name, func(b *testing.B) {
+			filePath := path.Join(config.Get().Paths.BaseDir, "test_data", "excel", name)
+
+			var peak heapPeak
+			for b.Loop() {
+				runtime.GC()
+				peak.sample()
+
+				file, err := os.Open(filePath)
+				if err != nil {
+					b.Fatalf("cannot open workbook: %v", err)
+				}
+
+				p, err := NewParser(file)
+				if err != nil {
+					b.Fatalf("cannot create parser: %v", err)
+				}
+
+				rows := 0
+				for p.NextSheet() {
+					_, err := p.StreamCurrentSheet(func(*SubjectDTO) error {
+						// Reading the metrics is cheap, but not free
+						if rows++; rows%50 == 0 {
+							peak.sample()
+						}
+						return nil
+					})
+					if err != nil {
+						b.Fatalf("cannot parse sheet %s: %v", p.SheetName(), err)
+					}
+					peak.sample()
+				}
+
+				p.Close()
+				file.Close()
+			}
+
+			b.ReportMetric(float64(peak.max)/(1<<20), "peak-heap-MB")
+		})
+	}
+}
+
+// heapPeak keeps the highest amount of live heap objects sampled.
+type heapPeak struct {
+	max     uint64
+	samples []metrics.Sample
+}
+
+func (h *heapPeak) sample() {
+	if h.samples == nil {
+		h.samples = []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	}
+
+	metrics.Read(h.samples)
+	if v := h.samples[0].Value.Uint64(); v > h.max {
+		h.max = v
+	}
+}
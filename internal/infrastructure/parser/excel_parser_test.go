@@ -1,12 +1,18 @@
 package parser
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
 	"os"
 	"path"
+	"reflect"
 	"testing"
 
 	"github.com/elias-gill/poliplanner2/internal/config"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/commons"
 	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	"github.com/xuri/excelize/v2"
 )
 
 func TestParseSubjects_ByCareerSheets(t *testing.T) {
@@ -487,20 +493,20 @@ func TestParseSubjects_ByCareerSheets(t *testing.T) {
 
 // Helpers
 
-func teacher(title, first, last, email string) TeacherDTO {
-	return TeacherDTO{Title: title, FirstName: first, LastName: last, Email: email}
+func teacher(title, first, last, email string) commons.TeacherDTO {
+	return commons.TeacherDTO{Title: title, FirstName: first, LastName: last, Email: email}
 }
 
-func slot(start, end Hour) TimeSlot {
-	return TimeSlot{Start: start, End: end}
+func slot(start, end commons.Hour) commons.TimeSlot {
+	return commons.TimeSlot{Start: start, End: end}
 }
 
-func date(year, month, day int) Date {
-	return Date{Year: year, Month: month, Day: day, Valid: true}
+func date(year, month, day int) commons.Date {
+	return commons.Date{Year: year, Month: month, Day: day, Valid: true}
 }
 
-func hour(hour, minute int) Hour {
-	return Hour{Hour: hour, Minute: minute, Valid: true}
+func hour(hour, minute int) commons.Hour {
+	return commons.Hour{Hour: hour, Minute: minute, Valid: true}
 }
 
 func subject(opts ...func(*SubjectDTO)) SubjectDTO {
@@ -521,7 +527,7 @@ func withGeneral(dept string, semester int, level int, section, raw string) func
 	}
 }
 
-func withTeachers(t ...TeacherDTO) func(*SubjectDTO) {
+func withTeachers(t ...commons.TeacherDTO) func(*SubjectDTO) {
 	return func(s *SubjectDTO) {
 		for i, teacher := range t {
 			if i >= 4 {
@@ -559,7 +565,7 @@ func assertSubjectEqual(t *testing.T, sheet string, got, want SubjectDTO) {
 		}
 	}
 
-	compareHours := func(field string, gotHour, wantHour Hour) {
+	compareHours := func(field string, gotHour, wantHour commons.Hour) {
 		if gotHour.Valid != wantHour.Valid {
 			t.Errorf("%s %s Valid mismatch: got %t, want %t", ctx, field, gotHour.Valid, wantHour.Valid)
 			return
@@ -569,7 +575,7 @@ func assertSubjectEqual(t *testing.T, sheet string, got, want SubjectDTO) {
 		}
 	}
 
-	compareDates := func(field string, gotDate, wantDate Date) {
+	compareDates := func(field string, gotDate, wantDate commons.Date) {
 		if gotDate.Valid != wantDate.Valid {
 			t.Errorf("%s %s Valid mismatch: got %t, want %t", ctx, field, gotDate.Valid, wantDate.Valid)
 			return
@@ -621,3 +627,110 @@ func assertSubjectEqual(t *testing.T, sheet string, got, want SubjectDTO) {
 		}
 	}
 }
+
+// TestLayoutFilesHeaders checks that the layout files only name headers the parsers read, the
+// same validation as the uploaded ones.
+func TestLayoutFilesHeaders(t *testing.T) {
+	kinds := []struct {
+		name  string
+		dir   string
+		check func(name string, data []byte) error
+	}{
+		{"schedules", scheduleSheets.LayoutDir, func(name string, data []byte) error {
+			_, err := ParseScheduleLayout(name, data)
+			return err
+		}},
+		{"labs", labSheets.LayoutDir, func(name string, data []byte) error {
+			_, err := ParseLabLayout(name, data)
+			return err
+		}},
+	}
+
+	for _, kind := range kinds {
+		dir := path.Join(config.Get().Paths.BaseDir, "internal", "infrastructure", "parser", "layout", kind.dir)
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("cannot read %s layouts: %v", kind.name, err)
+		}
+
+		for _, entry := range entries {
+			data, err := os.ReadFile(path.Join(dir, entry.Name()))
+			if err != nil {
+				t.Fatalf("cannot read %s: %v", entry.Name(), err)
+			}
+			if err := kind.check(entry.Name(), data); err != nil {
+				t.Errorf("%s layout %s: %v", kind.name, entry.Name(), err)
+			}
+		}
+	}
+}
+
+// TestParseSubjects_CSVZip exports every sheet of the workbook as a CSV file, the way Google
+// Sheets does, and checks that the zip of them parses to the same subjects.
+func TestParseSubjects_CSVZip(t *testing.T) {
+	testFile := path.Join(config.Get().Paths.BaseDir, "test_data", "excel", "stripped_excel.xlsx")
+
+	f, err := excelize.OpenFile(testFile)
+	if err != nil {
+		t.Fatalf("cannot open workbook: %v", err)
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, sheet := range f.GetSheetList() {
+		rows, err := f.GetRows(sheet)
+		if err != nil {
+			t.Fatalf("cannot read sheet %s: %v", sheet, err)
+		}
+
+		w, _ := archive.Create("Horarios - " + sheet + ".csv")
+		csvWriter := csv.NewWriter(w)
+		csvWriter.WriteAll(rows)
+		if err := csvWriter.Error(); err != nil {
+			t.Fatalf("cannot write sheet %s: %v", sheet, err)
+		}
+	}
+	archive.Close()
+
+	file, err := os.Open(testFile)
+	if err != nil {
+		t.Fatalf("cannot open workbook: %v", err)
+	}
+	defer file.Close()
+
+	xlsxParser, err := NewParser(file)
+	if err != nil {
+		t.Fatalf("cannot create parser: %v", err)
+	}
+	defer xlsxParser.Close()
+
+	layouts, err := ScheduleLayouts()
+	if err != nil {
+		t.Fatalf("cannot load layouts: %v", err)
+	}
+	csvParser, err := NewParserWithLayouts(&buf, "horarios.zip", layouts)
+	if err != nil {
+		t.Fatalf("cannot create parser: %v", err)
+	}
+	defer csvParser.Close()
+
+	want, got := parseAllSheets(t, xlsxParser), parseAllSheets(t, csvParser)
+	if len(want) == 0 || !reflect.DeepEqual(got, want) {
+		t.Errorf("csv sheets differ from the xlsx ones:\ngot  %d sheets\nwant %d sheets", len(got), len(want))
+	}
+}
+
+func parseAllSheets(t *testing.T, p *ExcelParser) map[string][]SubjectDTO {
+	t.Helper()
+
+	sheets := make(map[string][]SubjectDTO)
+	for p.NextSheet() {
+		sheet, err := p.ParseCurrentSheet()
+		if err != nil {
+			t.Fatalf("cannot parse sheet %s: %v", p.SheetName(), err)
+		}
+		sheets[sheet.Name] = sheet.Subjects
+	}
+	return sheets
+}
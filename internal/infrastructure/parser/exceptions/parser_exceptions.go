@@ -42,18 +42,41 @@ func NewExcelParserInputException(message string, cause error) error {
 	}
 }
 
-// LayoutMatchException represents errors when no layout matches the header row
+// LayoutMatchException represents errors when no layout matches the header row. The row is
+// kept to write a layout for it.
 type LayoutMatchException struct {
 	Message string
+
+	// Number of the header row on the sheet starting from 1, and its cells
+	Row    int
+	Header []string
 }
 
 func (e LayoutMatchException) Error() string {
 	return fmt.Sprintf("LayoutMatchException: %s", e.Message)
 }
 
-func NewLayoutMatchException(message string) error {
+func NewLayoutMatchException(message string, row int, header []string) error {
 	return LayoutMatchException{
 		Message: message,
+		Row:     row,
+		Header:  header,
+	}
+}
+
+// MissingHeaderException represents sheets without a header row, so they have no table to
+// parse
+type MissingHeaderException struct {
+	Sheet string
+}
+
+func (e MissingHeaderException) Error() string {
+	return fmt.Sprintf("MissingHeaderException: No header row found in sheet: %s", e.Sheet)
+}
+
+func NewMissingHeaderException(sheet string) error {
+	return MissingHeaderException{
+		Sheet: sheet,
 	}
 }
 
@@ -1 +1,162 @@
 package parser
+
+import (
+	"strings"
+
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/commons"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+// LabDTO is a laboratory group of a subject. Lab sheets are not split by career, so a row
+// lists every career that takes the lab.
+type LabDTO struct {
+	Row int // Row number on the sheet, starting from 1
+
+	Department     string
+	RawSubjectName string
+	Careers        []string
+	Plan           string
+	Semester       int
+	Level          int
+	Section        string
+	Shift          string
+
+	Teachers     [4]commons.TeacherDTO
+	TeacherCount int
+
+	// Lab room, used for every session of the row
+	Lab string
+
+	// Sheets with a row per session have a day and a time column, the others a time column
+	// for each day of the week
+	Day      academic.WeekDay
+	Time     commons.TimeSlot
+	Schedule [7]commons.TimeSlot
+
+	teacherLines [4]int // Lines of the first names, last names, titles and emails columns
+}
+
+func (l *LabDTO) SetDepartment(val string) {
+	l.Department = strings.ToUpper(strings.TrimSpace(val))
+}
+
+func (l *LabDTO) SetSubjectName(val string) {
+	l.RawSubjectName = strings.TrimSpace(val)
+}
+
+// SetCareers splits the career codes of the row (eg: "IIN, LCIK" or "IIN/IEK"). Codes are
+// normalized like the sheet names of the schedules.
+func (l *LabDTO) SetCareers(val string) {
+	fields := strings.FieldsFunc(val, func(r rune) bool {
+		return r == ',' || r == '/' || r == ';' || r == '\n'
+	})
+
+	l.Careers = nil
+	for _, f := range fields {
+		code := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(f), " ", ""))
+		if code != "" {
+			l.Careers = append(l.Careers, code)
+		}
+	}
+}
+
+func (l *LabDTO) SetPlan(val string) {
+	l.Plan = strings.ToLower(strings.TrimSpace(val))
+}
+
+func (l *LabDTO) SetSemester(val string) { l.Semester = commons.ConvertStringToNumber(val) }
+func (l *LabDTO) SetLevel(val string)    { l.Level = commons.ConvertStringToNumber(val) }
+
+func (l *LabDTO) SetSection(val string) {
+	l.Section = strings.TrimSpace(strings.ToUpper(val))
+}
+
+func (l *LabDTO) SetShift(val string) {
+	l.Shift = strings.TrimSpace(strings.ToUpper(val))
+}
+
+func (l *LabDTO) SetTeachersFirtNames(v string) {
+	l.setTeacherLines(0, commons.ScanLines(v, func(i int, s string) { l.Teachers[i].FirstName = s }))
+}
+func (l *LabDTO) SetTeachersLastNames(v string) {
+	l.setTeacherLines(1, commons.ScanLines(v, func(i int, s string) { l.Teachers[i].LastName = s }))
+}
+func (l *LabDTO) SetTeachersTitles(v string) {
+	l.setTeacherLines(2, commons.ScanLines(v, func(i int, s string) { l.Teachers[i].Title = s }))
+}
+func (l *LabDTO) SetTeachersEmails(v string) {
+	l.setTeacherLines(3, commons.ScanLines(v, func(i int, s string) { l.Teachers[i].Email = s }))
+}
+
+func (l *LabDTO) setTeacherLines(column, count int) {
+	l.teacherLines[column] = count
+	if count > l.TeacherCount {
+		l.TeacherCount = count
+	}
+}
+
+// TeacherLinesMismatch reports whether the filled teacher columns have a different number of
+// lines, same as SubjectDTO.TeacherLinesMismatch.
+func (l *LabDTO) TeacherLinesMismatch() bool {
+	lines := 0
+	for _, n := range l.teacherLines {
+		if n == 0 {
+			continue
+		}
+		if lines != 0 && n != lines {
+			return true
+		}
+		lines = n
+	}
+	return false
+}
+
+func (l *LabDTO) SetLab(val string)  { l.Lab = strings.TrimSpace(val) }
+func (l *LabDTO) SetDay(val string)  { l.Day = parseWeekDay(val) }
+func (l *LabDTO) SetTime(val string) { l.Time = commons.ParseTimeSlot(val) }
+
+func (l *LabDTO) SetDayTime(day academic.WeekDay, val string) {
+	l.Schedule[day] = commons.ParseTimeSlot(val)
+}
+
+// WeekSchedule returns the sessions of the row in the same shape as the schedule sheets, so
+// both are mapped the same way.
+func (l *LabDTO) WeekSchedule() [7]WeekDayData {
+	var week [7]WeekDayData
+
+	for day, slot := range l.Schedule {
+		if slot.Start.Valid || slot.End.Valid {
+			week[day] = WeekDayData{Room: l.Lab, Time: slot}
+		}
+	}
+
+	if l.Day != 0 && (l.Time.Start.Valid || l.Time.End.Valid) {
+		week[l.Day] = WeekDayData{Room: l.Lab, Time: l.Time}
+	}
+
+	return week
+}
+
+// parseWeekDay maps a day name written on a sheet (eg: "Miércoles", "SABADO") to the week
+// day, or 0 when it is not a day.
+func parseWeekDay(val string) academic.WeekDay {
+	val = strings.ToLower(strings.TrimSpace(val))
+	val = strings.NewReplacer("é", "e", "á", "a").Replace(val)
+
+	switch {
+	case strings.HasPrefix(val, "lun"):
+		return academic.Monday
+	case strings.HasPrefix(val, "mar"):
+		return academic.Tuesday
+	case strings.HasPrefix(val, "mie"):
+		return academic.Wednesday
+	case strings.HasPrefix(val, "jue"):
+		return academic.Thursday
+	case strings.HasPrefix(val, "vie"):
+		return academic.Friday
+	case strings.HasPrefix(val, "sab"):
+		return academic.Saturday
+	default:
+		return 0
+	}
+}
@@ -1 +1,140 @@
 package parser
+
+import (
+	"io"
+	"strings"
+
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/commons"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/layout"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/tabular"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	"github.com/elias-gill/poliplanner2/logger"
+)
+
+// LabsParser reads the laboratory workbooks. Unlike the schedules, lab sheets are not named
+// after a career, so every sheet with a header row is parsed.
+type LabsParser struct {
+	engine *commons.BaseExcelEngine[LabDTO]
+}
+
+type ParsedLabSheet struct {
+	Name   string
+	Layout string // File name of the layout that matched the header
+
+	// Row of the header and its columns that were matched with doubts
+	HeaderRow int
+	Unsure    []layout.UnsureColumn
+	Labs      []LabDTO
+}
+
+// labSheets describes the lab workbooks for the engine.
+var labSheets = commons.SheetKind[LabDTO]{
+	LayoutDir:      "labs",
+	HeaderKeywords: []string{"item", "ítem", "dpto"},
+	Setters:        buildLabFieldSetters(),
+	Placeholders:   []string{"item"},
+	FinishRow:      func(l *LabDTO, row int) { l.Row = row },
+}
+
+// LabLayouts returns the layout files of the lab workbooks.
+func LabLayouts() ([]layout.Layout, error) {
+	return commons.LoadLayouts(labSheets.LayoutDir)
+}
+
+// ParseLabLayout reads a layout of the lab workbooks, checking that all of its headers are
+// read by the parser.
+func ParseLabLayout(name string, data []byte) (*layout.Layout, error) {
+	l, err := layout.Parse(name, data)
+	if err != nil {
+		return nil, err
+	}
+	return l, labSheets.CheckHeaders(l)
+}
+
+// NewLabsParser opens a lab workbook to parse it with the layout files.
+func NewLabsParser(file io.ReadCloser) (*LabsParser, error) {
+	layouts, err := LabLayouts()
+	if err != nil {
+		return nil, err
+	}
+	return NewLabsParserWithLayouts(file, "", layouts)
+}
+
+// NewLabsParserWithLayouts opens a lab workbook to parse it with the given layouts, in any of
+// the formats of NewParserWithLayouts.
+func NewLabsParserWithLayouts(file io.Reader, name string, layouts []layout.Layout) (*LabsParser, error) {
+	book, err := tabular.Open(file, name)
+	if err != nil {
+		return nil, err
+	}
+
+	engine, err := commons.NewBaseEngine(book, labSheets, layouts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LabsParser{engine: engine}, nil
+}
+
+func (lp *LabsParser) Close() {
+	lp.engine.Close()
+}
+
+func (lp *LabsParser) NextSheet() bool {
+	return lp.engine.NextSheet()
+}
+
+// StreamCurrentSheet parses the current sheet calling fn with each lab as soon as it is read,
+// same as ExcelParser.StreamCurrentSheet. Sheets without a table, like cover pages or notes,
+// return a MissingHeaderException.
+func (lp *LabsParser) StreamCurrentSheet(fn func(*LabDTO) error) (*ParsedLabSheet, error) {
+	sheet := &ParsedLabSheet{Name: strings.ToUpper(strings.TrimSpace(lp.engine.CurrentSheetName()))}
+	logger.Info("Parsing labs", "sheet_name", sheet.Name)
+
+	match, err := lp.engine.StreamCurrentSheet(fn)
+	if match != nil {
+		sheet.Layout = match.Layout.FileName
+		sheet.HeaderRow = match.Row
+		sheet.Unsure = match.Unsure
+	}
+
+	return sheet, err
+}
+
+// ParseCurrentSheet parses all the labs of the current sheet.
+func (lp *LabsParser) ParseCurrentSheet() (*ParsedLabSheet, error) {
+	var labs []LabDTO
+	sheet, err := lp.StreamCurrentSheet(func(l *LabDTO) error {
+		labs = append(labs, *l)
+		return nil
+	})
+	sheet.Labs = labs
+
+	return sheet, err
+}
+
+func buildLabFieldSetters() map[string]commons.FieldSetter[LabDTO] {
+	return map[string]commons.FieldSetter[LabDTO]{
+		"departamento":  func(l *LabDTO, v string) { l.SetDepartment(v) },
+		"asignatura":    func(l *LabDTO, v string) { l.SetSubjectName(v) },
+		"nivel":         func(l *LabDTO, v string) { l.SetLevel(v) },
+		"semestre":      func(l *LabDTO, v string) { l.SetSemester(v) },
+		"carrera":       func(l *LabDTO, v string) { l.SetCareers(v) },
+		"plan":          func(l *LabDTO, v string) { l.SetPlan(v) },
+		"turno":         func(l *LabDTO, v string) { l.SetShift(v) },
+		"seccion":       func(l *LabDTO, v string) { l.SetSection(v) },
+		"titulo":        func(l *LabDTO, v string) { l.SetTeachersTitles(v) },
+		"apellido":      func(l *LabDTO, v string) { l.SetTeachersLastNames(v) },
+		"nombre":        func(l *LabDTO, v string) { l.SetTeachersFirtNames(v) },
+		"correo":        func(l *LabDTO, v string) { l.SetTeachersEmails(v) },
+		"laboratorio":   func(l *LabDTO, v string) { l.SetLab(v) },
+		"dia":           func(l *LabDTO, v string) { l.SetDay(v) },
+		"horario":       func(l *LabDTO, v string) { l.SetTime(v) },
+		"horaLunes":     func(l *LabDTO, v string) { l.SetDayTime(academic.Monday, v) },
+		"horaMartes":    func(l *LabDTO, v string) { l.SetDayTime(academic.Tuesday, v) },
+		"horaMiercoles": func(l *LabDTO, v string) { l.SetDayTime(academic.Wednesday, v) },
+		"horaJueves":    func(l *LabDTO, v string) { l.SetDayTime(academic.Thursday, v) },
+		"horaViernes":   func(l *LabDTO, v string) { l.SetDayTime(academic.Friday, v) },
+		"horaSabado":    func(l *LabDTO, v string) { l.SetDayTime(academic.Saturday, v) },
+	}
+}
@@ -0,0 +1,128 @@
+package layout
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Draft builds a layout for a header row that no layout matched, starting from the closest
+// match, which is nil when there are no layouts. The columns assigned by the match keep their
+// header and patterns, adding the text of the cell when none of them is found on it. The rest
+// get a header named after the cell, which is not read until it is renamed to a known one.
+// Returns the draft and the header of each column of the row on it, empty for the empty cells.
+func Draft(match *Match, row []string) (Layout, []string) {
+	draft := Layout{
+		FileName: "borrador.json",
+		Patterns: make(map[string][]string),
+	}
+	columns := make([]string, len(row))
+
+	// The cells named after their text can not take the header of a matched column
+	used := make(map[string]bool)
+	var normalized map[string][]string
+	if match != nil {
+		normalized = match.Layout.normalizedPatterns()
+		for _, header := range match.Columns {
+			used[header] = true
+		}
+	}
+
+	for i, cell := range row {
+		cellText := normalizeHeader(cell)
+		if cellText == "" {
+			continue
+		}
+		text := strings.Join(strings.Fields(strings.ToLower(cell)), " ")
+
+		header := ""
+		if match != nil && i < len(match.Columns) {
+			header = match.Columns[i]
+		}
+
+		var patterns []string
+		if header != "" {
+			patterns = append(patterns, match.Layout.Patterns[header]...)
+			if similarity(cellText, normalized[header]) < 1 {
+				patterns = append(patterns, text)
+			}
+		} else {
+			header = uniqueHeader(used, draftHeaderName(cellText))
+			used[header] = true
+			patterns = []string{text}
+		}
+
+		draft.Headers = append(draft.Headers, header)
+		draft.Patterns[header] = patterns
+		columns[i] = header
+	}
+
+	draft.normalized = normalizePatterns(draft.Patterns)
+	return draft, columns
+}
+
+// draftHeaderName names a header after the normalized text of its cell in camel case, like
+// the headers of the layouts, eg: "fecha de inicio" is fechaDeInicio.
+func draftHeaderName(normalized string) string {
+	words := strings.Fields(normalized)
+	for i := 1; i < len(words); i++ {
+		r, size := utf8.DecodeRuneInString(words[i])
+		words[i] = string(unicode.ToUpper(r)) + words[i][size:]
+	}
+	return strings.Join(words, "")
+}
+
+// uniqueHeader adds a number to the header when it is already used.
+func uniqueHeader(used map[string]bool, header string) string {
+	if !used[header] {
+		return header
+	}
+	for n := 2; ; n++ {
+		if candidate := fmt.Sprintf("%s%d", header, n); !used[candidate] {
+			return candidate
+		}
+	}
+}
+
+// EncodeJSON writes the layout in the format of the layout files, one header per line.
+func (l *Layout) EncodeJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{\n  \"lista\": [\n")
+
+	for i, header := range l.Headers {
+		name, err := marshalString(header)
+		if err != nil {
+			return nil, err
+		}
+
+		patterns := make([]string, len(l.Patterns[header]))
+		for j, p := range l.Patterns[header] {
+			if patterns[j], err = marshalString(p); err != nil {
+				return nil, err
+			}
+		}
+
+		fmt.Fprintf(&buf, "    { \"encabezado\": %s, \"patron\": [%s] }", name, strings.Join(patterns, ", "))
+		if i < len(l.Headers)-1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('\n')
+	}
+
+	buf.WriteString("  ]\n}\n")
+	return buf.Bytes(), nil
+}
+
+// marshalString quotes the string for JSON, keeping accents and symbols as they are.
+func marshalString(s string) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
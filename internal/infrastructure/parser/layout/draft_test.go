@@ -0,0 +1,81 @@
+package layout
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDraft(t *testing.T) {
+	row := []string{"", "Item", "Materia (nombre)", "Secion", "Observaciones", "Fecha de inicio", "Fecha de  inicio", "Aula"}
+
+	match, ok := BestMatch([]Layout{testLayout}, row)
+	if ok {
+		t.Fatalf("row matched %s with %.2f, it should need a new layout", match.Layout.FileName, match.Score)
+	}
+
+	draft, columns := Draft(match, row)
+
+	wantHeaders := []string{"item", "asignatura", "seccion", "observaciones", "fechaDeInicio", "fechaDeInicio2", "aula"}
+	if !reflect.DeepEqual(draft.Headers, wantHeaders) {
+		t.Errorf("headers = %q; want %q", draft.Headers, wantHeaders)
+	}
+	if want := append([]string{""}, wantHeaders...); !reflect.DeepEqual(columns, want) {
+		t.Errorf("columns = %q; want %q", columns, want)
+	}
+
+	// Matched columns keep their patterns, adding the cell only when none is found on it
+	wantPatterns := map[string][]string{
+		"item":           {"item"},
+		"asignatura":     {"asignatura", "materia"},
+		"seccion":        {"sección", "secion"},
+		"observaciones":  {"observaciones"},
+		"fechaDeInicio":  {"fecha de inicio"},
+		"fechaDeInicio2": {"fecha de inicio"},
+		"aula":           {"aula"},
+	}
+	if !reflect.DeepEqual(draft.Patterns, wantPatterns) {
+		t.Errorf("patterns = %q; want %q", draft.Patterns, wantPatterns)
+	}
+}
+
+func TestDraftWithoutLayouts(t *testing.T) {
+	draft, _ := Draft(nil, []string{"Ítem", "Nombre del Docente"})
+
+	if want := []string{"item", "nombreDelDocente"}; !reflect.DeepEqual(draft.Headers, want) {
+		t.Errorf("headers = %q; want %q", draft.Headers, want)
+	}
+}
+
+// TestDraftRoundTrip saves the draft as a layout file, which has to load and match the row it
+// was drafted from.
+func TestDraftRoundTrip(t *testing.T) {
+	row := []string{"Item", "Asignatura", "Sección", "Observaciones \"internas\"", "Día <1>"}
+
+	match, _ := BestMatch([]Layout{testLayout}, row)
+	draft, _ := Draft(match, row)
+
+	data, err := draft.EncodeJSON()
+	if err != nil {
+		t.Fatalf("cannot encode draft: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "borrador.json"), data, 0o644); err != nil {
+		t.Fatalf("cannot write draft: %v", err)
+	}
+
+	layouts, err := NewJsonLayoutLoader(dir).LoadJsonLayouts()
+	if err != nil || len(layouts) != 1 {
+		t.Fatalf("cannot load draft: %v\n%s", err, data)
+	}
+	if !reflect.DeepEqual(layouts[0].Headers, draft.Headers) || !reflect.DeepEqual(layouts[0].Patterns, draft.Patterns) {
+		t.Errorf("loaded = %+v; want %+v", layouts[0], draft)
+	}
+
+	m, ok := BestMatch(layouts, row)
+	if !ok || m.Score != 1 || len(m.Unsure) != 0 {
+		t.Errorf("draft matched with %.2f, unsure %+v; want an exact match", m.Score, m.Unsure)
+	}
+}
@@ -14,6 +14,9 @@ type Layout struct {
 	FileName string
 	Headers  []string
 	Patterns map[string][]string
+
+	// Patterns without accents nor punctuation, used by the matcher
+	normalized map[string][]string
 }
 
 type JsonLayoutLoader struct {
@@ -58,32 +61,50 @@ func (l *JsonLayoutLoader) loadSingleLayout(filePath string) (*Layout, error) {
 		return nil, err
 	}
 
+	return Parse(filepath.Base(filePath), data)
+}
+
+// Parse reads a layout in the format of the layout files. Every header needs a name used only
+// once and at least one pattern with letters or digits, otherwise it could never match.
+func Parse(fileName string, data []byte) (*Layout, error) {
 	var jsonData jsonLayoutFile
 	if err := json.Unmarshal(data, &jsonData); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid JSON in %s: %w", fileName, err)
+	}
+	if len(jsonData.List) == 0 {
+		return nil, fmt.Errorf("no headers in %s, expected a \"lista\" of headers", fileName)
 	}
 
 	headers := make([]string, 0, len(jsonData.List))
 	patterns := make(map[string][]string)
 
-	for _, entry := range jsonData.List {
+	for i, entry := range jsonData.List {
 		if entry.Header == "" {
-			return nil, fmt.Errorf("empty header in %s", filepath.Base(filePath))
+			return nil, fmt.Errorf("empty header at position %d of %s", i+1, fileName)
 		}
-		headers = append(headers, entry.Header)
-		if len(entry.Patterns) > 0 {
-			// Guardamos los patrones ya en minúsculas de forma permanente
-			lowered := make([]string, len(entry.Patterns))
-			for i, p := range entry.Patterns {
-				lowered[i] = strings.ToLower(p)
+		if _, ok := patterns[entry.Header]; ok {
+			return nil, fmt.Errorf("header %q repeated in %s", entry.Header, fileName)
+		}
+
+		// Guardamos los patrones ya en minúsculas de forma permanente
+		lowered := make([]string, 0, len(entry.Patterns))
+		for _, p := range entry.Patterns {
+			if normalizeHeader(p) != "" {
+				lowered = append(lowered, strings.ToLower(p))
 			}
-			patterns[entry.Header] = lowered
 		}
+		if len(lowered) == 0 {
+			return nil, fmt.Errorf("header %q of %s has no patterns", entry.Header, fileName)
+		}
+
+		headers = append(headers, entry.Header)
+		patterns[entry.Header] = lowered
 	}
 
 	return &Layout{
-		FileName: filepath.Base(filePath),
-		Headers:  headers,
-		Patterns: patterns,
+		FileName:   fileName,
+		Headers:    headers,
+		Patterns:   patterns,
+		normalized: normalizePatterns(patterns),
 	}, nil
 }
@@ -0,0 +1,39 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	l, err := Parse("nuevo.json", []byte(`{"lista": [
+		{ "encabezado": "item", "patron": ["Item", "-"] },
+		{ "encabezado": "asignatura", "patron": ["Asignatura"] }
+	]}`))
+	if err != nil {
+		t.Fatalf("Parse() = %v", err)
+	}
+
+	// Patterns are lowered, and the ones without letters nor digits dropped
+	want := map[string][]string{"item": {"item"}, "asignatura": {"asignatura"}}
+	if l.FileName != "nuevo.json" || !reflect.DeepEqual(l.Patterns, want) {
+		t.Errorf("Parse() = %+v; want patterns %q", l, want)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := map[string]string{
+		"json":             `{"lista": [`,
+		"no headers":       `{"lista": []}`,
+		"empty header":     `{"lista": [{ "encabezado": "", "patron": ["item"] }]}`,
+		"repeated header":  `{"lista": [{ "encabezado": "item", "patron": ["item"] }, { "encabezado": "item", "patron": ["nro"] }]}`,
+		"no patterns":      `{"lista": [{ "encabezado": "item", "patron": [] }]}`,
+		"symbols patterns": `{"lista": [{ "encabezado": "item", "patron": ["-", " "] }]}`,
+	}
+
+	for name, data := range tests {
+		if l, err := Parse("nuevo.json", []byte(data)); err == nil {
+			t.Errorf("%s: Parse() = %+v; want an error", name, l)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package layout
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+// Scoring of the header matcher. A cell is assigned to a layout header when one of its
+// patterns is found on the cell, or when they are similar enough (a typo, an accent or a
+// plural). Columns can be missing, added or swapped with the next one, each lowering the
+// score of the layout, which is then chosen only above MatchThreshold.
+const (
+	// Lowest score of a layout to be used, the fraction of its headers found on the row
+	MatchThreshold = 0.85
+
+	// Lowest similarity between a cell and a pattern to consider them the same header
+	minSimilarity = 0.75
+
+	// Penalties of the cells of the row that are not part of the layout, and of each pair of
+	// swapped columns
+	extraCellPenalty = 1.0
+	swapPenalty      = 0.25
+)
+
+// Match is the layout that best fits a header row, along with the header assigned to each of
+// its columns.
+type Match struct {
+	Layout *Layout
+
+	// Fraction of the layout headers found on the row, minus the penalties. 1 when every
+	// cell matched exactly one header in order.
+	Score float64
+
+	// Layout header of each column of the row, empty for the columns that are not read
+	Columns []string
+
+	// Columns that were assigned without an exact match and headers that were not found
+	Unsure []UnsureColumn
+
+	// Number of the header row on the sheet starting from 1, set by the reader of the sheet
+	Row int
+}
+
+// UnsureKind tells why a column was assigned with less confidence.
+type UnsureKind int
+
+const (
+	UnsureSimilar UnsureKind = iota // The cell is only similar to the patterns of the header
+	UnsureSwapped                   // The column is swapped with the next one
+	UnsureMissing                   // No column of the row has the header
+)
+
+type UnsureColumn struct {
+	Kind UnsureKind
+
+	// Index of the column on the row and its text, -1 and empty when the header is missing
+	Column int
+	Cell   string
+
+	Header     string
+	Similarity float64
+}
+
+// ColumnName returns the name of the column as shown on spreadsheets, empty when missing.
+func (u UnsureColumn) ColumnName() string {
+	return ColumnName(u.Column)
+}
+
+// ColumnName returns the name of the column with the given index starting from 0, as shown
+// on spreadsheets, eg: A, B, ..., AA. Empty for negative indexes.
+func ColumnName(column int) string {
+	name := ""
+	for n := column + 1; n > 0; n = (n - 1) / 26 {
+		name = string(rune('A'+(n-1)%26)) + name
+	}
+	return name
+}
+
+// BestMatch scores every layout against the header row and returns the one with the highest
+// score, the first on ties. Returns false when no layout reaches MatchThreshold, the best one
+// is still returned to report how far it was.
+func BestMatch(layouts []Layout, row []string) (*Match, bool) {
+	cells := headerCells(row)
+
+	var best *Match
+	for i := range layouts {
+		m := matchLayout(&layouts[i], cells, len(row))
+		if best == nil || m.Score > best.Score {
+			best = m
+		}
+	}
+
+	return best, best != nil && best.Score >= MatchThreshold
+}
+
+// headerCell is a non-empty cell of the header row.
+type headerCell struct {
+	column     int
+	text       string
+	normalized string
+}
+
+func headerCells(row []string) []headerCell {
+	var cells []headerCell
+	for i, val := range row {
+		normalized := normalizeHeader(val)
+		if normalized == "" {
+			continue
+		}
+		cells = append(cells, headerCell{column: i, text: strings.TrimSpace(val), normalized: normalized})
+	}
+	return cells
+}
+
+// Steps of the alignment between the cells of the row and the headers of a layout
+type alignStep int
+
+const (
+	stepNone alignStep = iota
+	stepMatch
+	stepSwap
+	stepExtraCell
+	stepMissingHeader
+)
+
+// matchLayout aligns the cells with the layout headers keeping their order, like a diff: each
+// cell is assigned to a header, skipped as an extra column, or swapped with the next cell. The
+// alignment with the highest total similarity wins.
+func matchLayout(l *Layout, cells []headerCell, width int) *Match {
+	n, m := len(cells), len(l.Headers)
+
+	patterns := l.normalizedPatterns()
+
+	sim := make([][]float64, n)
+	for i, c := range cells {
+		sim[i] = make([]float64, m)
+		for j, h := range l.Headers {
+			sim[i][j] = similarity(c.normalized, patterns[h])
+		}
+	}
+
+	// best[i][j] is the best total aligning the first i cells with the first j headers
+	best := make([][]float64, n+1)
+	step := make([][]alignStep, n+1)
+	for i := range best {
+		best[i] = make([]float64, m+1)
+		step[i] = make([]alignStep, m+1)
+	}
+
+	for i := 0; i <= n; i++ {
+		for j := 0; j <= m; j++ {
+			if i == 0 && j == 0 {
+				continue
+			}
+
+			score, s := -1e9, stepNone
+			if i > 0 && j > 0 && sim[i-1][j-1] >= minSimilarity {
+				if v := best[i-1][j-1] + sim[i-1][j-1]; v > score {
+					score, s = v, stepMatch
+				}
+			}
+			if i > 1 && j > 1 && sim[i-2][j-1] >= minSimilarity && sim[i-1][j-2] >= minSimilarity {
+				if v := best[i-2][j-2] + sim[i-2][j-1] + sim[i-1][j-2] - swapPenalty; v > score {
+					score, s = v, stepSwap
+				}
+			}
+			if i > 0 {
+				if v := best[i-1][j] - extraCellPenalty; v > score {
+					score, s = v, stepExtraCell
+				}
+			}
+			if j > 0 {
+				if v := best[i][j-1]; v > score {
+					score, s = v, stepMissingHeader
+				}
+			}
+
+			best[i][j], step[i][j] = score, s
+		}
+	}
+
+	match := &Match{
+		Layout:  l,
+		Columns: make([]string, width),
+	}
+	if m > 0 {
+		match.Score = max(best[n][m]/float64(m), 0)
+	}
+
+	// Walk the alignment back to assign the columns, the doubts are collected in reverse
+	var unsure []UnsureColumn
+	assign := func(cell, header int, kind UnsureKind) {
+		c, h := cells[cell], l.Headers[header]
+		match.Columns[c.column] = h
+		if kind == UnsureSwapped || sim[cell][header] < 1 {
+			unsure = append(unsure, UnsureColumn{
+				Kind:       kind,
+				Column:     c.column,
+				Cell:       c.text,
+				Header:     h,
+				Similarity: sim[cell][header],
+			})
+		}
+	}
+
+	for i, j := n, m; i > 0 || j > 0; {
+		switch step[i][j] {
+		case stepMatch:
+			assign(i-1, j-1, UnsureSimilar)
+			i, j = i-1, j-1
+		case stepSwap:
+			assign(i-1, j-2, UnsureSwapped)
+			assign(i-2, j-1, UnsureSwapped)
+			i, j = i-2, j-2
+		case stepExtraCell:
+			i--
+		case stepMissingHeader:
+			unsure = append(unsure, UnsureColumn{Kind: UnsureMissing, Column: -1, Header: l.Headers[j-1]})
+			j--
+		}
+	}
+
+	for k := len(unsure) - 1; k >= 0; k-- {
+		match.Unsure = append(match.Unsure, unsure[k])
+	}
+
+	return match
+}
+
+// similarity returns how much the cell looks like the closest of the patterns, from 0 to 1.
+// A pattern found on the cell is a full match, as the header cells usually add words to it.
+func similarity(cell string, patterns []string) float64 {
+	bestSim := 0.0
+	cellTokens := strings.Fields(cell)
+
+	for _, p := range patterns {
+		if p == "" {
+			continue
+		}
+		if strings.Contains(cell, p) {
+			return 1
+		}
+
+		bestSim = max(bestSim, ratio(cell, p))
+
+		// Compare the pattern with each group of as many words of the cell
+		size := len(strings.Fields(p))
+		for k := 0; k+size <= len(cellTokens); k++ {
+			bestSim = max(bestSim, ratio(strings.Join(cellTokens[k:k+size], " "), p))
+		}
+	}
+
+	return bestSim
+}
+
+// ratio is the similarity of two strings based on their edit distance.
+func ratio(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// normalizedPatterns returns the patterns of the layout as compared by the matcher. Layouts
+// not built by the loader are normalized on each call.
+func (l *Layout) normalizedPatterns() map[string][]string {
+	if l.normalized != nil {
+		return l.normalized
+	}
+	return normalizePatterns(l.Patterns)
+}
+
+func normalizePatterns(patterns map[string][]string) map[string][]string {
+	normalized := make(map[string][]string, len(patterns))
+	for header, list := range patterns {
+		for _, p := range list {
+			normalized[header] = append(normalized[header], normalizeHeader(p))
+		}
+	}
+	return normalized
+}
+
+// normalizeHeader lowercases the text and removes its accents and punctuation, so "Sección",
+// "seccion" and "SECCION." are the same header.
+func normalizeHeader(s string) string {
+	var b strings.Builder
+	for _, r := range academic.NormalizeName(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
@@ -0,0 +1,167 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+)
+
+var testLayout = Layout{
+	FileName: "test.json",
+	Headers:  []string{"item", "asignatura", "seccion", "docente", "aula"},
+	Patterns: map[string][]string{
+		"item":       {"item"},
+		"asignatura": {"asignatura", "materia"},
+		"seccion":    {"sección"},
+		"docente":    {"nombre"},
+		"aula":       {"aula"},
+	},
+}
+
+func TestBestMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		row     []string
+		ok      bool
+		score   float64
+		columns []string
+		unsure  []UnsureColumn
+	}{
+		{
+			name:    "exact",
+			row:     []string{"", "Item", "Asignatura", "Sección", "Nombre del docente", "AULA"},
+			ok:      true,
+			score:   1,
+			columns: []string{"", "item", "asignatura", "seccion", "docente", "aula"},
+		},
+		{
+			name:    "typo and missing accent",
+			row:     []string{"Item", "Asignatura", "Secion", "Nombre", "Aula"},
+			ok:      true,
+			columns: []string{"item", "asignatura", "seccion", "docente", "aula"},
+			unsure: []UnsureColumn{
+				{Kind: UnsureSimilar, Column: 2, Cell: "Secion", Header: "seccion", Similarity: 6.0 / 7},
+			},
+		},
+		{
+			name:    "swapped columns",
+			row:     []string{"Item", "Sección", "Materia", "Nombre", "Aula"},
+			ok:      true,
+			score:   0.95,
+			columns: []string{"item", "seccion", "asignatura", "docente", "aula"},
+			unsure: []UnsureColumn{
+				{Kind: UnsureSwapped, Column: 1, Cell: "Sección", Header: "seccion", Similarity: 1},
+				{Kind: UnsureSwapped, Column: 2, Cell: "Materia", Header: "asignatura", Similarity: 1},
+			},
+		},
+		{
+			// Unknown columns cost as much as a missing header
+			name:  "extra column on a short layout",
+			row:   []string{"Item", "Asignatura", "Sección", "Nombre", "Aula", "Observaciones"},
+			ok:    false,
+			score: 0.8,
+		},
+		{
+			name:  "too many missing columns",
+			row:   []string{"Item", "Asignatura", "Aula"},
+			ok:    false,
+			score: 0.6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ok := BestMatch([]Layout{testLayout}, tt.row)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v; want %v (score %.2f)", ok, tt.ok, m.Score)
+			}
+			if tt.score != 0 && !near(m.Score, tt.score) {
+				t.Errorf("score = %.3f; want %.3f", m.Score, tt.score)
+			}
+			if !ok {
+				return
+			}
+			if !reflect.DeepEqual(m.Columns, tt.columns) {
+				t.Errorf("columns = %q; want %q", m.Columns, tt.columns)
+			}
+			if len(m.Unsure) != len(tt.unsure) {
+				t.Fatalf("unsure = %+v; want %+v", m.Unsure, tt.unsure)
+			}
+			for i, u := range m.Unsure {
+				want := tt.unsure[i]
+				if u.Kind != want.Kind || u.Column != want.Column || u.Cell != want.Cell ||
+					u.Header != want.Header || !near(u.Similarity, want.Similarity) {
+					t.Errorf("unsure[%d] = %+v; want %+v", i, u, want)
+				}
+			}
+		})
+	}
+}
+
+// wideLayout is testLayout with the days of the week, wide enough to tolerate a missing or
+// an extra column.
+func wideLayout() Layout {
+	wide := Layout{
+		FileName: "wide.json",
+		Headers:  append(append([]string{}, testLayout.Headers...), "lunes", "martes", "miercoles", "jueves", "viernes"),
+		Patterns: map[string][]string{
+			"lunes": {"lunes"}, "martes": {"martes"}, "miercoles": {"miércoles"}, "jueves": {"jueves"}, "viernes": {"viernes"},
+		},
+	}
+	for k, v := range testLayout.Patterns {
+		wide.Patterns[k] = v
+	}
+	return wide
+}
+
+func TestBestMatchExtraColumn(t *testing.T) {
+	row := []string{"Item", "Asignatura", "Sección", "Nombre", "Aula", "Observaciones", "Lunes", "Martes", "Miércoles", "Jueves", "Viernes"}
+	m, ok := BestMatch([]Layout{wideLayout()}, row)
+	if !ok || !near(m.Score, 0.9) {
+		t.Fatalf("score = %.2f (ok %v); want 0.90", m.Score, ok)
+	}
+	if m.Columns[5] != "" || m.Columns[6] != "lunes" || len(m.Unsure) != 0 {
+		t.Errorf("columns = %q, unsure = %+v; want column F skipped", m.Columns, m.Unsure)
+	}
+}
+
+func TestBestMatchMissingColumn(t *testing.T) {
+	row := []string{"Item", "Asignatura", "Sección", "Nombre", "Lunes", "Martes", "Miércoles", "Jueves", "Viernes"}
+	m, ok := BestMatch([]Layout{wideLayout()}, row)
+	if !ok {
+		t.Fatalf("layout with one missing column rejected, score %.2f", m.Score)
+	}
+
+	want := UnsureColumn{Kind: UnsureMissing, Column: -1, Header: "aula"}
+	if len(m.Unsure) != 1 || m.Unsure[0] != want {
+		t.Errorf("unsure = %+v; want %+v", m.Unsure, want)
+	}
+	if m.Columns[4] != "lunes" {
+		t.Errorf("column E = %q; want lunes", m.Columns[4])
+	}
+}
+
+func TestBestMatchPicksHighestScore(t *testing.T) {
+	short := Layout{
+		FileName: "short.json",
+		Headers:  []string{"item", "asignatura"},
+		Patterns: map[string][]string{"item": {"item"}, "asignatura": {"asignatura"}},
+	}
+
+	row := []string{"Item", "Asignatura", "Sección", "Nombre", "Aula"}
+	m, ok := BestMatch([]Layout{short, testLayout}, row)
+	if !ok || m.Layout.FileName != "test.json" {
+		t.Errorf("matched %s (ok %v); want test.json", m.Layout.FileName, ok)
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for column, want := range map[int]string{-1: "", 0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := (UnsureColumn{Column: column}).ColumnName(); got != want {
+			t.Errorf("ColumnName(%d) = %q; want %q", column, got, want)
+		}
+	}
+}
+
+func near(a, b float64) bool {
+	return a-b < 1e-9 && b-a < 1e-9
+}
@@ -14,6 +14,8 @@ type WeekDayData struct {
 }
 
 type SubjectDTO struct {
+	Row int // Row number on the sheet, starting from 1
+
 	Department     string
 	Plan           string
 	Emphases       []string
@@ -53,6 +55,17 @@ type SubjectDTO struct {
 	CommitteePresident string
 	CommitteeMember1   string
 	CommitteeMember2   string
+
+	// Non empty cells that could not be parsed as dates, the import reports them as warnings
+	InvalidDates []InvalidValue
+
+	teacherLines [4]int // Lines of the first names, last names, titles and emails columns
+}
+
+// InvalidValue is a cell whose content does not match the type of its column.
+type InvalidValue struct {
+	Field string // Column name as admins know it (eg: "1er parcial")
+	Value string
 }
 
 func (s *SubjectDTO) SetDepartment(val string) {
@@ -114,44 +127,52 @@ func (s *SubjectDTO) SetShift(val string) {
 
 func (s *SubjectDTO) SetTeachersFirtNames(v string) {
 	count := commons.ScanLines(v, func(i int, l string) { s.Teachers[i].FirstName = l })
+	s.teacherLines[0] = count
 	if count > s.TeacherCount {
 		s.TeacherCount = count
 	}
 }
 func (s *SubjectDTO) SetTeachersLastNames(v string) {
 	count := commons.ScanLines(v, func(i int, l string) { s.Teachers[i].LastName = l })
+	s.teacherLines[1] = count
 	if count > s.TeacherCount {
 		s.TeacherCount = count
 	}
 }
 func (s *SubjectDTO) SetTeachersTitles(v string) {
 	count := commons.ScanLines(v, func(i int, l string) { s.Teachers[i].Title = l })
+	s.teacherLines[2] = count
 	if count > s.TeacherCount {
 		s.TeacherCount = count
 	}
 }
 func (s *SubjectDTO) SetTeachersEmails(v string) {
 	count := commons.ScanLines(v, func(i int, l string) { s.Teachers[i].Email = l })
+	s.teacherLines[3] = count
 	if count > s.TeacherCount {
 		s.TeacherCount = count
 	}
 }
 
-func (s *SubjectDTO) SetPartial1Date(val string)  { s.Partial1Date = commons.ParseDate(val) }
-func (s *SubjectDTO) SetPartial1Time(val string)  { s.Partial1Time = commons.ParseTime(val) }
-func (s *SubjectDTO) SetPartial1Room(val string)  { s.Partial1Room = val }
-func (s *SubjectDTO) SetPartial2Date(val string)  { s.Partial2Date = commons.ParseDate(val) }
-func (s *SubjectDTO) SetPartial2Time(val string)  { s.Partial2Time = commons.ParseTime(val) }
-func (s *SubjectDTO) SetPartial2Room(val string)  { s.Partial2Room = val }
-func (s *SubjectDTO) SetFinal1Date(val string)    { s.Final1Date = commons.ParseDate(val) }
-func (s *SubjectDTO) SetFinal1Time(val string)    { s.Final1Time = commons.ParseTime(val) }
-func (s *SubjectDTO) SetFinal1Room(val string)    { s.Final1Room = val }
-func (s *SubjectDTO) SetFinal1RevDate(val string) { s.Final1RevDate = commons.ParseDate(val) }
+func (s *SubjectDTO) SetPartial1Date(val string) { s.Partial1Date = s.parseDate("1er parcial", val) }
+func (s *SubjectDTO) SetPartial1Time(val string) { s.Partial1Time = commons.ParseTime(val) }
+func (s *SubjectDTO) SetPartial1Room(val string) { s.Partial1Room = val }
+func (s *SubjectDTO) SetPartial2Date(val string) { s.Partial2Date = s.parseDate("2do parcial", val) }
+func (s *SubjectDTO) SetPartial2Time(val string) { s.Partial2Time = commons.ParseTime(val) }
+func (s *SubjectDTO) SetPartial2Room(val string) { s.Partial2Room = val }
+func (s *SubjectDTO) SetFinal1Date(val string)   { s.Final1Date = s.parseDate("1er final", val) }
+func (s *SubjectDTO) SetFinal1Time(val string)   { s.Final1Time = commons.ParseTime(val) }
+func (s *SubjectDTO) SetFinal1Room(val string)   { s.Final1Room = val }
+func (s *SubjectDTO) SetFinal1RevDate(val string) {
+	s.Final1RevDate = s.parseDate("revisión 1er final", val)
+}
 func (s *SubjectDTO) SetFinal1RevTime(val string) { s.Final1RevTime = commons.ParseTime(val) }
-func (s *SubjectDTO) SetFinal2Date(val string)    { s.Final2Date = commons.ParseDate(val) }
+func (s *SubjectDTO) SetFinal2Date(val string)    { s.Final2Date = s.parseDate("2do final", val) }
 func (s *SubjectDTO) SetFinal2Time(val string)    { s.Final2Time = commons.ParseTime(val) }
 func (s *SubjectDTO) SetFinal2Room(val string)    { s.Final2Room = val }
-func (s *SubjectDTO) SetFinal2RevDate(val string) { s.Final2RevDate = commons.ParseDate(val) }
+func (s *SubjectDTO) SetFinal2RevDate(val string) {
+	s.Final2RevDate = s.parseDate("revisión 2do final", val)
+}
 func (s *SubjectDTO) SetFinal2RevTime(val string) { s.Final2RevTime = commons.ParseTime(val) }
 
 func (s *SubjectDTO) SetDayTime(day academic.WeekDay, val string) {
@@ -165,6 +186,27 @@ func (s *SubjectDTO) SetCommitteePresident(val string) { s.CommitteePresident =
 func (s *SubjectDTO) SetCommitteeMember1(val string)   { s.CommitteeMember1 = val }
 func (s *SubjectDTO) SetCommitteeMember2(val string)   { s.CommitteeMember2 = val }
 
-func (d *SubjectDTO) Reset() {
-	*d = SubjectDTO{}
+// parseDate parses the cell of a date column, keeping the value when it is not a date.
+func (s *SubjectDTO) parseDate(field, val string) commons.Date {
+	date := commons.ParseDate(val)
+	if !date.Valid && strings.TrimSpace(val) != "" {
+		s.InvalidDates = append(s.InvalidDates, InvalidValue{Field: field, Value: strings.TrimSpace(val)})
+	}
+	return date
+}
+
+// TeacherLinesMismatch reports whether the filled teacher columns have a different number of
+// lines. Teachers are paired by line, so a missing line mixes the data of two teachers.
+func (s *SubjectDTO) TeacherLinesMismatch() bool {
+	lines := 0
+	for _, n := range s.teacherLines {
+		if n == 0 {
+			continue
+		}
+		if lines != 0 && n != lines {
+			return true
+		}
+		lines = n
+	}
+	return false
 }
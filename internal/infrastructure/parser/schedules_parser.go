@@ -2,274 +2,143 @@ package parser
 
 import (
 	"io"
-	"os"
-	"path/filepath"
 	"strings"
-	"sync"
-	"unicode"
 
-	"github.com/elias-gill/poliplanner2/internal/config"
-	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/exceptions"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/commons"
 	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/layout"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/tabular"
 	"github.com/elias-gill/poliplanner2/internal/model/academic"
 	"github.com/elias-gill/poliplanner2/logger"
-	"github.com/xuri/excelize/v2"
 )
 
-var dtoPool = sync.Pool{
-	New: func() any {
-		return new(SubjectDTO)
-	},
-}
-
+// ExcelParser reads the schedules workbooks, one sheet per career.
 type ExcelParser struct {
-	layouts        []layout.Layout
-	file           *excelize.File
-	sheetNames     []string
-	currentSheet   int
-	headerKeywords []string
-	fieldSetters   map[string]func(*SubjectDTO, string)
+	engine *commons.BaseExcelEngine[SubjectDTO]
 }
 
 type ParsedSheet struct {
-	Name     string
-	Subjects []SubjectDTO
-}
-
-func NewParser(file io.ReadCloser) (*ExcelParser, error) {
-	// Initialize layout loader
-	layoutsDir := filepath.Join(config.Get().Paths.BaseDir, "internal", "infrastructure", "parser", "layout", "schedules")
-	loader := layout.NewJsonLayoutLoader(layoutsDir)
-	layouts, err := loader.LoadJsonLayouts()
-	if err != nil {
-		return nil, exceptions.NewExcelParserConfigurationException("Failed to load layouts", err)
-	}
+	Name   string
+	Layout string // File name of the layout that matched the header
 
-	p := &ExcelParser{
-		layouts:        layouts,
-		headerKeywords: []string{"item", "ítem", "DPTO.", "dpto"},
-		currentSheet:   -1,
-		fieldSetters:   buildFieldSetters(),
-	}
+	// Row of the header and its columns that were matched with doubts
+	HeaderRow int
+	Unsure    []layout.UnsureColumn
+	Subjects  []SubjectDTO
 
-	memUsageStatus("Excel parser loading", func() {
-		err = p.prepareParser(file)
-	})
-
-	if err != nil {
-		return nil, err
-	}
-	return p, nil
+	// Rows with text before the header, which are not read
+	IgnoredRows []commons.IgnoredRow
 }
 
-func (ep *ExcelParser) Close() {
-	if ep.file != nil {
-		ep.file.Close()
-		ep.file = nil
-	}
+// scheduleSheets describes the schedules workbooks for the engine.
+var scheduleSheets = commons.SheetKind[SubjectDTO]{
+	LayoutDir:      "schedules",
+	HeaderKeywords: []string{"item", "ítem", "dpto"},
+	Setters:        buildFieldSetters(),
+	Placeholders:   []string{"item", "carrera", "plataforma"},
+	ShouldParse:    shouldParseSheet,
+	FinishRow:      func(d *SubjectDTO, row int) { d.Row = row },
 }
 
-func (ep *ExcelParser) NextSheet() bool {
-	ep.currentSheet++
-	for ep.currentSheet < len(ep.sheetNames) {
-		name := ep.sheetNames[ep.currentSheet]
-		if !ep.shouldParseSheet(name) {
-			ep.currentSheet++
-			continue
-		}
-		return true
-	}
-	return false
+// ScheduleLayouts returns the layout files of the schedules workbooks.
+func ScheduleLayouts() ([]layout.Layout, error) {
+	return commons.LoadLayouts(scheduleSheets.LayoutDir)
 }
 
-func (ep *ExcelParser) ParseCurrentSheet() (*ParsedSheet, error) {
-	if ep.currentSheet < 0 || ep.currentSheet >= len(ep.sheetNames) {
-		return nil, exceptions.NewExcelParserException("No current sheet selected", nil)
-	}
-
-	sheetName := ep.sheetNames[ep.currentSheet]
-	logger.Info("Parsing", "sheet_name", sheetName)
-
-	subjects, err := ep.parseSheet(sheetName)
-	return &ParsedSheet{
-		Name:     strings.ToUpper(strings.ReplaceAll(sheetName, " ", "")),
-		Subjects: subjects,
-	}, err
-}
-
-func (ep *ExcelParser) prepareParser(file io.ReadCloser) error {
-	if ep.file != nil {
-		ep.Close()
-	}
-
-	f, err := excelize.OpenReader(file, excelize.Options{
-		UnzipSizeLimit:    25 << 20,
-		UnzipXMLSizeLimit: 8 << 20,
-	})
+// ParseScheduleLayout reads a layout of the schedules workbooks, checking that all of its
+// headers are read by the parser.
+func ParseScheduleLayout(name string, data []byte) (*layout.Layout, error) {
+	l, err := layout.Parse(name, data)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return exceptions.NewExcelParserConfigurationException("Cannot read source", err)
-		}
-		return exceptions.NewExcelParserInputException("Error reading source: ", err)
+		return nil, err
 	}
-
-	ep.file = f
-	ep.sheetNames = f.GetSheetList()
-	ep.currentSheet = -1
-	return nil
+	return l, scheduleSheets.CheckHeaders(l)
 }
 
-func (ep *ExcelParser) parseSheet(sheetName string) ([]SubjectDTO, error) {
-	subjects := make([]SubjectDTO, 0, 250)
-
-	stream, err := ep.file.Rows(sheetName)
+// NewParser opens a schedules workbook to parse it with the layout files.
+func NewParser(file io.ReadCloser) (*ExcelParser, error) {
+	layouts, err := ScheduleLayouts()
 	if err != nil {
-		return nil, exceptions.NewExcelParserInputException("Sheet not found: "+sheetName, err)
+		return nil, err
 	}
-	defer stream.Close()
-
-	var lowerHeader []string
-	var lay *layout.Layout
-	var startingCell int
-
-	for stream.Next() {
-		row, err := stream.Columns()
-		if err != nil {
-			return nil, exceptions.NewExcelParserInputException("Error reading row", err)
-		}
-
-		if len(row) == 0 || ep.isEmptyRow(row) {
-			continue
-		}
+	return NewParserWithLayouts(file, "", layouts)
+}
 
-		if lay == nil {
-			if ep.isHeaderRow(row) {
-				lowerHeader = ep.buildLowerHeader(row)
-				startingCell = ep.calculateStartingCell(row)
-				l, err := ep.findFittingLayout(lowerHeader)
-				if err != nil {
-					return nil, err
-				}
-				lay = l
-			}
-			continue
-		}
+// NewParserWithLayouts opens a schedules workbook to parse it with the given layouts. The
+// workbook can be an xlsx, ods or csv file, or a zip of csv files, one per career. The name of
+// the file names the sheet of a lone csv file.
+func NewParserWithLayouts(file io.Reader, name string, layouts []layout.Layout) (*ExcelParser, error) {
+	var engine *commons.BaseExcelEngine[SubjectDTO]
+	var err error
 
-		if ep.isEmptyRow(row) {
-			break
+	memUsageStatus("Excel parser loading", func() {
+		var book tabular.Workbook
+		if book, err = tabular.Open(file, name); err != nil {
+			return
 		}
+		engine, err = commons.NewBaseEngine(book, scheduleSheets, layouts)
+	})
 
-		d := dtoPool.Get().(*SubjectDTO)
-		d.Reset()
-		current := startingCell - 1
-
-		for _, field := range lay.Headers {
-			current++
-			if current >= len(row) {
-				break
-			}
-			val := row[current]
-			if len(val) == 0 {
-				continue
-			}
-			if setter, ok := ep.fieldSetters[field]; ok {
-				setter(d, val)
-			}
-		}
-		// Slices fijos + Structs planos = Copia segura y aislada por valor en memoria contigua
-		subjects = append(subjects, *d)
-		dtoPool.Put(d)
+	if err != nil {
+		return nil, err
 	}
+	return &ExcelParser{engine: engine}, nil
+}
 
-	if lay == nil {
-		return nil, exceptions.NewLayoutMatchException("No header row found in sheet: " + sheetName)
-	}
-	return subjects, nil
+func (ep *ExcelParser) Close() {
+	ep.engine.Close()
 }
 
-func (ep *ExcelParser) findFittingLayout(lowerHeader []string) (*layout.Layout, error) {
-	for i := range ep.layouts {
-		if ep.layoutMatches(&ep.layouts[i], lowerHeader) {
-			return &ep.layouts[i], nil
-		}
-	}
-	return nil, exceptions.NewLayoutMatchException("No matching layout found for sheet")
+func (ep *ExcelParser) NextSheet() bool {
+	return ep.engine.NextSheet()
 }
 
-func (ep *ExcelParser) layoutMatches(l *layout.Layout, lower []string) bool {
-	cellIdx, hdrIdx := 0, 0
-	for hdrIdx < len(l.Headers) && cellIdx < len(lower) {
-		val := lower[cellIdx]
-		cellIdx++
-		if val == "" {
-			continue
-		}
-		patterns, ok := l.Patterns[l.Headers[hdrIdx]]
-		if !ok {
-			return false
-		}
-		match := false
-		for _, p := range patterns {
-			if strings.Contains(val, p) { // Eliminada la alocación oculta de strings.ToLower(p)
-				match = true
-				break
-			}
-		}
-		if !match {
-			return false
-		}
-		hdrIdx++
-	}
-	return hdrIdx == len(l.Headers)
+// IgnoredSheets returns the sheets of the workbook that are not career schedules, which
+// NextSheet skips.
+func (ep *ExcelParser) IgnoredSheets() []string {
+	return ep.engine.IgnoredSheets()
 }
 
-func (ep *ExcelParser) buildLowerHeader(row []string) []string {
-	lower := make([]string, len(row))
-	for i, val := range row {
-		lower[i] = strings.ToLower(strings.TrimSpace(val))
-	}
-	return lower
+// SheetName returns the normalized name of the current sheet, the career code.
+func (ep *ExcelParser) SheetName() string {
+	return normalizeSheetName(ep.engine.CurrentSheetName())
 }
 
-func (ep *ExcelParser) isHeaderRow(row []string) bool {
-	for _, val := range row {
-		trimmed := strings.TrimSpace(val)
-		if len(trimmed) == 0 {
-			continue
-		}
-		lowerVal := strings.ToLower(trimmed)
-		for _, keyword := range ep.headerKeywords {
-			if strings.Contains(lowerVal, keyword) {
-				return true
-			}
-		}
+// StreamCurrentSheet parses the current sheet calling fn with each subject as soon as it is
+// read, so the sheet is never fully loaded in memory. The DTO is reused for the next row.
+// The returned sheet has no subjects.
+func (ep *ExcelParser) StreamCurrentSheet(fn func(*SubjectDTO) error) (*ParsedSheet, error) {
+	sheet := &ParsedSheet{Name: ep.SheetName()}
+	logger.Info("Parsing", "sheet_name", sheet.Name)
+
+	match, err := ep.engine.StreamCurrentSheet(fn)
+	sheet.IgnoredRows = ep.engine.IgnoredRows
+	if match != nil {
+		sheet.Layout = match.Layout.FileName
+		sheet.HeaderRow = match.Row
+		sheet.Unsure = match.Unsure
 	}
-	return false
+
+	return sheet, err
 }
 
-func (ep *ExcelParser) isEmptyRow(row []string) bool {
-	for _, val := range row {
-		if len(strings.TrimSpace(val)) != 0 {
-			return false
-		}
-	}
-	return true
+// ParseCurrentSheet parses all the subjects of the current sheet.
+func (ep *ExcelParser) ParseCurrentSheet() (*ParsedSheet, error) {
+	var subjects []SubjectDTO
+	sheet, err := ep.StreamCurrentSheet(func(d *SubjectDTO) error {
+		subjects = append(subjects, *d)
+		return nil
+	})
+	sheet.Subjects = subjects
+
+	return sheet, err
 }
 
-func (ep *ExcelParser) calculateStartingCell(row []string) int {
-	for i, val := range row {
-		if len(val) > 0 {
-			for _, r := range val {
-				if !unicode.IsSpace(r) {
-					return i
-				}
-			}
-		}
-	}
-	return 0
+func normalizeSheetName(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, " ", ""))
 }
 
-func (ep *ExcelParser) shouldParseSheet(name string) bool {
+// shouldParseSheet tells whether the sheet is the schedule of a career.
+func shouldParseSheet(name string) bool {
 	if len(name) == 0 {
 		return false
 	}
@@ -284,8 +153,8 @@ func (ep *ExcelParser) shouldParseSheet(name string) bool {
 	return strings.Contains(lower, "oviedo")
 }
 
-func buildFieldSetters() map[string]func(*SubjectDTO, string) {
-	return map[string]func(*SubjectDTO, string){
+func buildFieldSetters() map[string]commons.FieldSetter[SubjectDTO] {
+	return map[string]commons.FieldSetter[SubjectDTO]{
 		"departamento":       func(d *SubjectDTO, v string) { d.SetDepartment(v) },
 		"enfasis":            func(d *SubjectDTO, v string) { d.SetEmphases(v) },
 		"plan":               func(d *SubjectDTO, v string) { d.SetPlan(v) },
@@ -0,0 +1,192 @@
+package tabular
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+// csvWorkbook reads CSV files as sheets, one sheet per file named after it.
+type csvWorkbook struct {
+	names  []string
+	sheets map[string][]byte
+}
+
+// openCSV opens a lone CSV file, a workbook with a single sheet.
+func openCSV(data []byte, name string) (Workbook, error) {
+	sheet := csvSheetName(name)
+	return &csvWorkbook{
+		names:  []string{sheet},
+		sheets: map[string][]byte{sheet: data},
+	}, nil
+}
+
+// openCSVZip opens a zip of CSV files, eg: one per career.
+func openCSVZip(files []*zip.File) (Workbook, error) {
+	w := &csvWorkbook{sheets: make(map[string][]byte, len(files))}
+	var size int64
+
+	for _, f := range files {
+		name := csvSheetName(f.Name)
+		if _, ok := w.sheets[name]; ok {
+			return nil, fmt.Errorf("more than one file for the sheet %q", name)
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("cannot open %s: %w", f.Name, err)
+		}
+		data, err := io.ReadAll(io.LimitReader(rc, maxUnzipSize-size+1))
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read %s: %w", f.Name, err)
+		}
+
+		size += int64(len(data))
+		if size > maxUnzipSize {
+			return nil, fmt.Errorf("the files are larger than %d MiB uncompressed", maxUnzipSize>>20)
+		}
+
+		w.names = append(w.names, name)
+		w.sheets[name] = data
+	}
+
+	return w, nil
+}
+
+// csvSheetName names the sheet of a CSV file after it. Google Sheets exports each sheet as
+// "<spreadsheet> - <sheet>.csv", so only the sheet is kept.
+func csvSheetName(fileName string) string {
+	name := path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	name = strings.TrimSuffix(name, path.Ext(name))
+	if i := strings.LastIndex(name, " - "); i >= 0 {
+		name = name[i+len(" - "):]
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." {
+		return "Hoja1"
+	}
+	return name
+}
+
+func (w *csvWorkbook) SheetNames() []string {
+	return w.names
+}
+
+func (w *csvWorkbook) Rows(sheet string) (Rows, error) {
+	data, ok := w.sheets[sheet]
+	if !ok {
+		return nil, fmt.Errorf("sheet %s does not exist", sheet)
+	}
+
+	data = csvText(data)
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = csvDelimiter(data)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	return &csvRows{reader: reader}, nil
+}
+
+func (w *csvWorkbook) Close() error {
+	w.sheets = nil
+	return nil
+}
+
+// csvText drops the byte order mark, and converts files saved as Latin-1 (the default of
+// Excel on Windows) to UTF-8.
+func csvText(data []byte) []byte {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if utf8.Valid(data) {
+		return data
+	}
+
+	text := make([]rune, len(data))
+	for i, b := range data {
+		text[i] = rune(b)
+	}
+	return []byte(string(text))
+}
+
+// csvDelimiter guesses the delimiter from the first line: Google Sheets exports with commas,
+// while LibreOffice and Excel in spanish use semicolons.
+func csvDelimiter(data []byte) rune {
+	line, _, _ := bytes.Cut(data, []byte("\n"))
+
+	delimiter, most := ',', 0
+	for _, d := range []rune{',', ';', '\t'} {
+		if n := bytes.Count(line, []byte(string(d))); n > most {
+			delimiter, most = d, n
+		}
+	}
+	return delimiter
+}
+
+type csvRows struct {
+	reader *csv.Reader
+	row    []string
+	err    error
+
+	// The reader skips blank lines, which are still rows of the sheet: the record read is
+	// held until the empty rows before it were returned, so row numbers match the file.
+	next     []string
+	nextLine int
+	line     int // Last line of the current row
+}
+
+func (r *csvRows) Next() bool {
+	if r.err != nil {
+		return false
+	}
+
+	if r.next == nil {
+		record, err := r.reader.Read()
+		if err == io.EOF {
+			return false
+		}
+		if err != nil {
+			r.err = err
+			return true
+		}
+		r.next = record
+		r.nextLine, _ = r.reader.FieldPos(0)
+	}
+
+	if r.line+1 < r.nextLine {
+		r.row = nil
+		r.line++
+		return true
+	}
+
+	// Quoted cells can span several lines
+	last := len(r.next) - 1
+	lastLine, _ := r.reader.FieldPos(last)
+	r.line = lastLine + strings.Count(r.next[last], "\n")
+
+	r.row, r.next = r.next, nil
+	return true
+}
+
+func (r *csvRows) Columns() ([]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	// Trailing empty cells are dropped, like on the other formats
+	end := len(r.row)
+	for end > 0 && r.row[end-1] == "" {
+		end--
+	}
+	return r.row[:end], nil
+}
+
+func (r *csvRows) Close() error {
+	return nil
+}
@@ -0,0 +1,339 @@
+package tabular
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	odsMimeType = "application/vnd.oasis.opendocument.spreadsheet"
+
+	odsTableNS  = "urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	odsTextNS   = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+	odsOfficeNS = "urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+
+	// Columns of a row beyond this are dropped, as a repeated cell can claim thousands of them
+	odsMaxColumns = 16384
+)
+
+// odsWorkbook reads OpenDocument spreadsheets (LibreOffice). The content is decoded as the
+// rows are read, so only the current row is kept in memory.
+type odsWorkbook struct {
+	content *zip.File
+	names   []string
+
+	// Decoder of the sheet read last, kept as the sheets are usually read in order. Reading
+	// an earlier sheet decodes the content again from the start.
+	decoder *xml.Decoder
+	closer  io.Closer
+	sheet   int // Index of the sheet the decoder is on
+}
+
+func openODS(archive *zip.Reader) (Workbook, error) {
+	var mimetype, content *zip.File
+	for _, f := range archive.File {
+		switch f.Name {
+		case "mimetype":
+			mimetype = f
+		case "content.xml":
+			content = f
+		}
+	}
+
+	kind, err := readZipFile(mimetype, 256)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(string(kind)) != odsMimeType {
+		return nil, fmt.Errorf("%w, got an OpenDocument %s", errUnknownFormat, kind)
+	}
+	if content == nil {
+		return nil, fmt.Errorf("the spreadsheet has no content.xml")
+	}
+
+	w := &odsWorkbook{content: content}
+	if err := w.readSheetNames(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func readZipFile(f *zip.File, limit int64) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, limit))
+}
+
+// open returns a decoder over the content, along with its closer.
+func (w *odsWorkbook) open() (*xml.Decoder, io.Closer, error) {
+	rc, err := w.content.Open()
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot open content.xml: %w", err)
+	}
+	return xml.NewDecoder(io.LimitReader(rc, maxUnzipSize)), rc, nil
+}
+
+func (w *odsWorkbook) readSheetNames() error {
+	decoder, closer, err := w.open()
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid content.xml: %w", err)
+		}
+
+		if start, ok := tok.(xml.StartElement); ok && isElement(start.Name, odsTableNS, "table") {
+			w.names = append(w.names, attr(start, odsTableNS, "name"))
+			if err := decoder.Skip(); err != nil {
+				return fmt.Errorf("invalid content.xml: %w", err)
+			}
+		}
+	}
+}
+
+func (w *odsWorkbook) SheetNames() []string {
+	return w.names
+}
+
+func (w *odsWorkbook) Rows(sheet string) (Rows, error) {
+	target := slices.Index(w.names, sheet)
+	if target < 0 {
+		return nil, fmt.Errorf("sheet %s does not exist", sheet)
+	}
+
+	if w.decoder == nil || w.sheet >= target {
+		w.Close()
+
+		decoder, closer, err := w.open()
+		if err != nil {
+			return nil, err
+		}
+		w.decoder, w.closer, w.sheet = decoder, closer, -1
+	}
+
+	for {
+		tok, err := w.decoder.Token()
+		if err != nil {
+			w.Close()
+			return nil, fmt.Errorf("invalid content.xml: %w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok || !isElement(start.Name, odsTableNS, "table") {
+			continue
+		}
+
+		w.sheet++
+		if w.sheet == target {
+			return &odsRows{decoder: w.decoder}, nil
+		}
+		if err := w.decoder.Skip(); err != nil {
+			w.Close()
+			return nil, fmt.Errorf("invalid content.xml: %w", err)
+		}
+	}
+}
+
+func (w *odsWorkbook) Close() error {
+	if w.closer == nil {
+		return nil
+	}
+
+	err := w.closer.Close()
+	w.decoder, w.closer = nil, nil
+	return err
+}
+
+// odsRows reads the rows of a table. Rows and cells can be repeated by an attribute instead of
+// being written again, empty rows to the end of the sheet often are.
+type odsRows struct {
+	decoder *xml.Decoder // Shared with the workbook, which closes it
+
+	row    []string
+	repeat int // Times left to return the current row again
+	done   bool
+	err    error
+}
+
+func (r *odsRows) Next() bool {
+	if r.done || r.err != nil {
+		return false
+	}
+	if r.repeat > 0 {
+		r.repeat--
+		return true
+	}
+
+	for {
+		tok, err := r.decoder.Token()
+		if err != nil {
+			r.err = fmt.Errorf("invalid content.xml: %w", err)
+			return true
+		}
+
+		switch el := tok.(type) {
+		case xml.StartElement:
+			switch {
+			case isElement(el.Name, odsTableNS, "table-row"):
+				r.row, r.err = readRow(r.decoder)
+				r.repeat = repeated(el, odsTableNS, "number-rows-repeated") - 1
+				return true
+
+			case isElement(el.Name, odsTableNS, "table-header-rows"),
+				isElement(el.Name, odsTableNS, "table-row-group"),
+				isElement(el.Name, odsTableNS, "table-rows"):
+				// Rows grouped on the sheet, read as any other row
+
+			default:
+				if err := r.decoder.Skip(); err != nil {
+					r.err = fmt.Errorf("invalid content.xml: %w", err)
+					return true
+				}
+			}
+
+		case xml.EndElement:
+			if isElement(el.Name, odsTableNS, "table") {
+				r.done = true
+				return false
+			}
+		}
+	}
+}
+
+func (r *odsRows) Columns() ([]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return slices.Clone(r.row), nil
+}
+
+func (r *odsRows) Close() error {
+	r.done = true
+	return nil
+}
+
+// readRow reads the cells of the row up to the last one with a value.
+func readRow(decoder *xml.Decoder) ([]string, error) {
+	var cells []string
+	empty := 0 // Empty cells not added yet, only needed before a cell with a value
+
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("invalid content.xml: %w", err)
+		}
+
+		switch el := tok.(type) {
+		case xml.StartElement:
+			if !isElement(el.Name, odsTableNS, "table-cell") && !isElement(el.Name, odsTableNS, "covered-table-cell") {
+				if err := decoder.Skip(); err != nil {
+					return nil, fmt.Errorf("invalid content.xml: %w", err)
+				}
+				continue
+			}
+
+			text, err := readCellText(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			n := repeated(el, odsTableNS, "number-columns-repeated")
+			if text == "" {
+				empty += n
+				continue
+			}
+			for range min(n, odsMaxColumns-len(cells)-empty) {
+				for ; empty > 0; empty-- {
+					cells = append(cells, "")
+				}
+				cells = append(cells, text)
+			}
+
+		case xml.EndElement:
+			return cells, nil
+		}
+	}
+}
+
+// readCellText reads the text of the cell as displayed, its paragraphs on separate lines.
+func readCellText(decoder *xml.Decoder) (string, error) {
+	var text strings.Builder
+	paragraphs := 0
+	depth := 1
+
+	for depth > 0 {
+		tok, err := decoder.Token()
+		if err != nil {
+			return "", fmt.Errorf("invalid content.xml: %w", err)
+		}
+
+		switch el := tok.(type) {
+		case xml.StartElement:
+			switch {
+			case isElement(el.Name, odsOfficeNS, "annotation"):
+				// Comments of the cell are not part of its value
+				if err := decoder.Skip(); err != nil {
+					return "", fmt.Errorf("invalid content.xml: %w", err)
+				}
+				continue
+			case isElement(el.Name, odsTextNS, "p"):
+				if paragraphs > 0 {
+					text.WriteByte('\n')
+				}
+				paragraphs++
+			case isElement(el.Name, odsTextNS, "s"):
+				text.WriteString(strings.Repeat(" ", repeated(el, odsTextNS, "c")))
+			case isElement(el.Name, odsTextNS, "tab"):
+				text.WriteByte('\t')
+			case isElement(el.Name, odsTextNS, "line-break"):
+				text.WriteByte('\n')
+			}
+			depth++
+
+		case xml.EndElement:
+			depth--
+
+		case xml.CharData:
+			text.Write(el)
+		}
+	}
+
+	return text.String(), nil
+}
+
+func isElement(name xml.Name, space, local string) bool {
+	return name.Space == space && name.Local == local
+}
+
+func attr(el xml.StartElement, space, local string) string {
+	for _, a := range el.Attr {
+		if a.Name.Space == space && a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// repeated returns the count of a repetition attribute, 1 when it is missing.
+func repeated(el xml.StartElement, space, local string) int {
+	n, err := strconv.Atoi(attr(el, space, local))
+	if err != nil || n < 1 {
+		return 1
+	}
+	return n
+}
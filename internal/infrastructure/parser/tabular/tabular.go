@@ -0,0 +1,115 @@
+// Package tabular reads the sheets of the spreadsheet formats the faculties publish (xlsx,
+// ods and csv) as rows of text cells, so the parser engine does not depend on a format.
+package tabular
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/exceptions"
+)
+
+// Limit of the size of the files, and of the uncompressed size of the zip based ones
+const (
+	maxFileSize  = 25 << 20 // 25 MiB
+	maxUnzipSize = 25 << 20 // 25 MiB
+)
+
+var errUnknownFormat = errors.New("unknown file format, expected xlsx, ods, csv or a zip of csv files")
+
+// Workbook is a spreadsheet with named sheets, read one row at a time.
+type Workbook interface {
+	// Names of the sheets in the order of the file
+	SheetNames() []string
+
+	// Rows opens a stream over the rows of the sheet, from the first one of the sheet
+	Rows(sheet string) (Rows, error)
+
+	Close() error
+}
+
+// Rows streams the rows of a sheet, like sql.Rows.
+type Rows interface {
+	Next() bool
+
+	// Columns returns the cells of the current row as displayed, empty ones included up to
+	// the last cell with a value. The slice is not reused by the next row.
+	Columns() ([]string, error)
+
+	Close() error
+}
+
+// Open reads the file and opens it with the backend of its format, which is sniffed from the
+// content. The name of the file is only used to name the sheet of a lone CSV file.
+func Open(r io.Reader, name string) (Workbook, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxFileSize+1))
+	if err != nil {
+		return nil, exceptions.NewExcelParserConfigurationException("Cannot read source", err)
+	}
+	if len(data) > maxFileSize {
+		return nil, exceptions.NewExcelParserInputException("Error reading source: ",
+			fmt.Errorf("the file is larger than %d MiB", maxFileSize>>20))
+	}
+
+	var book Workbook
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		book, err = openZip(data)
+	case isText(data):
+		book, err = openCSV(data, name)
+	default:
+		err = errUnknownFormat
+	}
+	if err != nil {
+		return nil, exceptions.NewExcelParserInputException("Error reading source: ", err)
+	}
+	return book, nil
+}
+
+// openZip opens the zip based formats: xlsx and ods are zip files too, told apart by their
+// entries.
+func openZip(data []byte) (Workbook, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	var csvFiles []*zip.File
+	others := false
+
+	for _, f := range archive.File {
+		switch {
+		case f.Name == "[Content_Types].xml":
+			return openXLSX(data)
+		case f.Name == "mimetype":
+			return openODS(archive)
+		case f.FileInfo().IsDir() || isHidden(f.Name):
+			continue
+		case strings.EqualFold(path.Ext(f.Name), ".csv"):
+			csvFiles = append(csvFiles, f)
+		default:
+			others = true
+		}
+	}
+
+	if len(csvFiles) == 0 || others {
+		return nil, errUnknownFormat
+	}
+	return openCSVZip(csvFiles)
+}
+
+// isHidden tells if the zip entry is metadata added by the OS, eg: __MACOSX/._IIN.csv.
+func isHidden(name string) bool {
+	return strings.HasPrefix(name, "__MACOSX/") || strings.HasPrefix(path.Base(name), ".")
+}
+
+// isText tells if the content looks like text, as binary formats have NUL bytes early.
+func isText(data []byte) bool {
+	head := data[:min(len(data), 8<<10)]
+	return len(head) > 0 && bytes.IndexByte(head, 0) < 0
+}
@@ -0,0 +1,240 @@
+package tabular
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestOpenXLSX(t *testing.T) {
+	f := excelize.NewFile()
+	f.SetSheetName("Sheet1", "IIN")
+	f.SetSheetRow("IIN", "A2", &[]any{"Item", "Asignatura", nil, "Sección"})
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("cannot build workbook: %v", err)
+	}
+
+	assertSheets(t, open(t, buf.Bytes(), "horarios.xlsx"), map[string][][]string{
+		"IIN": {nil, {"Item", "Asignatura", "", "Sección"}},
+	})
+}
+
+func TestOpenCSV(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+		want map[string][][]string
+	}{
+		{
+			name: "google sheets export",
+			file: "Horarios 2026 - IIN.csv",
+			data: "Item,Asignatura,Sección,,\n1,\"Cálculo I, parte 1\",TQ,,\n",
+			want: map[string][][]string{"IIN": {{"Item", "Asignatura", "Sección"}, {"1", "Cálculo I, parte 1", "TQ"}}},
+		},
+		{
+			name: "libreoffice with semicolons and byte order mark",
+			file: "ISP.csv",
+			data: "\xef\xbb\xbfItem;Asignatura;Horario\n;;\n1;\"Física\nI\";07:30 - 09:00\n",
+			want: map[string][][]string{"ISP": {{"Item", "Asignatura", "Horario"}, {}, {"1", "Física\nI", "07:30 - 09:00"}}},
+		},
+		{
+			name: "blank lines",
+			file: "IEK.csv",
+			data: "Item;Nota\n1;\"a\nb\"\n\n2;c\n",
+			want: map[string][][]string{"IEK": {{"Item", "Nota"}, {"1", "a\nb"}, {}, {"2", "c"}}},
+		},
+		{
+			name: "latin-1",
+			file: "LCIK.csv",
+			data: "Item;Secci\xf3n\n",
+			want: map[string][][]string{"LCIK": {{"Item", "Sección"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSheets(t, open(t, []byte(tt.data), tt.file), tt.want)
+		})
+	}
+}
+
+func TestOpenCSVZip(t *testing.T) {
+	data := zipFiles(t, [][2]string{
+		{"Horarios - IIN.csv", "Item,Asignatura\n1,Cálculo I\n"},
+		{"__MACOSX/._Horarios - IIN.csv", "\x00\x05"},
+		{"carreras/ISP.csv", "Item;Asignatura\n"},
+	})
+
+	book := open(t, data, "horarios.zip")
+	if want := []string{"IIN", "ISP"}; !reflect.DeepEqual(book.SheetNames(), want) {
+		t.Fatalf("SheetNames() = %q; want %q", book.SheetNames(), want)
+	}
+	assertSheets(t, book, map[string][][]string{
+		"IIN": {{"Item", "Asignatura"}, {"1", "Cálculo I"}},
+		"ISP": {{"Item", "Asignatura"}},
+	})
+}
+
+const odsContent = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content
+	xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+<office:body><office:spreadsheet>
+	<table:table table:name="Notas">
+		<table:table-row><table:table-cell><text:p>Sin horarios</text:p></table:table-cell></table:table-row>
+	</table:table>
+	<table:table table:name="IIN">
+		<table:table-column table:number-columns-repeated="1024"/>
+		<table:table-row table:number-rows-repeated="2"><table:table-cell table:number-columns-repeated="1024"/></table:table-row>
+		<table:table-header-rows>
+			<table:table-row>
+				<table:table-cell><text:p>Item</text:p></table:table-cell>
+				<table:table-cell table:number-columns-spanned="2"><office:annotation><text:p>revisar</text:p></office:annotation><text:p>Nombre de la<text:s text:c="2"/>Asignatura</text:p></table:table-cell>
+				<table:covered-table-cell/>
+				<table:table-cell><text:p>Horario</text:p><text:p><text:span>(semana)</text:span></text:p></table:table-cell>
+				<table:table-cell table:number-columns-repeated="1020"/>
+			</table:table-row>
+		</table:table-header-rows>
+		<table:table-row-group>
+			<table:table-row table:number-rows-repeated="2">
+				<table:table-cell office:value-type="float" office:value="1"><text:p>1</text:p></table:table-cell>
+				<table:table-cell table:number-columns-repeated="2"><text:p>x</text:p></table:table-cell>
+			</table:table-row>
+		</table:table-row-group>
+		<table:table-row table:number-rows-repeated="1048570"><table:table-cell table:number-columns-repeated="1024"/></table:table-row>
+	</table:table>
+</office:spreadsheet></office:body>
+</office:document-content>`
+
+func TestOpenODS(t *testing.T) {
+	data := zipFiles(t, [][2]string{
+		{"mimetype", "application/vnd.oasis.opendocument.spreadsheet"},
+		{"content.xml", odsContent},
+	})
+
+	book := open(t, data, "horarios.ods")
+	if want := []string{"Notas", "IIN"}; !reflect.DeepEqual(book.SheetNames(), want) {
+		t.Fatalf("SheetNames() = %q; want %q", book.SheetNames(), want)
+	}
+
+	rows, err := book.Rows("IIN")
+	if err != nil {
+		t.Fatalf("Rows() = %v", err)
+	}
+	defer rows.Close()
+
+	// The empty rows repeated to the end of the sheet are read too, so only the first ones
+	// are checked
+	var got [][]string
+	count := 0
+	for rows.Next() {
+		row, err := rows.Columns()
+		if err != nil {
+			t.Fatalf("Columns() = %v", err)
+		}
+		if count < 5 {
+			got = append(got, row)
+		}
+		count++
+	}
+
+	want := [][]string{
+		nil,
+		nil,
+		{"Item", "Nombre de la  Asignatura", "", "Horario\n(semana)"},
+		{"1", "x", "x"},
+		{"1", "x", "x"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %q; want %q", got, want)
+	}
+	if count != 1048575 {
+		t.Errorf("read %d rows; want 1048575", count)
+	}
+}
+
+func TestOpenUnknownFormat(t *testing.T) {
+	tests := map[string][]byte{
+		"xls":          []byte("\xd0\xcf\x11\xe0\xa1\xb1\x1a\xe1\x00\x00"),
+		"empty":        nil,
+		"odt":          zipFiles(t, [][2]string{{"mimetype", "application/vnd.oasis.opendocument.text"}, {"content.xml", "<a/>"}}),
+		"zip of pdfs":  zipFiles(t, [][2]string{{"IIN.pdf", "%PDF"}}),
+		"zip with csv": zipFiles(t, [][2]string{{"IIN.csv", "Item"}, {"notas.txt", "-"}}),
+	}
+
+	for name, data := range tests {
+		if book, err := Open(bytes.NewReader(data), name); err == nil {
+			t.Errorf("%s: Open() = %q; want an error", name, book.SheetNames())
+		}
+	}
+}
+
+func open(t *testing.T, data []byte, name string) Workbook {
+	t.Helper()
+
+	book, err := Open(bytes.NewReader(data), name)
+	if err != nil {
+		t.Fatalf("Open(%s) = %v", name, err)
+	}
+	t.Cleanup(func() { book.Close() })
+	return book
+}
+
+// assertSheets checks every row of the sheets.
+func assertSheets(t *testing.T, book Workbook, want map[string][][]string) {
+	t.Helper()
+
+	for sheet, wantRows := range want {
+		rows, err := book.Rows(sheet)
+		if err != nil {
+			t.Fatalf("Rows(%s) = %v", sheet, err)
+		}
+
+		var got [][]string
+		for rows.Next() {
+			row, err := rows.Columns()
+			if err != nil {
+				t.Fatalf("Columns() = %v", err)
+			}
+			got = append(got, row)
+		}
+		rows.Close()
+
+		if len(got) != len(wantRows) {
+			t.Errorf("%s rows = %q; want %q", sheet, got, wantRows)
+			continue
+		}
+		for i := range got {
+			if len(got[i]) != 0 || len(wantRows[i]) != 0 {
+				if !reflect.DeepEqual(got[i], wantRows[i]) {
+					t.Errorf("%s row %d = %q; want %q", sheet, i+1, got[i], wantRows[i])
+				}
+			}
+		}
+	}
+}
+
+func zipFiles(t *testing.T, files [][2]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range files {
+		fw, err := w.Create(f[0])
+		if err != nil {
+			t.Fatalf("cannot build zip: %v", err)
+		}
+		fw.Write([]byte(f[1]))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("cannot build zip: %v", err)
+	}
+	return buf.Bytes()
+}
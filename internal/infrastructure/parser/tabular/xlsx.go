@@ -0,0 +1,48 @@
+package tabular
+
+import (
+	"bytes"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxWorkbook reads Excel workbooks through excelize.
+type xlsxWorkbook struct {
+	file *excelize.File
+}
+
+func openXLSX(data []byte) (Workbook, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(data), excelize.Options{
+		UnzipSizeLimit:    maxUnzipSize,
+		UnzipXMLSizeLimit: 8 << 20,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &xlsxWorkbook{file: f}, nil
+}
+
+func (w *xlsxWorkbook) SheetNames() []string {
+	return w.file.GetSheetList()
+}
+
+func (w *xlsxWorkbook) Rows(sheet string) (Rows, error) {
+	rows, err := w.file.Rows(sheet)
+	if err != nil {
+		return nil, err
+	}
+	return xlsxRows{rows}, nil
+}
+
+func (w *xlsxWorkbook) Close() error {
+	return w.file.Close()
+}
+
+// xlsxRows drops the options of excelize.Rows.Columns to fit Rows.
+type xlsxRows struct {
+	*excelize.Rows
+}
+
+func (r xlsxRows) Columns() ([]string, error) {
+	return r.Rows.Columns()
+}
@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/elias-gill/poliplanner2/internal/config"
@@ -13,6 +14,10 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// ErrNoFTS5 is returned when the SQLite driver was built without the FTS5 module, which the
+// search index needs.
+var ErrNoFTS5 = errors.New("SQLite has no FTS5 module, build with -tags sqlite_fts5")
+
 type DbConnection struct {
 	db *sql.DB
 }
@@ -41,9 +46,26 @@ func ConnectDB() (*DbConnection, error) {
 	}
 	log.Debug("Foreign keys enabled successfully")
 
+	// Checked before the migrations, which would fail halfway and leave the database dirty
+	if err := CheckFTS5(db); err != nil {
+		return nil, err
+	}
+
 	return &DbConnection{db: db}, err
 }
 
+// CheckFTS5 checks that the SQLite driver was built with the FTS5 module.
+func CheckFTS5(db *sql.DB) error {
+	var enabled bool
+	if err := db.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&enabled); err != nil {
+		return fmt.Errorf("cannot check for FTS5: %w", err)
+	}
+	if !enabled {
+		return ErrNoFTS5
+	}
+	return nil
+}
+
 func RunMigrations() error {
 	cfg := config.Get()
 
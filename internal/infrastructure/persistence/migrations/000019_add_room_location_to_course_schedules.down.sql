@@ -0,0 +1,3 @@
+ALTER TABLE curso_horarios DROP COLUMN numero_aula;
+ALTER TABLE curso_horarios DROP COLUMN edificio;
+ALTER TABLE curso_horarios DROP COLUMN modalidad;
@@ -0,0 +1,19 @@
+-- Ubicación estructurada de cada sesión de clase.
+--   modalidad: 0 = desconocida, 1 = presencial, 2 = virtual
+ALTER TABLE curso_horarios ADD COLUMN modalidad INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE curso_horarios ADD COLUMN edificio TEXT;
+ALTER TABLE curso_horarios ADD COLUMN numero_aula TEXT;
+
+-- Clasificación inicial de los datos existentes. La próxima importación de la planilla
+-- sobreescribe estos valores con la clasificación completa del mapper.
+UPDATE curso_horarios
+SET modalidad = 2
+WHERE lower(aula) LIKE '%virtual%'
+   OR lower(aula) LIKE '%plataforma%'
+   OR lower(aula) LIKE '%teams%';
+
+UPDATE curso_horarios
+SET modalidad = 1
+WHERE modalidad = 0
+  AND trim(COALESCE(aula, '')) <> ''
+  AND upper(trim(aula)) <> 'A CONFIRMAR';
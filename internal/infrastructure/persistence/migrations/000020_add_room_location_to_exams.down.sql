@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_examenes_aula;
+DROP INDEX IF EXISTS idx_curso_horarios_aula;
+
+ALTER TABLE examenes DROP COLUMN numero_aula;
+ALTER TABLE examenes DROP COLUMN edificio;
+ALTER TABLE examenes DROP COLUMN modalidad;
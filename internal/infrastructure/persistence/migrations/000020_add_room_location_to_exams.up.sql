@@ -0,0 +1,21 @@
+-- Misma clasificación de aulas que curso_horarios (ver migración 000019).
+--   modalidad: 0 = desconocida, 1 = presencial, 2 = virtual
+ALTER TABLE examenes ADD COLUMN modalidad INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE examenes ADD COLUMN edificio TEXT;
+ALTER TABLE examenes ADD COLUMN numero_aula TEXT;
+
+UPDATE examenes
+SET modalidad = 2
+WHERE lower(aula) LIKE '%virtual%'
+   OR lower(aula) LIKE '%plataforma%'
+   OR lower(aula) LIKE '%teams%';
+
+UPDATE examenes
+SET modalidad = 1
+WHERE modalidad = 0
+  AND trim(COALESCE(aula, '')) <> ''
+  AND upper(trim(aula)) <> 'A CONFIRMAR';
+
+-- Índices para el directorio de aulas
+CREATE INDEX IF NOT EXISTS idx_curso_horarios_aula ON curso_horarios(modalidad, edificio, numero_aula);
+CREATE INDEX IF NOT EXISTS idx_examenes_aula ON examenes(modalidad, edificio, numero_aula);
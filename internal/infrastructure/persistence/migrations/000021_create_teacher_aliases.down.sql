@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_docentes_alias_docente;
+DROP TABLE IF EXISTS docentes_alias;
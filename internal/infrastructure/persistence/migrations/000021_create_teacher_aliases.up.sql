@@ -0,0 +1,12 @@
+-- Nombres alternativos de docentes fusionados desde la pantalla de administración.
+-- El Upsert de docentes consulta esta tabla para que una fusión no se deshaga en la
+-- siguiente importación, cuando el Excel vuelve a traer la variante del nombre.
+CREATE TABLE IF NOT EXISTS docentes_alias (
+    nombre TEXT NOT NULL,
+    apellido TEXT NOT NULL,
+    id_docente INTEGER NOT NULL REFERENCES docentes(id) ON DELETE CASCADE ON UPDATE CASCADE,
+
+    PRIMARY KEY (nombre, apellido)
+);
+
+CREATE INDEX IF NOT EXISTS idx_docentes_alias_docente ON docentes_alias(id_docente);
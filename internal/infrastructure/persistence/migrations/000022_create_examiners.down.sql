@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_mesas_curso_examinador;
+DROP TABLE IF EXISTS mesas_curso;
+
+DROP INDEX IF EXISTS idx_examinadores_docente;
+DROP TABLE IF EXISTS examinadores;
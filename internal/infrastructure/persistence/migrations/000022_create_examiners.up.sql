@@ -0,0 +1,25 @@
+-- Miembros de mesas examinadoras. En el Excel son texto libre con el título incluido
+-- ("Ing. Juan Pérez"), por eso se guardan normalizados en "clave" para reconocer a la misma
+-- persona entre cursos. Cuando existe un docente con el mismo nombre se vincula.
+CREATE TABLE IF NOT EXISTS examinadores (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    nombre TEXT NOT NULL,
+    clave TEXT NOT NULL UNIQUE,
+    id_docente INTEGER REFERENCES docentes(id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_examinadores_docente ON examinadores(id_docente);
+
+--   rol: 0 = presidente, 1 = miembro
+CREATE TABLE IF NOT EXISTS mesas_curso (
+    id_curso INTEGER NOT NULL REFERENCES cursos(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    id_examinador INTEGER NOT NULL REFERENCES examinadores(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    rol INTEGER NOT NULL DEFAULT 1,
+
+    PRIMARY KEY (id_curso, id_examinador)
+);
+
+CREATE INDEX IF NOT EXISTS idx_mesas_curso_examinador ON mesas_curso(id_examinador);
+
+-- Los cursos existentes se completan desde la aplicación al iniciar, ya que la clave
+-- necesita la misma normalización que se usa al importar.
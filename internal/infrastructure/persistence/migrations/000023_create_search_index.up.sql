@@ -0,0 +1,15 @@
+-- Índice de búsqueda global (FTS5). Cada fila es un resultado: un curso, una asignatura, un
+-- docente o un aula. Solo "texto" se indexa; el resto se guarda para mostrar el resultado
+-- sin volver a consultar las demás tablas.
+--
+-- El índice se reconstruye desde la aplicación después de cada importación exitosa.
+--
+-- NOTA: requiere compilar con el tag "sqlite_fts5".
+CREATE VIRTUAL TABLE IF NOT EXISTS busqueda USING fts5(
+    tipo UNINDEXED,
+    ref UNINDEXED,
+    titulo UNINDEXED,
+    detalle UNINDEXED,
+    texto,
+    tokenize = 'unicode61 remove_diacritics 2'
+);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_periodos_fechas;
+
+ALTER TABLE periodos DROP COLUMN finales_fin;
+ALTER TABLE periodos DROP COLUMN finales_inicio;
+ALTER TABLE periodos DROP COLUMN parciales_fin;
+ALTER TABLE periodos DROP COLUMN parciales_inicio;
+ALTER TABLE periodos DROP COLUMN clases_fin;
+ALTER TABLE periodos DROP COLUMN clases_inicio;
+ALTER TABLE periodos DROP COLUMN fecha_fin;
+ALTER TABLE periodos DROP COLUMN fecha_inicio;
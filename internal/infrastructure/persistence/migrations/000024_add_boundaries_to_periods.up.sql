@@ -0,0 +1,16 @@
+-- Límites de cada período académico, cargados por un administrador. Las fechas se guardan
+-- como 'YYYY-MM-DD' (hora de Paraguay) y todos los rangos son inclusivos.
+--   fecha_inicio/fecha_fin: duración total del período, desde el inicio de clases hasta el
+--   cierre de los finales. Cuando es NULL se usa el cálculo por fecha de la aplicación.
+--   clases_*: semanas de clases.
+--   parciales_*, finales_*: ventanas de exámenes.
+ALTER TABLE periodos ADD COLUMN fecha_inicio TEXT;
+ALTER TABLE periodos ADD COLUMN fecha_fin TEXT;
+ALTER TABLE periodos ADD COLUMN clases_inicio TEXT;
+ALTER TABLE periodos ADD COLUMN clases_fin TEXT;
+ALTER TABLE periodos ADD COLUMN parciales_inicio TEXT;
+ALTER TABLE periodos ADD COLUMN parciales_fin TEXT;
+ALTER TABLE periodos ADD COLUMN finales_inicio TEXT;
+ALTER TABLE periodos ADD COLUMN finales_fin TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_periodos_fechas ON periodos(fecha_inicio, fecha_fin);
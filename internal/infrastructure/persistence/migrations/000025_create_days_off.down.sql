@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_dias_sin_clase_fechas;
+DROP TABLE IF EXISTS dias_sin_clase;
@@ -0,0 +1,16 @@
+-- Feriados y días sin clases del calendario académico, importados por un administrador desde
+-- un archivo ICS o CSV. Las fechas se guardan como 'YYYY-MM-DD' y el rango es inclusivo, un
+-- feriado de un solo día tiene desde = hasta.
+--   tipo: 0 = feriado, 1 = receso, 2 = otro día sin clases
+CREATE TABLE IF NOT EXISTS dias_sin_clase (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    desde TEXT NOT NULL,
+    hasta TEXT NOT NULL,
+    descripcion TEXT NOT NULL,
+    tipo INTEGER NOT NULL DEFAULT 0,
+
+    CHECK (hasta >= desde),
+    UNIQUE (desde, hasta, descripcion)
+);
+
+CREATE INDEX IF NOT EXISTS idx_dias_sin_clase_fechas ON dias_sin_clase(desde, hasta);
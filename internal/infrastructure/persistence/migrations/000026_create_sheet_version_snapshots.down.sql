@@ -0,0 +1 @@
+DROP TABLE IF EXISTS sheet_version_snapshot;
@@ -0,0 +1,7 @@
+-- Copia normalizada de los datos académicos importados por cada versión del Excel, para poder
+-- comparar dos versiones entre sí. Se guarda como JSON comprimido con gzip, una fila por
+-- versión importada correctamente.
+CREATE TABLE IF NOT EXISTS sheet_version_snapshot (
+    version_id INTEGER PRIMARY KEY REFERENCES sheet_version(version_id) ON DELETE CASCADE,
+    data BLOB NOT NULL
+);
@@ -0,0 +1,3 @@
+-- Las vueltas atrás a una versión anterior también quedan en el historial, como una versión
+-- nueva que apunta a la versión restaurada. Es NULL para las importaciones normales.
+ALTER TABLE sheet_version ADD COLUMN restored_from INTEGER REFERENCES sheet_version(version_id);
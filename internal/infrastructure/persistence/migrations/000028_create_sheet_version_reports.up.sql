@@ -0,0 +1,7 @@
+-- Reporte de cada intento de importación del Excel: formato detectado por hoja, cantidad de
+-- filas, avisos y tiempos. Se guarda también para los intentos fallidos, como JSON comprimido
+-- con gzip.
+CREATE TABLE IF NOT EXISTS sheet_version_report (
+    version_id INTEGER PRIMARY KEY REFERENCES sheet_version(version_id) ON DELETE CASCADE,
+    data BLOB NOT NULL
+);
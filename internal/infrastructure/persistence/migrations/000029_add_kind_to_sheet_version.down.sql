@@ -0,0 +1 @@
+ALTER TABLE sheet_version DROP COLUMN kind;
@@ -0,0 +1,3 @@
+-- Las planillas de laboratorios se importan aparte de los horarios, pero quedan en el mismo
+-- historial. 0 = horarios, 1 = laboratorios.
+ALTER TABLE sheet_version ADD COLUMN kind INTEGER NOT NULL DEFAULT 0;
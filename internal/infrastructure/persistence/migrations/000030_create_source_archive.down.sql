@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_sheet_version_hash;
+ALTER TABLE sheet_version DROP COLUMN hash;
+DROP TABLE IF EXISTS sheet_source_archive;
@@ -0,0 +1,11 @@
+-- Planillas importadas guardadas en disco, con el SHA-256 de su contenido como nombre de
+-- archivo. La fila se borra junto con el archivo cuando queda fuera de la retención.
+CREATE TABLE IF NOT EXISTS sheet_source_archive (
+    hash TEXT PRIMARY KEY,
+    size INTEGER NOT NULL,
+    archived_at DATETIME NOT NULL DEFAULT (datetime('now'))
+);
+
+-- Archivo leído por cada versión. Las restauraciones no tienen archivo.
+ALTER TABLE sheet_version ADD COLUMN hash TEXT;
+CREATE INDEX idx_sheet_version_hash ON sheet_version(hash);
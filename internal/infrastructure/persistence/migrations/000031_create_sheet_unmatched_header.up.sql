@@ -0,0 +1,12 @@
+-- Filas de encabezado de las hojas que no coincidieron con ningún formato, guardadas junto con
+-- la versión que falló para escribir el formato nuevo desde la administración. Las celdas se
+-- guardan como un arreglo JSON, en el orden de las columnas.
+CREATE TABLE IF NOT EXISTS sheet_unmatched_header (
+    header_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    version_id INTEGER NOT NULL REFERENCES sheet_version(version_id) ON DELETE CASCADE,
+    sheet_name TEXT NOT NULL,
+    header_row INTEGER NOT NULL,
+    cells TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_sheet_unmatched_header_version ON sheet_unmatched_header(version_id);
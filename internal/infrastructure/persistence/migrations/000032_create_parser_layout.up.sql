@@ -0,0 +1,11 @@
+-- Formatos del parser cargados desde la administración, que se combinan con los archivos de
+-- formato del repositorio. Una fila con datos reemplaza al archivo con el mismo nombre, o agrega
+-- un formato nuevo. Una fila sin datos (NULL) solo guarda si el archivo está habilitado.
+CREATE TABLE IF NOT EXISTS parser_layout (
+    kind INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    data TEXT,
+    enabled INTEGER NOT NULL DEFAULT 1,
+    updated_at DATETIME NOT NULL DEFAULT (datetime('now')),
+    PRIMARY KEY (kind, name)
+);
@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"github.com/elias-gill/poliplanner2/internal/repository"
+)
+
+// Rows inserted by a single statement. SQLite allows 32766 parameters per statement, the
+// widest table of the imports has 11 columns.
+const bulkInsertRows = 500
+
+// prepareAll prepares the queries on the executor, in the same order. On error the statements
+// already prepared are closed.
+func prepareAll(ctx context.Context, exec repository.Executor, queries ...string) ([]*sql.Stmt, error) {
+	stmts := make([]*sql.Stmt, 0, len(queries))
+	for _, q := range queries {
+		stmt, err := exec.PrepareContext(ctx, q)
+		if err != nil {
+			closeAll(stmts)
+			return nil, err
+		}
+		stmts = append(stmts, stmt)
+	}
+
+	return stmts, nil
+}
+
+func closeAll(stmts []*sql.Stmt) {
+	for _, stmt := range stmts {
+		stmt.Close()
+	}
+}
+
+// bulkInsert inserts the rows with multi-row INSERT statements. Insert is the statement up
+// to VALUES, eg: "INSERT INTO t (a, b)", every row must have the same number of values.
+func bulkInsert(ctx context.Context, exec repository.Executor, insert string, rows [][]any) error {
+	for len(rows) > 0 {
+		chunk := rows[:min(len(rows), bulkInsertRows)]
+		rows = rows[len(chunk):]
+
+		placeholder := "(?" + strings.Repeat(", ?", len(chunk[0])-1) + ")"
+
+		var query strings.Builder
+		query.WriteString(insert)
+		query.WriteString(" VALUES ")
+
+		args := make([]any, 0, len(chunk)*len(chunk[0]))
+		for i, row := range chunk {
+			if i > 0 {
+				query.WriteString(", ")
+			}
+			query.WriteString(placeholder)
+			args = append(args, row...)
+		}
+
+		if _, err := exec.ExecContext(ctx, query.String(), args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteByCourses removes the rows of the table that belong to any of the courses. Column is
+// the name of the course reference on the table.
+func deleteByCourses(ctx context.Context, exec repository.Executor, table, column string, ids any) error {
+	// A batch can have thousands of courses, too many for a list of parameters
+	list, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+
+	_, err = exec.ExecContext(ctx,
+		"DELETE FROM "+table+" WHERE "+column+" IN (SELECT value FROM json_each(?))",
+		string(list),
+	)
+	return err
+}
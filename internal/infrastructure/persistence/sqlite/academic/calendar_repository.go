@@ -0,0 +1,116 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/persistence/sqlite/tx_manager"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+type CalendarRepository struct {
+	db *sql.DB
+}
+
+func NewCalendarRepository(db *sql.DB) *CalendarRepository {
+	return &CalendarRepository{db: db}
+}
+
+func (r *CalendarRepository) Add(ctx context.Context, days []academic.DayOff) (int, error) {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	added := 0
+	for _, d := range days {
+		res, err := exec.ExecContext(ctx, `
+			INSERT INTO dias_sin_clase (desde, hasta, descripcion, tipo)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT(desde, hasta, descripcion) DO NOTHING
+			`, formatDay(d.Dates.Start), formatDay(d.Dates.End), d.Description, d.Kind)
+		if err != nil {
+			return added, fmt.Errorf("insert day off %q: %w", d.Description, err)
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return added, err
+		}
+		added += int(n)
+	}
+
+	return added, nil
+}
+
+func (r *CalendarRepository) Delete(ctx context.Context, id academic.DayOffID) error {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	_, err := exec.ExecContext(ctx, `DELETE FROM dias_sin_clase WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete day off %v: %w", id, err)
+	}
+
+	return nil
+}
+
+func (r *CalendarRepository) Clear(ctx context.Context) error {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	if _, err := exec.ExecContext(ctx, `DELETE FROM dias_sin_clase`); err != nil {
+		return fmt.Errorf("clear days off: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CalendarRepository) ListBetween(ctx context.Context, dates academic.DateRange) ([]academic.DayOff, error) {
+	return r.list(ctx, `
+		SELECT id, desde, hasta, descripcion, tipo
+		FROM dias_sin_clase
+		WHERE desde <= ? AND hasta >= ?
+		ORDER BY desde, hasta
+		`, formatDay(dates.End), formatDay(dates.Start))
+}
+
+func (r *CalendarRepository) List(ctx context.Context) ([]academic.DayOff, error) {
+	return r.list(ctx, `
+		SELECT id, desde, hasta, descripcion, tipo
+		FROM dias_sin_clase
+		ORDER BY desde, hasta
+		`)
+}
+
+func (r *CalendarRepository) list(ctx context.Context, query string, args ...any) ([]academic.DayOff, error) {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	rows, err := exec.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list days off: %w", err)
+	}
+	defer rows.Close()
+
+	var days []academic.DayOff
+	for rows.Next() {
+		var (
+			d          academic.DayOff
+			start, end sql.NullString
+		)
+		if err := rows.Scan(&d.ID, &start, &end, &d.Description, &d.Kind); err != nil {
+			return nil, fmt.Errorf("scan day off: %w", err)
+		}
+
+		if d.Dates.Start, err = parseDay(start); err != nil {
+			return nil, err
+		}
+		if d.Dates.End, err = parseDay(end); err != nil {
+			return nil, err
+		}
+
+		days = append(days, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate day off rows: %w", err)
+	}
+
+	return days, nil
+}
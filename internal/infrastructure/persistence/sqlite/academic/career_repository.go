@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	txManager "github.com/elias-gill/poliplanner2/internal/infrastructure/persistence/sqlite/tx_manager"
 	"github.com/elias-gill/poliplanner2/internal/model/academic"
@@ -61,6 +62,27 @@ func (r *CareerRepository) GetByID(ctx context.Context, id academic.CareerID) (*
 	return &c, nil
 }
 
+// GetByCode retrieves a career by its code (siglas). Returns nil when there is no such career.
+func (r *CareerRepository) GetByCode(ctx context.Context, code string) (*academic.Career, error) {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	var c academic.Career
+	err := exec.QueryRowContext(ctx, `
+		SELECT id, siglas, nombre
+		FROM carreras
+		WHERE siglas = ?
+	`, code).Scan(&c.ID, &c.Code, &c.Name)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &c, nil
+}
+
 // List returns all registered careers ordered alphabetically by code.
 func (r *CareerRepository) List(ctx context.Context) ([]*academic.Career, error) {
 	exec := txManager.GetExecutor(ctx, r.db)
@@ -126,3 +148,124 @@ func (r *CareerRepository) ListPlans(ctx context.Context, id academic.CareerID)
 
 	return plans, nil
 }
+
+// ListEmphases retrieves the emphases of a career ordered by code.
+func (r *CareerRepository) ListEmphases(ctx context.Context, id academic.CareerID) ([]academic.Emphasis, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT codigo, COALESCE(nombre, '')
+		FROM enfasis
+		WHERE carrera = ?
+		ORDER BY codigo ASC
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query emphases for career %d: %w", id, err)
+	}
+	defer rows.Close()
+
+	var emphases []academic.Emphasis
+	for rows.Next() {
+		e := academic.Emphasis{Career: id}
+		if err := rows.Scan(&e.Code, &e.Name); err != nil {
+			return nil, fmt.Errorf("failed to scan emphasis row: %w", err)
+		}
+		emphases = append(emphases, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating emphasis rows: %w", err)
+	}
+
+	return emphases, nil
+}
+
+func (r *CareerRepository) ListExams(ctx context.Context, id academic.CareerID, period academic.PeriodID) ([]academic.CareerExamView, error) {
+	query := `
+		SELECT
+			c.id,
+			c.nombre,
+			c.seccion,
+			c.turno,
+			m.semestre,
+			p.codigo,
+			COALESCE((
+				SELECT GROUP_CONCAT(e.codigo, ',')
+				FROM enfasis_materia em
+				JOIN enfasis e ON e.id = em.enfasis
+				WHERE em.malla = m.id
+			), ''),
+			x.tipo,
+			x.instancia,
+			CAST(x.fecha AS TEXT),
+			CAST(x.hora AS TEXT),
+			COALESCE(CAST(x.revision_fecha AS TEXT), ''),
+			COALESCE(CAST(x.revision_hora AS TEXT), ''),
+			COALESCE(x.aula, ''),
+			x.modalidad,
+			COALESCE(x.edificio, ''),
+			COALESCE(x.numero_aula, '')
+		FROM mallas m
+		JOIN planes p ON p.id = m.plan
+		JOIN cursos c ON c.malla = m.id
+		JOIN examenes x ON x.curso_id = c.id
+		WHERE m.carrera = ? AND c.periodo = ?
+		ORDER BY x.fecha, x.hora, c.nombre, c.seccion
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, id, period)
+	if err != nil {
+		return nil, fmt.Errorf("list exams of career %d: %w", id, err)
+	}
+	defer rows.Close()
+
+	var exams []academic.CareerExamView
+	for rows.Next() {
+		var (
+			e                      academic.CareerExamView
+			plan, emphases         string
+			dateStr, hourStr       string
+			revDateStr, revHourStr string
+		)
+
+		if err := rows.Scan(
+			&e.CourseID,
+			&e.CourseName,
+			&e.Section,
+			&e.Shift,
+			&e.Semester,
+			&plan,
+			&emphases,
+			&e.Type,
+			&e.Instance,
+			&dateStr,
+			&hourStr,
+			&revDateStr,
+			&revHourStr,
+			&e.Room.Raw,
+			&e.Room.Kind,
+			&e.Room.Building,
+			&e.Room.Number,
+		); err != nil {
+			return nil, fmt.Errorf("scan career exam: %w", err)
+		}
+
+		e.Plans = []string{plan}
+		if emphases != "" {
+			e.Emphases = strings.Split(emphases, ",")
+		}
+
+		if e.Date, err = parseExamDate(dateStr, hourStr); err != nil {
+			return nil, err
+		}
+		if e.Revision, err = parseExamDate(revDateStr, revHourStr); err != nil {
+			return nil, err
+		}
+
+		exams = append(exams, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate career exam rows: %w", err)
+	}
+
+	return exams, nil
+}
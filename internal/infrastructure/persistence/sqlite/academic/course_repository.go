@@ -3,11 +3,13 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	txManager "github.com/elias-gill/poliplanner2/internal/infrastructure/persistence/sqlite/tx_manager"
 	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	"github.com/elias-gill/poliplanner2/internal/repository"
 	academicRepo "github.com/elias-gill/poliplanner2/internal/repository/academic"
 )
 
@@ -22,10 +24,18 @@ func NewCourseRepository(db *sql.DB) *CourseRepository {
 }
 
 func (r *CourseRepository) Upsert(ctx context.Context, course *academicRepo.CourseSaveParams) (academic.CourseID, error) {
+	ids, err := r.UpsertBatch(ctx, []*academicRepo.CourseSaveParams{course})
+	if err != nil {
+		return 0, err
+	}
+
+	return ids[0], nil
+}
+
+func (r *CourseRepository) UpsertBatch(ctx context.Context, courses []*academicRepo.CourseSaveParams) ([]academic.CourseID, error) {
 	exec := txManager.GetExecutor(ctx, r.db)
 
-	var courseID int64
-	err := exec.QueryRowContext(ctx, `
+	stmt, err := exec.PrepareContext(ctx, `
 		INSERT INTO cursos (
 		malla, periodo, nombre, seccion, turno, tipo,
 		comite_presidente, comite_miembro1, comite_miembro2, fechas_sabados
@@ -38,24 +48,35 @@ func (r *CourseRepository) Upsert(ctx context.Context, course *academicRepo.Cour
 		comite_miembro2 = excluded.comite_miembro2,
 		fechas_sabados = excluded.fechas_sabados
 		RETURNING id
-		`,
-		course.Curriculum,
-		course.Period,
-		course.Name,
-		course.Section,
-		course.Shift,
-		int(course.Type),
-		course.Comitee.President,
-		course.Comitee.Member1,
-		course.Comitee.Member2,
-		course.SaturdayDates,
-	).Scan(&courseID)
-
+		`)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
+	defer stmt.Close()
 
-	return academic.CourseID(courseID), nil
+	ids := make([]academic.CourseID, 0, len(courses))
+	for _, course := range courses {
+		var courseID int64
+		err := stmt.QueryRowContext(ctx,
+			course.Curriculum,
+			course.Period,
+			course.Name,
+			course.Section,
+			course.Shift,
+			int(course.Type),
+			course.Comitee.President,
+			course.Comitee.Member1,
+			course.Comitee.Member2,
+			course.SaturdayDates,
+		).Scan(&courseID)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, academic.CourseID(courseID))
+	}
+
+	return ids, nil
 }
 
 func (r *CourseRepository) AssignTeachers(ctx context.Context, courseID academic.CourseID, teachers []academic.TeacherID) error {
@@ -66,9 +87,7 @@ func (r *CourseRepository) AssignTeachers(ctx context.Context, courseID academic
 		return err
 	}
 
-	stmt, err := exec.PrepareContext(ctx, `
-		INSERT INTO docentes_curso (id_docente, id_curso) VALUES (?, ?)
-		`)
+	stmt, err := exec.PrepareContext(ctx, insertCourseTeacher+" VALUES (?, ?)")
 	if err != nil {
 		return err
 	}
@@ -83,6 +102,23 @@ func (r *CourseRepository) AssignTeachers(ctx context.Context, courseID academic
 	return nil
 }
 
+func (r *CourseRepository) AssignCommittee(ctx context.Context, courseID academic.CourseID, seats []academic.CommitteeSeat) error {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	_, err := exec.ExecContext(ctx, `DELETE FROM mesas_curso WHERE id_curso = ?`, courseID)
+	if err != nil {
+		return err
+	}
+
+	committee, err := prepareCommittee(ctx, exec)
+	if err != nil {
+		return err
+	}
+	defer committee.close()
+
+	return committee.exec(ctx, courseID, seats)
+}
+
 func (r *CourseRepository) AssignSchedule(ctx context.Context, courseID academic.CourseID, schedule []academic.ClassSession) error {
 	exec := txManager.GetExecutor(ctx, r.db)
 
@@ -91,28 +127,19 @@ func (r *CourseRepository) AssignSchedule(ctx context.Context, courseID academic
 		return err
 	}
 
-	stmt, err := exec.PrepareContext(ctx, `
-		INSERT INTO curso_horarios (curso_id, dia, desde, hasta, aula)
-		VALUES (?, ?, ?, ?, ?)
-		`)
+	stmt, err := exec.PrepareContext(ctx, insertCourseSession+" VALUES (?, ?, ?, ?, ?, ?, ?, ?)")
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
 	for _, s := range schedule {
-		if s.Time.Start == nil || s.Time.End == nil {
+		args, ok := sessionRow(courseID, s)
+		if !ok {
 			continue
 		}
 
-		_, err := stmt.ExecContext(ctx,
-			courseID,
-			int(s.Day),
-			s.Time.Start.Format("15:04"),
-			s.Time.End.Format("15:04"),
-			s.Room,
-		)
-		if err != nil {
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
 			return err
 		}
 	}
@@ -128,60 +155,265 @@ func (r *CourseRepository) AssignExams(ctx context.Context, courseID academic.Co
 		return err
 	}
 
-	stmt, err := exec.PrepareContext(ctx, `
-		INSERT INTO examenes (
-		curso_id, tipo, instancia,
-		fecha, hora, aula,
-		revision_fecha, revision_hora
-		)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
-		`)
+	stmt, err := exec.PrepareContext(ctx, insertCourseExam+" VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
 	for _, e := range exams {
-		examDate := e.Date()
-		if examDate == nil {
+		args, ok := examRow(courseID, e)
+		if !ok {
 			continue
 		}
 
-		var typeStr string
-		switch e.Type {
-		case academic.ExamPartial:
-			typeStr = "partial"
-		case academic.ExamFinal:
-			typeStr = "final"
-		default:
-			typeStr = "unknown"
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
+			return fmt.Errorf("failed to execute exam insert stmt: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *CourseRepository) AssignBatch(ctx context.Context, assignments []academicRepo.CourseAssignment) error {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	// Only the last assignment of a course is kept, as if they were assigned one by one
+	last := make(map[academic.CourseID]int, len(assignments))
+	for i, a := range assignments {
+		last[a.Course] = i
+	}
+
+	ids := make([]academic.CourseID, 0, len(last))
+	for id := range last {
+		ids = append(ids, id)
+	}
+
+	for _, t := range []struct{ table, column string }{
+		{"docentes_curso", "id_curso"},
+		{"curso_horarios", "curso_id"},
+		{"examenes", "curso_id"},
+		{"mesas_curso", "id_curso"},
+	} {
+		if err := deleteByCourses(ctx, exec, t.table, t.column, ids); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", t.table, err)
+		}
+	}
+
+	committee, err := prepareCommittee(ctx, exec)
+	if err != nil {
+		return err
+	}
+	defer committee.close()
+
+	var teachers, sessions, exams [][]any
+	for i, a := range assignments {
+		if last[a.Course] != i {
+			continue
 		}
 
-		var revDate any
-		var revTime any
-		if revision := e.Revision(); revision != nil {
-			revDate = revision.Format("2006-01-02")
-			revTime = revision.Format("15:04")
+		for _, tid := range a.Teachers {
+			teachers = append(teachers, []any{tid, a.Course})
+		}
+		for _, s := range a.Schedule {
+			if args, ok := sessionRow(a.Course, s); ok {
+				sessions = append(sessions, args)
+			}
+		}
+		for _, e := range a.Exams {
+			if args, ok := examRow(a.Course, e); ok {
+				exams = append(exams, args)
+			}
 		}
 
-		_, err := stmt.ExecContext(ctx,
-			courseID,
-			typeStr,
-			e.Instance,
-			examDate.Format("2006-01-02"),
-			examDate.Format("15:04"),
-			e.Room,
-			revDate,
-			revTime,
-		)
-		if err != nil {
-			return fmt.Errorf("failed to execute exam insert stmt: %w", err)
+		// Examiners are created on demand, they cannot be inserted in bulk
+		if err := committee.exec(ctx, a.Course, a.Committee); err != nil {
+			return fmt.Errorf("failed to assign committee: %w", err)
+		}
+	}
+
+	if err := bulkInsert(ctx, exec, insertCourseTeacher, teachers); err != nil {
+		return fmt.Errorf("failed to insert course teachers: %w", err)
+	}
+	if err := bulkInsert(ctx, exec, insertCourseSession, sessions); err != nil {
+		return fmt.Errorf("failed to insert course schedules: %w", err)
+	}
+	if err := bulkInsert(ctx, exec, insertCourseExam, exams); err != nil {
+		return fmt.Errorf("failed to insert course exams: %w", err)
+	}
+
+	return nil
+}
+
+// Inserts of the related data of a course, up to VALUES
+const (
+	insertCourseTeacher = `INSERT INTO docentes_curso (id_docente, id_curso)`
+	insertCourseSession = `INSERT INTO curso_horarios (curso_id, dia, desde, hasta, aula, modalidad, edificio, numero_aula)`
+	insertCourseExam    = `INSERT INTO examenes (
+		curso_id, tipo, instancia,
+		fecha, hora, aula,
+		revision_fecha, revision_hora,
+		modalidad, edificio, numero_aula
+		)`
+)
+
+// sessionRow returns the values of a class session row. Sessions without times are not
+// stored.
+func sessionRow(courseID academic.CourseID, s academic.ClassSession) ([]any, bool) {
+	if s.Time.Start == nil || s.Time.End == nil {
+		return nil, false
+	}
+
+	return []any{
+		courseID,
+		int(s.Day),
+		s.Time.Start.Format("15:04"),
+		s.Time.End.Format("15:04"),
+		s.Room.Raw,
+		int(s.Room.Kind),
+		s.Room.Building,
+		s.Room.Number,
+	}, true
+}
+
+// examRow returns the values of an exam row. Exams without date are not stored.
+func examRow(courseID academic.CourseID, e academic.Exam) ([]any, bool) {
+	examDate := e.Date()
+	if examDate == nil {
+		return nil, false
+	}
+
+	var typeStr string
+	switch e.Type {
+	case academic.ExamPartial:
+		typeStr = "partial"
+	case academic.ExamFinal:
+		typeStr = "final"
+	default:
+		typeStr = "unknown"
+	}
+
+	var revDate any
+	var revTime any
+	if revision := e.Revision(); revision != nil {
+		revDate = revision.Format("2006-01-02")
+		revTime = revision.Format("15:04")
+	}
+
+	return []any{
+		courseID,
+		typeStr,
+		e.Instance,
+		examDate.Format("2006-01-02"),
+		examDate.Format("15:04"),
+		e.Room.Raw,
+		revDate,
+		revTime,
+		int(e.Room.Kind),
+		e.Room.Building,
+		e.Room.Number,
+	}, true
+}
+
+// committeeInsert holds the prepared statements that store the committee of a course.
+type committeeInsert struct {
+	stmts []*sql.Stmt
+}
+
+func prepareCommittee(ctx context.Context, exec repository.Executor) (*committeeInsert, error) {
+	stmts, err := prepareAll(ctx, exec,
+		`INSERT INTO examinadores (nombre, clave) VALUES (?, ?)
+		ON CONFLICT(clave) DO UPDATE SET nombre = excluded.nombre
+		RETURNING id`,
+		// The same person can be written twice on a committee, keep the highest role
+		`INSERT INTO mesas_curso (id_curso, id_examinador, rol) VALUES (?, ?, ?)
+		ON CONFLICT(id_curso, id_examinador) DO UPDATE SET rol = MIN(rol, excluded.rol)`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &committeeInsert{stmts: stmts}, nil
+}
+
+func (c *committeeInsert) exec(ctx context.Context, courseID academic.CourseID, seats []academic.CommitteeSeat) error {
+	for _, seat := range seats {
+		key := academic.CommitteeMemberKey(seat.Name)
+		if key == "" {
+			continue
+		}
+
+		var examinerID int64
+		if err := c.stmts[0].QueryRowContext(ctx, seat.Name, key).Scan(&examinerID); err != nil {
+			return err
+		}
+
+		if _, err := c.stmts[1].ExecContext(ctx, courseID, examinerID, int(seat.Role)); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+func (c *committeeInsert) close() {
+	closeAll(c.stmts)
+}
+
+func (r *CourseRepository) DeleteByPeriodExcept(ctx context.Context, period academic.PeriodID, keep []academic.CourseID) (int64, error) {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	ids, err := courseIDsJSON(keep)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := exec.ExecContext(ctx, `
+		DELETE FROM cursos
+		WHERE periodo = ? AND tipo != ? AND id NOT IN (SELECT value FROM json_each(?))
+		`, period, academic.Laboratory, string(ids))
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *CourseRepository) CountByPeriodExcept(ctx context.Context, period academic.PeriodID, keep []academic.CourseID) (int64, int64, error) {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	ids, err := courseIDsJSON(keep)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var courses, schedules int64
+	err = exec.QueryRowContext(ctx, `
+		WITH removed AS (
+			SELECT id FROM cursos
+			WHERE periodo = ? AND tipo != ? AND id NOT IN (SELECT value FROM json_each(?))
+		)
+		SELECT
+			(SELECT COUNT(*) FROM removed),
+			(SELECT COUNT(DISTINCT horario_id) FROM horarios_detalle WHERE curso_id IN (SELECT id FROM removed))
+		`, period, academic.Laboratory, string(ids)).Scan(&courses, &schedules)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return courses, schedules, nil
+}
+
+// courseIDsJSON encodes the ids as a JSON array for json_each. A whole workbook has thousands
+// of courses, too many for a list of parameters. No ids must be "[]", since the NULL row of
+// json_each('null') makes "NOT IN" match nothing.
+func courseIDsJSON(ids []academic.CourseID) ([]byte, error) {
+	if ids == nil {
+		ids = []academic.CourseID{}
+	}
+	return json.Marshal(ids)
+}
+
 func (r *CourseRepository) ListByCurriculumID(ctx context.Context, curriculum academic.CurriculumID, period academic.PeriodID) ([]academic.CourseSummaryView, error) {
 	query := `
 		SELECT id, seccion, turno, tipo, nombre
@@ -238,7 +470,7 @@ func (r *CourseRepository) GetCourseTeachers(ctx context.Context, courseID acade
 
 func (r *CourseRepository) GetCourseSchedules(ctx context.Context, courseID academic.CourseID) ([]academic.ClassSession, error) {
 	query := `
-		SELECT dia, desde, hasta, aula
+		SELECT dia, desde, hasta, COALESCE(aula, ''), modalidad, COALESCE(edificio, ''), COALESCE(numero_aula, '')
 		FROM curso_horarios
 		WHERE curso_id = $1
 	`
@@ -259,7 +491,7 @@ func (r *CourseRepository) GetCourseSchedules(ctx context.Context, courseID acad
 			endStr   string
 		)
 
-		if err := rows.Scan(&s.Day, &startStr, &endStr, &s.Room); err != nil {
+		if err := rows.Scan(&s.Day, &startStr, &endStr, &s.Room.Raw, &s.Room.Kind, &s.Room.Building, &s.Room.Number); err != nil {
 			return nil, fmt.Errorf("scan schedule: %w", err)
 		}
 
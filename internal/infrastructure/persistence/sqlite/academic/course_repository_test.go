@@ -0,0 +1,256 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/persistence/sqlite/sqlitetest"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	academicRepo "github.com/elias-gill/poliplanner2/internal/repository/academic"
+)
+
+func TestAssignBatchLastAssignmentWins(t *testing.T) {
+	ctx := context.Background()
+	db := sqlitetest.Open(t)
+	courses := NewCourseRepository(db)
+
+	periodID, err := NewPeriodRepository(db).Upsert(ctx, academic.Period{Year: 2026, Semester: academic.FirstSemester})
+	if err != nil {
+		t.Fatalf("cannot create period: %v", err)
+	}
+	curriculumID := createCurriculum(t, db)
+
+	ids, err := courses.UpsertBatch(ctx, []*academicRepo.CourseSaveParams{
+		{Name: "Fisica I", Section: "A", Shift: "M", Period: periodID, Curriculum: curriculumID},
+		{Name: "Fisica I", Section: "B", Shift: "M", Period: periodID, Curriculum: curriculumID},
+	})
+	if err != nil {
+		t.Fatalf("cannot create courses: %v", err)
+	}
+	a, b := ids[0], ids[1]
+
+	teacherRepo := NewTeacherRepository(db)
+	juan, err := teacherRepo.Upsert(ctx, academic.Teacher{FirstName: "Juan", LastName: "Gonzalez", Email: "jgonzalez@pol.una.py"})
+	if err != nil {
+		t.Fatalf("cannot create teacher: %v", err)
+	}
+	ana, err := teacherRepo.Upsert(ctx, academic.Teacher{FirstName: "Ana", LastName: "Benitez", Email: "abenitez@pol.una.py"})
+	if err != nil {
+		t.Fatalf("cannot create teacher: %v", err)
+	}
+
+	session := func(day academic.WeekDay) academic.ClassSession {
+		start := time.Date(0, 1, 1, 7, 30, 0, 0, time.UTC)
+		end := start.Add(90 * time.Minute)
+		return academic.ClassSession{Day: day, Time: academic.TimeSlot{Start: &start, End: &end}}
+	}
+
+	// Course A is listed twice, only its last assignment is kept
+	err = courses.AssignBatch(ctx, []academicRepo.CourseAssignment{
+		{Course: a, Teachers: []academic.TeacherID{juan}, Schedule: []academic.ClassSession{session(academic.Monday)}},
+		{Course: b, Teachers: []academic.TeacherID{juan}, Schedule: []academic.ClassSession{session(academic.Wednesday)}},
+		{Course: a, Teachers: []academic.TeacherID{ana}, Schedule: []academic.ClassSession{session(academic.Tuesday), session(academic.Thursday)}},
+	})
+	if err != nil {
+		t.Fatalf("AssignBatch() = %v", err)
+	}
+
+	assertCourse(t, courses, a, []academic.WeekDay{academic.Tuesday, academic.Thursday}, []string{"Ana"})
+	assertCourse(t, courses, b, []academic.WeekDay{academic.Wednesday}, []string{"Juan"})
+
+	// A later batch replaces the data of its courses only
+	if err := courses.AssignBatch(ctx, []academicRepo.CourseAssignment{{Course: b}}); err != nil {
+		t.Fatalf("AssignBatch() = %v", err)
+	}
+	assertCourse(t, courses, a, []academic.WeekDay{academic.Tuesday, academic.Thursday}, []string{"Ana"})
+	assertCourse(t, courses, b, nil, nil)
+}
+
+func TestCountByPeriodExcept(t *testing.T) {
+	ctx := context.Background()
+	db := sqlitetest.Open(t)
+	courses := NewCourseRepository(db)
+
+	periodID, err := NewPeriodRepository(db).Upsert(ctx, academic.Period{Year: 2026, Semester: academic.FirstSemester})
+	if err != nil {
+		t.Fatalf("cannot create period: %v", err)
+	}
+	curriculumID := createCurriculum(t, db)
+
+	ids, err := courses.UpsertBatch(ctx, []*academicRepo.CourseSaveParams{
+		{Name: "Fisica I", Section: "A", Shift: "M", Period: periodID, Curriculum: curriculumID},
+		{Name: "Fisica I", Section: "B", Shift: "M", Period: periodID, Curriculum: curriculumID},
+		{Name: "Fisica I", Section: "C", Shift: "M", Period: periodID, Curriculum: curriculumID},
+		{Name: "Fisica I (Laboratorio)", Section: "A", Shift: "M", Type: academic.Laboratory, Period: periodID, Curriculum: curriculumID},
+	})
+	if err != nil {
+		t.Fatalf("cannot create courses: %v", err)
+	}
+	a, b, c, lab := ids[0], ids[1], ids[2], ids[3]
+
+	// Two students saved course B, one of them along with C. The lab is always kept
+	for i, saved := range [][]academic.CourseID{{a, b, lab}, {b, c}, {a}} {
+		addSchedule(t, db, i+1, saved)
+	}
+
+	count, schedules, err := courses.CountByPeriodExcept(ctx, periodID, []academic.CourseID{a})
+	if err != nil {
+		t.Fatalf("CountByPeriodExcept() = %v", err)
+	}
+	if count != 2 || schedules != 2 {
+		t.Errorf("CountByPeriodExcept() = %d courses, %d schedules; want 2 and 2", count, schedules)
+	}
+
+	removed, err := courses.DeleteByPeriodExcept(ctx, periodID, []academic.CourseID{a})
+	if err != nil {
+		t.Fatalf("DeleteByPeriodExcept() = %v", err)
+	}
+	if removed != count {
+		t.Errorf("DeleteByPeriodExcept() removed %d courses; want the %d counted", removed, count)
+	}
+
+	count, schedules, err = courses.CountByPeriodExcept(ctx, periodID, []academic.CourseID{a})
+	if err != nil || count != 0 || schedules != 0 {
+		t.Errorf("CountByPeriodExcept() after removing = %d, %d, %v; want nothing", count, schedules, err)
+	}
+}
+
+// A rollback to a version without courses keeps nothing but the laboratories.
+func TestDeleteByPeriodExceptEmptySnapshot(t *testing.T) {
+	ctx := context.Background()
+	db := sqlitetest.Open(t)
+	courses := NewCourseRepository(db)
+
+	periodID, err := NewPeriodRepository(db).Upsert(ctx, academic.Period{Year: 2026, Semester: academic.FirstSemester})
+	if err != nil {
+		t.Fatalf("cannot create period: %v", err)
+	}
+	curriculumID := createCurriculum(t, db)
+
+	ids, err := courses.UpsertBatch(ctx, []*academicRepo.CourseSaveParams{
+		{Name: "Fisica I", Section: "A", Shift: "M", Period: periodID, Curriculum: curriculumID},
+		{Name: "Fisica I", Section: "B", Shift: "M", Period: periodID, Curriculum: curriculumID},
+		{Name: "Fisica I (Laboratorio)", Section: "A", Shift: "M", Type: academic.Laboratory, Period: periodID, Curriculum: curriculumID},
+	})
+	if err != nil {
+		t.Fatalf("cannot create courses: %v", err)
+	}
+	addSchedule(t, db, 1, []academic.CourseID{ids[0], ids[2]})
+
+	for _, keep := range [][]academic.CourseID{nil, {}} {
+		count, schedules, err := courses.CountByPeriodExcept(ctx, periodID, keep)
+		if err != nil {
+			t.Fatalf("CountByPeriodExcept(%#v) = %v", keep, err)
+		}
+		if count != 2 || schedules != 1 {
+			t.Errorf("CountByPeriodExcept(%#v) = %d courses, %d schedules; want 2 and 1", keep, count, schedules)
+		}
+	}
+
+	removed, err := courses.DeleteByPeriodExcept(ctx, periodID, nil)
+	if err != nil {
+		t.Fatalf("DeleteByPeriodExcept() = %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("DeleteByPeriodExcept() removed %d courses; want 2", removed)
+	}
+
+	var left []academic.CourseID
+	rows, err := db.Query(`SELECT id FROM cursos WHERE periodo = ?`, periodID)
+	if err != nil {
+		t.Fatalf("cannot list courses: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id academic.CourseID
+		if err := rows.Scan(&id); err != nil {
+			t.Fatalf("cannot scan course: %v", err)
+		}
+		left = append(left, id)
+	}
+	if !reflect.DeepEqual(left, []academic.CourseID{ids[2]}) {
+		t.Errorf("courses left = %v; want only the laboratory %d", left, ids[2])
+	}
+}
+
+// createCurriculum creates a subject on a career and returns its curriculum.
+func createCurriculum(t *testing.T, db *sql.DB) academic.CurriculumID {
+	t.Helper()
+	ctx := context.Background()
+
+	careerID, err := NewCareerRepository(db).Upsert(ctx, academic.Career{Code: "IIN"})
+	if err != nil {
+		t.Fatalf("cannot create career: %v", err)
+	}
+	subjectID, err := NewSubjectRepository(db).Upsert(ctx, academic.Subject{Name: "Fisica I"})
+	if err != nil {
+		t.Fatalf("cannot create subject: %v", err)
+	}
+	curriculumID, err := NewCurriculumRepository(db).Upsert(ctx, academicRepo.CurriculumSaveParams{
+		SubjectID: subjectID,
+		CareerID:  careerID,
+	})
+	if err != nil {
+		t.Fatalf("cannot create curriculum: %v", err)
+	}
+
+	return curriculumID
+}
+
+// addSchedule saves a schedule of a new student with the given courses.
+func addSchedule(t *testing.T, db *sql.DB, student int, courses []academic.CourseID) {
+	t.Helper()
+
+	res, err := db.Exec(`INSERT INTO users (username, password, email) VALUES (?, '', ?)`,
+		fmt.Sprintf("alumno%d", student), fmt.Sprintf("alumno%d@fpuna.edu.py", student))
+	if err != nil {
+		t.Fatalf("cannot create student: %v", err)
+	}
+	userID, _ := res.LastInsertId()
+
+	res, err = db.Exec(`INSERT INTO horarios (usuario_id) VALUES (?)`, userID)
+	if err != nil {
+		t.Fatalf("cannot create schedule: %v", err)
+	}
+	scheduleID, _ := res.LastInsertId()
+
+	for _, course := range courses {
+		if _, err := db.Exec(`INSERT INTO horarios_detalle (horario_id, curso_id) VALUES (?, ?)`, scheduleID, course); err != nil {
+			t.Fatalf("cannot save course on schedule: %v", err)
+		}
+	}
+}
+
+// assertCourse checks the days of the sessions and the first names of the teachers of a course.
+func assertCourse(t *testing.T, repo *CourseRepository, id academic.CourseID, days []academic.WeekDay, teachers []string) {
+	t.Helper()
+	ctx := context.Background()
+
+	schedule, err := repo.GetCourseSchedules(ctx, id)
+	if err != nil {
+		t.Fatalf("GetCourseSchedules() = %v", err)
+	}
+	var gotDays []academic.WeekDay
+	for _, s := range schedule {
+		gotDays = append(gotDays, s.Day)
+	}
+	if !reflect.DeepEqual(gotDays, days) {
+		t.Errorf("course %d sessions = %v; want %v", id, gotDays, days)
+	}
+
+	list, err := repo.GetCourseTeachers(ctx, id)
+	if err != nil {
+		t.Fatalf("GetCourseTeachers() = %v", err)
+	}
+	var gotTeachers []string
+	for _, teacher := range list {
+		gotTeachers = append(gotTeachers, teacher.FirstName)
+	}
+	if !reflect.DeepEqual(gotTeachers, teachers) {
+		t.Errorf("course %d teachers = %v; want %v", id, gotTeachers, teachers)
+	}
+}
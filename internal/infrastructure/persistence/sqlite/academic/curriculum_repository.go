@@ -19,66 +19,87 @@ func NewCurriculumRepository(db *sql.DB) *CurriculumRepository {
 }
 
 func (r *CurriculumRepository) Upsert(ctx context.Context, c academicRepo.CurriculumSaveParams) (academic.CurriculumID, error) {
+	ids, err := r.UpsertBatch(ctx, []academicRepo.CurriculumSaveParams{c})
+	if err != nil {
+		return 0, err
+	}
+
+	return ids[0], nil
+}
+
+// UpsertBatch upserts the curriculums with their plans and emphases in order, reusing the same
+// statements for all of them.
+func (r *CurriculumRepository) UpsertBatch(ctx context.Context, params []academicRepo.CurriculumSaveParams) ([]academic.CurriculumID, error) {
 	exec := txManager.GetExecutor(ctx, r.db)
 
-	// Insert plan data
-	var planID int64
-	err := exec.QueryRowContext(ctx, `
-		INSERT INTO planes (carrera, codigo)
+	stmts, err := prepareAll(ctx, exec,
+		`INSERT INTO planes (carrera, codigo)
 		VALUES (?, ?)
 		ON CONFLICT(codigo, carrera) DO UPDATE SET codigo = excluded.codigo
-		RETURNING id
-	`, c.CareerID, c.Curriculum.Plan.Code).Scan(&planID)
-	if err != nil {
-		return 0, fmt.Errorf("error al upsert de plan: %w", err)
-	}
-
-	// Insert the curriculum
-	var mallaID int64
-	err = exec.QueryRowContext(ctx, `
-		INSERT INTO mallas (carrera, plan, asignatura, semestre, nivel)
+		RETURNING id`,
+		`INSERT INTO mallas (carrera, plan, asignatura, semestre, nivel)
 		VALUES (?, ?, ?, ?, ?)
 		ON CONFLICT(carrera, asignatura, plan) DO UPDATE SET
 			semestre = excluded.semestre,
 			nivel = excluded.nivel
-		RETURNING id
-	`, c.CareerID, planID, c.SubjectID, c.Curriculum.Semester, c.Curriculum.Level).Scan(&mallaID)
+		RETURNING id`,
+		`INSERT INTO enfasis (carrera, codigo, nombre)
+		VALUES (?, ?, ?)
+		ON CONFLICT(codigo, carrera) DO UPDATE SET
+			nombre = excluded.nombre
+		RETURNING id`,
+		`INSERT INTO enfasis_materia (malla, enfasis)
+		VALUES (?, ?)
+		ON CONFLICT(malla, enfasis) DO NOTHING`,
+	)
 	if err != nil {
-		return 0, fmt.Errorf("error al upsert de malla: %w", err)
+		return nil, err
 	}
-
-	// Insert and link emphases
-	for _, emp := range c.Curriculum.Emphases {
-		careerID := emp.Career
-		if careerID == 0 {
-			careerID = c.CareerID
+	defer closeAll(stmts)
+	upsertPlan, upsertCurriculum, upsertEmphasis, linkEmphasis := stmts[0], stmts[1], stmts[2], stmts[3]
+
+	ids := make([]academic.CurriculumID, 0, len(params))
+	for _, c := range params {
+		// Insert plan data
+		var planID int64
+		err := upsertPlan.QueryRowContext(ctx, c.CareerID, c.Curriculum.Plan.Code).Scan(&planID)
+		if err != nil {
+			return nil, fmt.Errorf("error al upsert de plan: %w", err)
 		}
 
-		// insert
-		var enfasisID int64
-		err := exec.QueryRowContext(ctx, `
-			INSERT INTO enfasis (carrera, codigo, nombre)
-			VALUES (?, ?, ?)
-			ON CONFLICT(codigo, carrera) DO UPDATE SET
-				nombre = excluded.nombre
-			RETURNING id
-		`, careerID, emp.Code, emp.Name).Scan(&enfasisID)
+		// Insert the curriculum
+		var mallaID int64
+		err = upsertCurriculum.QueryRowContext(ctx,
+			c.CareerID, planID, c.SubjectID, c.Curriculum.Semester, c.Curriculum.Level,
+		).Scan(&mallaID)
 		if err != nil {
-			return 0, fmt.Errorf("error al upsert de enfasis (%s): %w", emp.Code, err)
+			return nil, fmt.Errorf("error al upsert de malla: %w", err)
 		}
 
-		// link
-		_, err = exec.ExecContext(ctx, `
-			INSERT INTO enfasis_materia (malla, enfasis)
-			VALUES (?, ?)
-			ON CONFLICT(malla, enfasis) DO NOTHING
-		`, mallaID, enfasisID)
-		if err != nil {
-			return 0, fmt.Errorf("error al vincular enfasis (%s) a la malla: %w", emp.Code, err)
+		// Insert and link emphases
+		for _, emp := range c.Curriculum.Emphases {
+			careerID := emp.Career
+			if careerID == 0 {
+				careerID = c.CareerID
+			}
+
+			// insert
+			var enfasisID int64
+			err := upsertEmphasis.QueryRowContext(ctx, careerID, emp.Code, emp.Name).Scan(&enfasisID)
+			if err != nil {
+				return nil, fmt.Errorf("error al upsert de enfasis (%s): %w", emp.Code, err)
+			}
+
+			// link
+			if _, err := linkEmphasis.ExecContext(ctx, mallaID, enfasisID); err != nil {
+				return nil, fmt.Errorf("error al vincular enfasis (%s) a la malla: %w", emp.Code, err)
+			}
 		}
+
+		ids = append(ids, academic.CurriculumID(mallaID))
 	}
 
-	return academic.CurriculumID(mallaID), nil
+	return ids, nil
 }
 
 func (r *CurriculumRepository) GetByCareerID(ctx context.Context, career academic.CareerID) ([]academic.CurriculumSubjectItem, error) {
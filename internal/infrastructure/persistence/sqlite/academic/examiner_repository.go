@@ -0,0 +1,262 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	txManager "github.com/elias-gill/poliplanner2/internal/infrastructure/persistence/sqlite/tx_manager"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+type ExaminerRepository struct {
+	db *sql.DB
+}
+
+func NewExaminerRepository(db *sql.DB) *ExaminerRepository {
+	return &ExaminerRepository{db: db}
+}
+
+// ListByPeriod returns the examiners of the period sorted by name. The same course is listed
+// once per career that shares it, so committees are counted by name, section and shift.
+func (r *ExaminerRepository) ListByPeriod(ctx context.Context, period academic.PeriodID) ([]academic.ExaminerSummaryView, error) {
+	query := `
+		SELECT
+			e.id,
+			e.nombre,
+			e.id_docente,
+			COUNT(DISTINCT c.nombre || '|' || c.seccion || '|' || c.turno)
+		FROM examinadores e
+		JOIN mesas_curso m ON m.id_examinador = e.id
+		JOIN cursos c ON c.id = m.id_curso
+		WHERE c.periodo = ?
+		GROUP BY e.id
+		ORDER BY e.clave
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, period)
+	if err != nil {
+		return nil, fmt.Errorf("list examiners: %w", err)
+	}
+	defer rows.Close()
+
+	var examiners []academic.ExaminerSummaryView
+	for rows.Next() {
+		var (
+			e         academic.ExaminerSummaryView
+			teacherID sql.NullInt64
+		)
+		if err := rows.Scan(&e.Examiner.ID, &e.Examiner.Name, &teacherID, &e.Courses); err != nil {
+			return nil, fmt.Errorf("scan examiner: %w", err)
+		}
+		e.Examiner.TeacherID = nullTeacherID(teacherID)
+		examiners = append(examiners, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate examiner rows: %w", err)
+	}
+
+	return examiners, nil
+}
+
+func (r *ExaminerRepository) GetByID(ctx context.Context, id academic.ExaminerID) (*academic.Examiner, error) {
+	var (
+		e         = academic.Examiner{ID: id}
+		teacherID sql.NullInt64
+	)
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT nombre, id_docente
+		FROM examinadores
+		WHERE id = ?
+	`, id).Scan(&e.Name, &teacherID)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	e.TeacherID = nullTeacherID(teacherID)
+
+	return &e, nil
+}
+
+func (r *ExaminerRepository) ListFinalExams(ctx context.Context, id academic.ExaminerID, period academic.PeriodID) ([]academic.ExaminerExamView, error) {
+	query := `
+		SELECT
+			MIN(c.id),
+			c.nombre,
+			c.seccion,
+			MIN(m.rol),
+			x.instancia,
+			CAST(x.fecha AS TEXT),
+			CAST(x.hora AS TEXT),
+			COALESCE(CAST(x.revision_fecha AS TEXT), ''),
+			COALESCE(CAST(x.revision_hora AS TEXT), ''),
+			COALESCE(x.aula, ''),
+			x.modalidad,
+			COALESCE(x.edificio, ''),
+			COALESCE(x.numero_aula, '')
+		FROM mesas_curso m
+		JOIN cursos c ON c.id = m.id_curso
+		JOIN examenes x ON x.curso_id = c.id
+		WHERE m.id_examinador = ? AND c.periodo = ? AND x.tipo = ?
+		GROUP BY c.nombre, c.seccion, c.turno, x.instancia
+		ORDER BY x.fecha, x.hora, c.nombre
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, id, period, string(academic.ExamFinal))
+	if err != nil {
+		return nil, fmt.Errorf("list examiner exams: %w", err)
+	}
+	defer rows.Close()
+
+	var exams []academic.ExaminerExamView
+	for rows.Next() {
+		var (
+			e                      academic.ExaminerExamView
+			dateStr, hourStr       string
+			revDateStr, revHourStr string
+		)
+
+		if err := rows.Scan(
+			&e.CourseID,
+			&e.CourseName,
+			&e.Section,
+			&e.Role,
+			&e.Instance,
+			&dateStr,
+			&hourStr,
+			&revDateStr,
+			&revHourStr,
+			&e.Room.Raw,
+			&e.Room.Kind,
+			&e.Room.Building,
+			&e.Room.Number,
+		); err != nil {
+			return nil, fmt.Errorf("scan examiner exam: %w", err)
+		}
+
+		if e.Date, err = parseExamDate(dateStr, hourStr); err != nil {
+			return nil, err
+		}
+		if e.Revision, err = parseExamDate(revDateStr, revHourStr); err != nil {
+			return nil, err
+		}
+
+		exams = append(exams, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate examiner exam rows: %w", err)
+	}
+
+	return exams, nil
+}
+
+func (r *ExaminerRepository) ListCoursesWithoutExaminers(ctx context.Context) ([]academic.CourseSummaryView, error) {
+	query := `
+		SELECT
+			c.id,
+			COALESCE(c.comite_presidente, ''),
+			COALESCE(c.comite_miembro1, ''),
+			COALESCE(c.comite_miembro2, '')
+		FROM cursos c
+		WHERE (COALESCE(c.comite_presidente, '') <> ''
+				OR COALESCE(c.comite_miembro1, '') <> ''
+				OR COALESCE(c.comite_miembro2, '') <> '')
+			AND NOT EXISTS (SELECT 1 FROM mesas_curso m WHERE m.id_curso = c.id)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list courses without examiners: %w", err)
+	}
+	defer rows.Close()
+
+	var courses []academic.CourseSummaryView
+	for rows.Next() {
+		var c academic.CourseSummaryView
+		if err := rows.Scan(&c.ID, &c.Committee.President, &c.Committee.Member1, &c.Committee.Member2); err != nil {
+			return nil, fmt.Errorf("scan course committee: %w", err)
+		}
+		courses = append(courses, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate course committee rows: %w", err)
+	}
+
+	return courses, nil
+}
+
+func (r *ExaminerRepository) ListUnlinked(ctx context.Context) ([]academic.Examiner, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, nombre
+		FROM examinadores
+		WHERE id_docente IS NULL
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list unlinked examiners: %w", err)
+	}
+	defer rows.Close()
+
+	var examiners []academic.Examiner
+	for rows.Next() {
+		var e academic.Examiner
+		if err := rows.Scan(&e.ID, &e.Name); err != nil {
+			return nil, fmt.Errorf("scan examiner: %w", err)
+		}
+		examiners = append(examiners, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate examiner rows: %w", err)
+	}
+
+	return examiners, nil
+}
+
+func (r *ExaminerRepository) LinkTeacher(ctx context.Context, id academic.ExaminerID, teacher academic.TeacherID) error {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	_, err := exec.ExecContext(ctx, `UPDATE examinadores SET id_docente = ? WHERE id = ?`, teacher, id)
+	if err != nil {
+		return fmt.Errorf("link examiner %v to teacher %v: %w", id, teacher, err)
+	}
+
+	return nil
+}
+
+// ==================
+//  Helper functions
+// ==================
+
+func nullTeacherID(id sql.NullInt64) *academic.TeacherID {
+	if !id.Valid {
+		return nil
+	}
+	teacherID := academic.TeacherID(id.Int64)
+	return &teacherID
+}
+
+// parseExamDate joins the date and hour columns of an exam. Returns nil when the exam has no
+// date.
+func parseExamDate(dateStr, hourStr string) (*time.Time, error) {
+	if len(dateStr) < 10 || len(hourStr) < 5 {
+		return nil, nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02 15:04", dateStr[:10]+" "+hourStr[:5], timezone.ParaguayTZ)
+	if err != nil {
+		return nil, fmt.Errorf("parse exam date %q %q: %w", dateStr, hourStr, err)
+	}
+
+	return &date, nil
+}
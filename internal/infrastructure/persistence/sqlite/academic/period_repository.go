@@ -3,11 +3,25 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
 	"github.com/elias-gill/poliplanner2/internal/infrastructure/persistence/sqlite/tx_manager"
 	"github.com/elias-gill/poliplanner2/internal/model/academic"
 )
 
+// dayLayout is the format of the date only columns (periods and days off).
+const dayLayout = "2006-01-02"
+
+const periodColumns = `
+	p.id, p.year, p.periodo,
+	p.fecha_inicio, p.fecha_fin,
+	p.clases_inicio, p.clases_fin,
+	p.parciales_inicio, p.parciales_fin,
+	p.finales_inicio, p.finales_fin`
+
 type PeriodRepository struct {
 	db *sql.DB
 }
@@ -38,3 +52,161 @@ func (r *PeriodRepository) Upsert(ctx context.Context, p academic.Period) (acade
 
 	return academic.PeriodID(id), nil
 }
+
+// GetByID retrieves a period by its database ID. Returns nil when there is no such period.
+func (r *PeriodRepository) GetByID(ctx context.Context, id academic.PeriodID) (*academic.Period, error) {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	row := exec.QueryRowContext(ctx, `
+		SELECT `+periodColumns+` FROM periodos p WHERE p.id = ?
+		`, id)
+
+	p, err := scanPeriod(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (r *PeriodRepository) List(ctx context.Context) ([]academic.Period, error) {
+	return r.list(ctx, `
+		SELECT `+periodColumns+`
+		FROM periodos p
+		WHERE EXISTS (SELECT 1 FROM cursos c WHERE c.periodo = p.id)
+		ORDER BY p.year DESC, p.periodo DESC
+		`)
+}
+
+func (r *PeriodRepository) ListAll(ctx context.Context) ([]academic.Period, error) {
+	return r.list(ctx, `
+		SELECT `+periodColumns+`
+		FROM periodos p
+		ORDER BY p.year DESC, p.periodo DESC
+		`)
+}
+
+func (r *PeriodRepository) FindByDate(ctx context.Context, t time.Time) (*academic.Period, error) {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	day := t.In(timezone.ParaguayTZ).Format(dayLayout)
+	row := exec.QueryRowContext(ctx, `
+		SELECT `+periodColumns+`
+		FROM periodos p
+		WHERE p.fecha_inicio <= ? AND p.fecha_fin >= ?
+		ORDER BY p.fecha_inicio DESC
+		LIMIT 1
+		`, day, day)
+
+	p, err := scanPeriod(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find period by date %s: %w", day, err)
+	}
+
+	return p, nil
+}
+
+func (r *PeriodRepository) UpdateBoundaries(ctx context.Context, p academic.Period) error {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	_, err := exec.ExecContext(ctx, `
+		UPDATE periodos SET
+			fecha_inicio = ?, fecha_fin = ?,
+			clases_inicio = ?, clases_fin = ?,
+			parciales_inicio = ?, parciales_fin = ?,
+			finales_inicio = ?, finales_fin = ?
+		WHERE id = ?
+		`,
+		formatDay(p.Span.Start), formatDay(p.Span.End),
+		formatDay(p.Classes.Start), formatDay(p.Classes.End),
+		formatDay(p.Partials.Start), formatDay(p.Partials.End),
+		formatDay(p.Finals.Start), formatDay(p.Finals.End),
+		p.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("update period %v boundaries: %w", p.ID, err)
+	}
+
+	return nil
+}
+
+func (r *PeriodRepository) list(ctx context.Context, query string) ([]academic.Period, error) {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	rows, err := exec.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list periods: %w", err)
+	}
+	defer rows.Close()
+
+	var periods []academic.Period
+	for rows.Next() {
+		p, err := scanPeriod(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan period: %w", err)
+		}
+		periods = append(periods, *p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate period rows: %w", err)
+	}
+
+	return periods, nil
+}
+
+// scanPeriod reads a row selected with periodColumns. Works for both *sql.Row and *sql.Rows.
+func scanPeriod(row interface{ Scan(...any) error }) (*academic.Period, error) {
+	var (
+		p     academic.Period
+		dates [8]sql.NullString
+	)
+
+	err := row.Scan(
+		&p.ID, &p.Year, &p.Semester,
+		&dates[0], &dates[1], &dates[2], &dates[3],
+		&dates[4], &dates[5], &dates[6], &dates[7],
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := []*academic.DateRange{&p.Span, &p.Classes, &p.Partials, &p.Finals}
+	for i, rng := range ranges {
+		if rng.Start, err = parseDay(dates[2*i]); err != nil {
+			return nil, err
+		}
+		if rng.End, err = parseDay(dates[2*i+1]); err != nil {
+			return nil, err
+		}
+	}
+
+	return &p, nil
+}
+
+func parseDay(s sql.NullString) (time.Time, error) {
+	if !s.Valid || s.String == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.ParseInLocation(dayLayout, s.String, timezone.ParaguayTZ)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse day %q: %w", s.String, err)
+	}
+
+	return t, nil
+}
+
+// formatDay returns nil for zero dates so the column is stored as NULL.
+func formatDay(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.In(timezone.ParaguayTZ).Format(dayLayout)
+}
@@ -0,0 +1,59 @@
+package sqlite
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/persistence/sqlite/sqlitetest"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	academicRepo "github.com/elias-gill/poliplanner2/internal/repository/academic"
+)
+
+func TestPeriodRepositoryList(t *testing.T) {
+	ctx := context.Background()
+	db := sqlitetest.Open(t)
+	periods := NewPeriodRepository(db)
+
+	upsert := func(year int, semester academic.YearSemester) academic.PeriodID {
+		id, err := periods.Upsert(ctx, academic.Period{Year: year, Semester: semester})
+		if err != nil {
+			t.Fatalf("cannot create period: %v", err)
+		}
+		return id
+	}
+	older := upsert(2025, academic.SecondSemester)
+	first := upsert(2026, academic.FirstSemester)
+	second := upsert(2026, academic.SecondSemester)
+
+	curriculumID := createCurriculum(t, db)
+
+	// The second semester of 2026 has no courses yet
+	_, err := NewCourseRepository(db).UpsertBatch(ctx, []*academicRepo.CourseSaveParams{
+		{Name: "Fisica I", Section: "A", Shift: "M", Period: older, Curriculum: curriculumID},
+		{Name: "Fisica I", Section: "A", Shift: "M", Period: first, Curriculum: curriculumID},
+		{Name: "Fisica I", Section: "B", Shift: "M", Period: first, Curriculum: curriculumID},
+	})
+	if err != nil {
+		t.Fatalf("cannot create courses: %v", err)
+	}
+
+	ids := func(list []academic.Period, err error) []academic.PeriodID {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("cannot list periods: %v", err)
+		}
+		var ids []academic.PeriodID
+		for _, p := range list {
+			ids = append(ids, p.ID)
+		}
+		return ids
+	}
+
+	if got, want := ids(periods.List(ctx)), []academic.PeriodID{first, older}; !slices.Equal(got, want) {
+		t.Errorf("List() = %v; want %v, the periods with courses from the newest", got, want)
+	}
+	if got, want := ids(periods.ListAll(ctx)), []academic.PeriodID{second, first, older}; !slices.Equal(got, want) {
+		t.Errorf("ListAll() = %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,155 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+type RoomRepository struct {
+	db *sql.DB
+}
+
+func NewRoomRepository(db *sql.DB) *RoomRepository {
+	return &RoomRepository{db: db}
+}
+
+func (r *RoomRepository) ListSessions(ctx context.Context, period academic.PeriodID) ([]academic.RoomSessionView, error) {
+	query := `
+		SELECT
+			c.id,
+			c.nombre,
+			c.seccion,
+			h.dia,
+			h.desde,
+			h.hasta,
+			COALESCE(h.aula, ''),
+			COALESCE(h.edificio, ''),
+			COALESCE(h.numero_aula, '')
+		FROM curso_horarios h
+		JOIN cursos c ON c.id = h.curso_id
+		WHERE c.periodo = ? AND h.modalidad = ?
+		ORDER BY h.dia, h.desde
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, period, int(academic.RoomPhysical))
+	if err != nil {
+		return nil, fmt.Errorf("list room sessions: %w", err)
+	}
+	defer rows.Close()
+
+	const timeLayout = "15:04"
+
+	var sessions []academic.RoomSessionView
+	for rows.Next() {
+		var (
+			s        academic.RoomSessionView
+			startStr string
+			endStr   string
+		)
+
+		if err := rows.Scan(
+			&s.CourseID,
+			&s.CourseName,
+			&s.Section,
+			&s.Day,
+			&startStr,
+			&endStr,
+			&s.Room.Raw,
+			&s.Room.Building,
+			&s.Room.Number,
+		); err != nil {
+			return nil, fmt.Errorf("scan room session: %w", err)
+		}
+		s.Room.Kind = academic.RoomPhysical
+
+		start, err := time.Parse(timeLayout, startStr)
+		if err != nil {
+			return nil, fmt.Errorf("parse start time %q: %w", startStr, err)
+		}
+		end, err := time.Parse(timeLayout, endStr)
+		if err != nil {
+			return nil, fmt.Errorf("parse end time %q: %w", endStr, err)
+		}
+		s.Time = academic.TimeSlot{Start: &start, End: &end}
+
+		sessions = append(sessions, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate room session rows: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (r *RoomRepository) ListExams(ctx context.Context, period academic.PeriodID) ([]academic.RoomExamView, error) {
+	query := `
+		SELECT
+			c.id,
+			c.nombre,
+			c.seccion,
+			e.tipo,
+			e.instancia,
+			CAST(e.fecha AS TEXT),
+			CAST(e.hora AS TEXT),
+			COALESCE(e.aula, ''),
+			COALESCE(e.edificio, ''),
+			COALESCE(e.numero_aula, '')
+		FROM examenes e
+		JOIN cursos c ON c.id = e.curso_id
+		WHERE c.periodo = ? AND e.modalidad = ?
+		ORDER BY e.fecha, e.hora
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, period, int(academic.RoomPhysical))
+	if err != nil {
+		return nil, fmt.Errorf("list room exams: %w", err)
+	}
+	defer rows.Close()
+
+	var exams []academic.RoomExamView
+	for rows.Next() {
+		var (
+			e       academic.RoomExamView
+			dateStr string
+			hourStr string
+		)
+
+		if err := rows.Scan(
+			&e.CourseID,
+			&e.CourseName,
+			&e.Section,
+			&e.Type,
+			&e.Instance,
+			&dateStr,
+			&hourStr,
+			&e.Room.Raw,
+			&e.Room.Building,
+			&e.Room.Number,
+		); err != nil {
+			return nil, fmt.Errorf("scan room exam: %w", err)
+		}
+		e.Room.Kind = academic.RoomPhysical
+
+		if len(dateStr) >= 10 && len(hourStr) >= 5 {
+			date, err := time.ParseInLocation("2006-01-02 15:04", dateStr[:10]+" "+hourStr[:5], timezone.ParaguayTZ)
+			if err != nil {
+				return nil, fmt.Errorf("parse exam date %q %q: %w", dateStr, hourStr, err)
+			}
+			e.Date = &date
+		}
+
+		exams = append(exams, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate room exam rows: %w", err)
+	}
+
+	return exams, nil
+}
@@ -0,0 +1,173 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	txManager "github.com/elias-gill/poliplanner2/internal/infrastructure/persistence/sqlite/tx_manager"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+type SearchRepository struct {
+	db *sql.DB
+}
+
+func NewSearchRepository(db *sql.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// The same course is offered to many careers, so courses are grouped by name, section and
+// shift, listing every career on the detail.
+const indexCoursesQuery = `
+	WITH grupos AS (
+		SELECT
+			MIN(c.id) AS id,
+			c.nombre,
+			c.seccion,
+			c.turno,
+			MIN(a.nombre) AS asignatura,
+			MIN(d.siglas) AS depto_siglas,
+			MIN(COALESCE(d.nombre, '')) AS depto_nombre,
+			GROUP_CONCAT(DISTINCT ca.siglas) AS carreras
+		FROM cursos c
+		JOIN mallas m ON m.id = c.malla
+		JOIN carreras ca ON ca.id = m.carrera
+		JOIN asignaturas a ON a.id = m.asignatura
+		JOIN departamentos d ON d.id = a.departamento
+		WHERE c.periodo = ?
+		GROUP BY c.nombre, c.seccion, c.turno
+	)
+	INSERT INTO busqueda (tipo, ref, titulo, detalle, texto)
+	SELECT
+		?,
+		g.id,
+		g.nombre,
+		'Sección ' || g.seccion || ' · ' || g.turno || ' · ' || REPLACE(g.carreras, ',', ', '),
+		g.nombre || ' ' || g.asignatura || ' ' || g.depto_siglas || ' ' || g.depto_nombre || ' ' ||
+		g.seccion || ' ' || g.carreras || ' ' ||
+		COALESCE((
+			SELECT GROUP_CONCAT(dc.nombre || ' ' || dc.apellido, ' ')
+			FROM docentes_curso x
+			JOIN docentes dc ON dc.id = x.id_docente
+			WHERE x.id_curso = g.id
+		), '') || ' ' ||
+		COALESCE((
+			SELECT GROUP_CONCAT(DISTINCT COALESCE(h.aula, ''))
+			FROM curso_horarios h
+			WHERE h.curso_id = g.id
+		), '')
+	FROM grupos g
+`
+
+const indexSubjectsQuery = `
+	INSERT INTO busqueda (tipo, ref, titulo, detalle, texto)
+	SELECT
+		?,
+		a.id,
+		a.nombre,
+		d.siglas || ' · ' || REPLACE(GROUP_CONCAT(DISTINCT ca.siglas), ',', ', '),
+		a.nombre || ' ' || d.siglas || ' ' || COALESCE(d.nombre, '')
+	FROM asignaturas a
+	JOIN departamentos d ON d.id = a.departamento
+	JOIN mallas m ON m.asignatura = a.id
+	JOIN carreras ca ON ca.id = m.carrera
+	JOIN cursos c ON c.malla = m.id
+	WHERE c.periodo = ?
+	GROUP BY a.id
+`
+
+const indexTeachersQuery = `
+	INSERT INTO busqueda (tipo, ref, titulo, detalle, texto)
+	SELECT
+		?,
+		dc.id,
+		dc.nombre || ' ' || dc.apellido,
+		TRIM(COALESCE(dc.titulo, '') || ' ' || COALESCE(dc.correo, '')),
+		dc.nombre || ' ' || dc.apellido || ' ' || COALESCE(dc.correo, '')
+	FROM docentes dc
+	WHERE EXISTS (
+		SELECT 1
+		FROM docentes_curso x
+		JOIN cursos c ON c.id = x.id_curso
+		WHERE x.id_docente = dc.id AND c.periodo = ?
+	)
+`
+
+// Rooms use the same code as academic.Location.Code, so results link to the room page.
+const indexRoomsQuery = `
+	WITH aulas AS (
+		SELECT
+			CASE
+				WHEN COALESCE(h.edificio, '') <> '' AND COALESCE(h.numero_aula, '') <> ''
+					THEN h.edificio || '-' || h.numero_aula
+				ELSE UPPER(TRIM(h.aula))
+			END AS codigo,
+			h.aula,
+			c.id AS curso
+		FROM curso_horarios h
+		JOIN cursos c ON c.id = h.curso_id
+		WHERE c.periodo = ? AND h.modalidad = ? AND TRIM(COALESCE(h.aula, '')) <> ''
+	)
+	INSERT INTO busqueda (tipo, ref, titulo, detalle, texto)
+	SELECT
+		?,
+		codigo,
+		codigo,
+		COUNT(DISTINCT curso) || ' cursos',
+		codigo || ' ' || GROUP_CONCAT(DISTINCT aula)
+	FROM aulas
+	GROUP BY codigo
+`
+
+func (r *SearchRepository) Rebuild(ctx context.Context, period academic.PeriodID) error {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	if _, err := exec.ExecContext(ctx, `DELETE FROM busqueda`); err != nil {
+		return fmt.Errorf("clear search index: %w", err)
+	}
+
+	if _, err := exec.ExecContext(ctx, indexCoursesQuery, period, academic.SearchCourse); err != nil {
+		return fmt.Errorf("index courses: %w", err)
+	}
+	if _, err := exec.ExecContext(ctx, indexSubjectsQuery, academic.SearchSubject, period); err != nil {
+		return fmt.Errorf("index subjects: %w", err)
+	}
+	if _, err := exec.ExecContext(ctx, indexTeachersQuery, academic.SearchTeacher, period); err != nil {
+		return fmt.Errorf("index teachers: %w", err)
+	}
+	if _, err := exec.ExecContext(ctx, indexRoomsQuery, period, int(academic.RoomPhysical), academic.SearchRoom); err != nil {
+		return fmt.Errorf("index rooms: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SearchRepository) Search(ctx context.Context, query string, limit int) ([]academic.SearchResult, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT tipo, ref, titulo, detalle
+		FROM busqueda
+		WHERE busqueda MATCH ?
+		ORDER BY rank
+		LIMIT ?
+	`, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("search %q: %w", query, err)
+	}
+	defer rows.Close()
+
+	var results []academic.SearchResult
+	for rows.Next() {
+		var res academic.SearchResult
+		if err := rows.Scan(&res.Kind, &res.Ref, &res.Title, &res.Detail); err != nil {
+			return nil, fmt.Errorf("scan search result: %w", err)
+		}
+		results = append(results, res)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate search rows: %w", err)
+	}
+
+	return results, nil
+}
@@ -19,41 +19,59 @@ func NewSubjectRepository(db *sql.DB) *SubjectRepository {
 
 // Upsert inserts or updates a department and its associated subject.
 func (r *SubjectRepository) Upsert(ctx context.Context, s academic.Subject) (academic.SubjectID, error) {
-	exec := txManager.GetExecutor(ctx, r.db)
-
-	// Upsert department (now includes 'nombre')
-	_, err := exec.ExecContext(ctx, `
-		INSERT INTO departamentos (siglas, nombre)
-		VALUES (?, ?)
-		ON CONFLICT(siglas) DO UPDATE SET nombre = excluded.nombre
-	`, s.Department.Code, s.Department.Name)
+	ids, err := r.UpsertBatch(ctx, []academic.Subject{s})
 	if err != nil {
 		return 0, err
 	}
 
-	var deptID int64
-	err = exec.QueryRowContext(ctx, `SELECT id FROM departamentos WHERE siglas = ?`, s.Department.Code).Scan(&deptID)
-	if err != nil {
-		return 0, err
-	}
+	return ids[0], nil
+}
+
+// UpsertBatch upserts the subjects and their departments in order, reusing the same
+// statements for all of them.
+func (r *SubjectRepository) UpsertBatch(ctx context.Context, subjects []academic.Subject) ([]academic.SubjectID, error) {
+	exec := txManager.GetExecutor(ctx, r.db)
 
-	// Upsert subject
-	_, err = exec.ExecContext(ctx, `
-		INSERT INTO asignaturas (nombre, departamento)
+	stmts, err := prepareAll(ctx, exec,
+		`INSERT INTO departamentos (siglas, nombre)
 		VALUES (?, ?)
-		ON CONFLICT(nombre) DO UPDATE SET departamento = excluded.departamento
-	`, s.Name, deptID)
+		ON CONFLICT(siglas) DO UPDATE SET nombre = excluded.nombre`,
+		`SELECT id FROM departamentos WHERE siglas = ?`,
+		`INSERT INTO asignaturas (nombre, departamento)
+		VALUES (?, ?)
+		ON CONFLICT(nombre) DO UPDATE SET departamento = excluded.departamento`,
+		`SELECT id FROM asignaturas WHERE nombre = ?`,
+	)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
+	defer closeAll(stmts)
+	upsertDept, selectDept, upsertSubject, selectSubject := stmts[0], stmts[1], stmts[2], stmts[3]
 
-	var id int64
-	err = exec.QueryRowContext(ctx, `SELECT id FROM asignaturas WHERE nombre = ?`, s.Name).Scan(&id)
-	if err != nil {
-		return 0, err
+	ids := make([]academic.SubjectID, 0, len(subjects))
+	for _, s := range subjects {
+		if _, err := upsertDept.ExecContext(ctx, s.Department.Code, s.Department.Name); err != nil {
+			return nil, err
+		}
+
+		var deptID int64
+		if err := selectDept.QueryRowContext(ctx, s.Department.Code).Scan(&deptID); err != nil {
+			return nil, err
+		}
+
+		if _, err := upsertSubject.ExecContext(ctx, s.Name, deptID); err != nil {
+			return nil, err
+		}
+
+		var id int64
+		if err := selectSubject.QueryRowContext(ctx, s.Name).Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, academic.SubjectID(id))
 	}
 
-	return academic.SubjectID(id), nil
+	return ids, nil
 }
 
 // GetByID retrieves a subject by its database ID, joining its corresponding department.
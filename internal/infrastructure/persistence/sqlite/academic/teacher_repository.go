@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 
 	txManager "github.com/elias-gill/poliplanner2/internal/infrastructure/persistence/sqlite/tx_manager"
 	"github.com/elias-gill/poliplanner2/internal/model/academic"
@@ -19,8 +20,75 @@ func NewTeacherRepository(db *sql.DB) *TeacherRepository {
 
 // Upsert inserts or updates a teacher record by email.
 func (r *TeacherRepository) Upsert(ctx context.Context, t academic.Teacher) (academic.TeacherID, error) {
+	ids, err := r.UpsertBatch(ctx, []academic.Teacher{t})
+	if err != nil {
+		return 0, err
+	}
+
+	return ids[0], nil
+}
+
+// UpsertBatch upserts the teachers in order, same as Upsert, reusing the same statements for
+// all of them.
+func (r *TeacherRepository) UpsertBatch(ctx context.Context, teachers []academic.Teacher) ([]academic.TeacherID, error) {
 	exec := txManager.GetExecutor(ctx, r.db)
 
+	stmts, err := prepareAll(ctx, exec,
+		// COALESCE avoids scan errors in Go if stored 'correo' is SQL NULL.
+		`SELECT id, COALESCE(correo, '')
+		FROM docentes
+		WHERE nombre = ? AND apellido = ?
+		LIMIT 1`,
+		`SELECT id_docente
+		FROM docentes_alias
+		WHERE nombre = ? AND apellido = ?`,
+		`SELECT id
+		FROM docentes
+		WHERE correo = ?
+		LIMIT 1`,
+		`UPDATE docentes
+		SET titulo = ?, nombre = ?, apellido = ?, correo = ?
+		WHERE id = ?`,
+		`UPDATE docentes
+		SET correo = ?
+		WHERE id = ? AND COALESCE(correo, '') = ''`,
+		`INSERT INTO docentes (titulo, nombre, apellido, correo)
+		VALUES (?, ?, ?, ?)
+		RETURNING id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer closeAll(stmts)
+
+	upsert := teacherUpsert{
+		byName:      stmts[0],
+		byAlias:     stmts[1],
+		byEmail:     stmts[2],
+		update:      stmts[3],
+		updateEmail: stmts[4],
+		insert:      stmts[5],
+	}
+
+	ids := make([]academic.TeacherID, 0, len(teachers))
+	for _, t := range teachers {
+		id, err := upsert.exec(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// teacherUpsert holds the prepared statements of a teacher upsert.
+type teacherUpsert struct {
+	byName, byAlias, byEmail    *sql.Stmt
+	update, updateEmail, insert *sql.Stmt
+}
+
+func (u teacherUpsert) exec(ctx context.Context, t academic.Teacher) (academic.TeacherID, error) {
 	// Prepare database email parameter: NULL when empty, string otherwise
 	var dbEmail any = t.Email
 	if t.Email == "" {
@@ -31,13 +99,7 @@ func (r *TeacherRepository) Upsert(ctx context.Context, t academic.Teacher) (aca
 	var existingEmail string
 
 	// Primary search: match by full name combination.
-	// COALESCE avoids scan errors in Go if stored 'correo' is SQL NULL.
-	err := exec.QueryRowContext(ctx, `
-		SELECT id, COALESCE(correo, '') 
-		FROM docentes 
-		WHERE nombre = ? AND apellido = ? 
-		LIMIT 1
-	`, t.FirstName, t.LastName).Scan(&existingID, &existingEmail)
+	err := u.byName.QueryRowContext(ctx, t.FirstName, t.LastName).Scan(&existingID, &existingEmail)
 
 	// Primary match found: update teacher info while preserving existing email if incoming email is empty.
 	if err == nil {
@@ -48,11 +110,7 @@ func (r *TeacherRepository) Upsert(ctx context.Context, t academic.Teacher) (aca
 			finalEmail = nil
 		}
 
-		_, updateErr := exec.ExecContext(ctx, `
-			UPDATE docentes 
-			SET titulo = ?, nombre = ?, apellido = ?, correo = ? 
-			WHERE id = ?
-		`, t.Title, t.FirstName, t.LastName, finalEmail, existingID)
+		_, updateErr := u.update.ExecContext(ctx, t.Title, t.FirstName, t.LastName, finalEmail, existingID)
 		if updateErr != nil {
 			return 0, updateErr
 		}
@@ -64,21 +122,31 @@ func (r *TeacherRepository) Upsert(ctx context.Context, t academic.Teacher) (aca
 		return 0, err
 	}
 
+	// Alias search: names of teachers merged by an admin point to the surviving record. The
+	// survivor keeps its own name, we only complete the email when it was missing.
+	err = u.byAlias.QueryRowContext(ctx, t.FirstName, t.LastName).Scan(&existingID)
+
+	if err == nil {
+		if t.Email != "" {
+			_, updateErr := u.updateEmail.ExecContext(ctx, t.Email, existingID)
+			if updateErr != nil {
+				return 0, updateErr
+			}
+		}
+
+		return academic.TeacherID(existingID), nil
+	}
+
+	if err != sql.ErrNoRows {
+		return 0, err
+	}
+
 	// Secondary search: match by email (only executed when an incoming email is present).
 	if t.Email != "" {
-		err = exec.QueryRowContext(ctx, `
-			SELECT id 
-			FROM docentes 
-			WHERE correo = ? 
-			LIMIT 1
-		`, t.Email).Scan(&existingID)
+		err = u.byEmail.QueryRowContext(ctx, t.Email).Scan(&existingID)
 
 		if err == nil {
-			_, updateErr := exec.ExecContext(ctx, `
-				UPDATE docentes 
-				SET titulo = ?, nombre = ?, apellido = ?, correo = ? 
-				WHERE id = ?
-			`, t.Title, t.FirstName, t.LastName, dbEmail, existingID)
+			_, updateErr := u.update.ExecContext(ctx, t.Title, t.FirstName, t.LastName, dbEmail, existingID)
 			if updateErr != nil {
 				return 0, updateErr
 			}
@@ -93,11 +161,7 @@ func (r *TeacherRepository) Upsert(ctx context.Context, t academic.Teacher) (aca
 
 	// No match found: insert a new teacher record.
 	var newID int64
-	err = exec.QueryRowContext(ctx, `
-		INSERT INTO docentes (titulo, nombre, apellido, correo) 
-		VALUES (?, ?, ?, ?) 
-		RETURNING id
-	`, t.Title, t.FirstName, t.LastName, dbEmail).Scan(&newID)
+	err = u.insert.QueryRowContext(ctx, t.Title, t.FirstName, t.LastName, dbEmail).Scan(&newID)
 	if err != nil {
 		return 0, err
 	}
@@ -109,9 +173,9 @@ func (r *TeacherRepository) Upsert(ctx context.Context, t academic.Teacher) (aca
 func (r *TeacherRepository) GetByID(ctx context.Context, id academic.TeacherID) (*academic.Teacher, error) {
 	exec := txManager.GetExecutor(ctx, r.db)
 
-	var t academic.Teacher
+	t := academic.Teacher{ID: id}
 	err := exec.QueryRowContext(ctx, `
-		SELECT titulo, nombre, apellido, correo
+		SELECT COALESCE(titulo, ''), nombre, apellido, COALESCE(correo, '')
 		FROM docentes
 		WHERE id = ?
 	`, id).Scan(&t.Title, &t.FirstName, &t.LastName, &t.Email)
@@ -125,3 +189,238 @@ func (r *TeacherRepository) GetByID(ctx context.Context, id academic.TeacherID)
 
 	return &t, nil
 }
+
+// ListByPeriod returns the teachers of the period sorted by last name. The same course is
+// listed once per career that shares it, so courses are counted by name, section and shift.
+func (r *TeacherRepository) ListByPeriod(ctx context.Context, period academic.PeriodID) ([]academic.TeacherSummaryView, error) {
+	query := `
+		SELECT
+			d.id,
+			COALESCE(d.titulo, ''),
+			d.nombre,
+			d.apellido,
+			COALESCE(d.correo, ''),
+			COUNT(DISTINCT c.nombre || '|' || c.seccion || '|' || c.turno)
+		FROM docentes d
+		JOIN docentes_curso dc ON dc.id_docente = d.id
+		JOIN cursos c ON c.id = dc.id_curso
+		WHERE c.periodo = ?
+		GROUP BY d.id
+		ORDER BY d.apellido, d.nombre
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, period)
+	if err != nil {
+		return nil, fmt.Errorf("list teachers: %w", err)
+	}
+	defer rows.Close()
+
+	var teachers []academic.TeacherSummaryView
+	for rows.Next() {
+		var t academic.TeacherSummaryView
+		if err := rows.Scan(
+			&t.Teacher.ID,
+			&t.Teacher.Title,
+			&t.Teacher.FirstName,
+			&t.Teacher.LastName,
+			&t.Teacher.Email,
+			&t.Courses,
+		); err != nil {
+			return nil, fmt.Errorf("scan teacher: %w", err)
+		}
+		teachers = append(teachers, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate teacher rows: %w", err)
+	}
+
+	return teachers, nil
+}
+
+func (r *TeacherRepository) ListCourses(ctx context.Context, id academic.TeacherID, period academic.PeriodID) ([]academic.TeacherCourseView, error) {
+	query := `
+		SELECT MIN(c.id), c.nombre, c.seccion, c.turno
+		FROM cursos c
+		JOIN docentes_curso dc ON dc.id_curso = c.id
+		WHERE dc.id_docente = ? AND c.periodo = ?
+		GROUP BY c.nombre, c.seccion, c.turno
+		ORDER BY c.nombre, c.seccion
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, id, period)
+	if err != nil {
+		return nil, fmt.Errorf("list teacher courses: %w", err)
+	}
+	defer rows.Close()
+
+	var courses []academic.TeacherCourseView
+	for rows.Next() {
+		var c academic.TeacherCourseView
+		if err := rows.Scan(&c.CourseID, &c.Name, &c.Section, &c.Shift); err != nil {
+			return nil, fmt.Errorf("scan teacher course: %w", err)
+		}
+		courses = append(courses, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate teacher course rows: %w", err)
+	}
+
+	return courses, nil
+}
+
+func (r *TeacherRepository) ListAll(ctx context.Context) ([]academic.TeacherSummaryView, error) {
+	query := `
+		SELECT
+			d.id,
+			COALESCE(d.titulo, ''),
+			d.nombre,
+			d.apellido,
+			COALESCE(d.correo, ''),
+			COUNT(DISTINCT c.periodo || '|' || c.nombre || '|' || c.seccion || '|' || c.turno)
+		FROM docentes d
+		LEFT JOIN docentes_curso dc ON dc.id_docente = d.id
+		LEFT JOIN cursos c ON c.id = dc.id_curso
+		GROUP BY d.id
+		ORDER BY d.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list all teachers: %w", err)
+	}
+	defer rows.Close()
+
+	var teachers []academic.TeacherSummaryView
+	for rows.Next() {
+		var t academic.TeacherSummaryView
+		if err := rows.Scan(
+			&t.Teacher.ID,
+			&t.Teacher.Title,
+			&t.Teacher.FirstName,
+			&t.Teacher.LastName,
+			&t.Teacher.Email,
+			&t.Courses,
+		); err != nil {
+			return nil, fmt.Errorf("scan teacher: %w", err)
+		}
+		teachers = append(teachers, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate teacher rows: %w", err)
+	}
+
+	return teachers, nil
+}
+
+// Merge must run inside a transaction, otherwise a failure can leave the course
+// assignments split between both records.
+func (r *TeacherRepository) Merge(ctx context.Context, survivor academic.TeacherID, duplicates []academic.TeacherID) error {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	for _, dup := range duplicates {
+		if dup == survivor {
+			continue
+		}
+
+		// Courses already taught by the survivor are ignored by the primary key
+		if _, err := exec.ExecContext(ctx, `
+			INSERT OR IGNORE INTO docentes_curso (id_docente, id_curso)
+			SELECT ?, id_curso FROM docentes_curso WHERE id_docente = ?
+		`, survivor, dup); err != nil {
+			return fmt.Errorf("reassign courses of teacher %v: %w", dup, err)
+		}
+
+		if _, err := exec.ExecContext(ctx, `
+			INSERT INTO docentes_alias (nombre, apellido, id_docente)
+			SELECT nombre, apellido, ? FROM docentes WHERE id = ?
+			ON CONFLICT (nombre, apellido) DO UPDATE SET id_docente = excluded.id_docente
+		`, survivor, dup); err != nil {
+			return fmt.Errorf("save alias of teacher %v: %w", dup, err)
+		}
+
+		if _, err := exec.ExecContext(ctx, `
+			UPDATE docentes_alias SET id_docente = ? WHERE id_docente = ?
+		`, survivor, dup); err != nil {
+			return fmt.Errorf("move aliases of teacher %v: %w", dup, err)
+		}
+
+		if _, err := exec.ExecContext(ctx, `
+			UPDATE examinadores SET id_docente = ? WHERE id_docente = ?
+		`, survivor, dup); err != nil {
+			return fmt.Errorf("move examiners of teacher %v: %w", dup, err)
+		}
+
+		// Complete the data missing on the survivor
+		if _, err := exec.ExecContext(ctx, `
+			UPDATE docentes
+			SET
+				correo = COALESCE(NULLIF(correo, ''), (SELECT NULLIF(correo, '') FROM docentes WHERE id = ?)),
+				titulo = COALESCE(NULLIF(titulo, ''), (SELECT NULLIF(titulo, '') FROM docentes WHERE id = ?))
+			WHERE id = ?
+		`, dup, dup, survivor); err != nil {
+			return fmt.Errorf("complete teacher %v: %w", survivor, err)
+		}
+
+		if _, err := exec.ExecContext(ctx, `DELETE FROM docentes_curso WHERE id_docente = ?`, dup); err != nil {
+			return fmt.Errorf("delete courses of teacher %v: %w", dup, err)
+		}
+
+		if _, err := exec.ExecContext(ctx, `DELETE FROM docentes WHERE id = ?`, dup); err != nil {
+			return fmt.Errorf("delete teacher %v: %w", dup, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *TeacherRepository) ListCommittees(ctx context.Context, period academic.PeriodID) ([]academic.CourseSummaryView, error) {
+	query := `
+		SELECT
+			MIN(id),
+			nombre,
+			seccion,
+			turno,
+			COALESCE(comite_presidente, ''),
+			COALESCE(comite_miembro1, ''),
+			COALESCE(comite_miembro2, '')
+		FROM cursos
+		WHERE periodo = ?
+			AND (COALESCE(comite_presidente, '') <> ''
+				OR COALESCE(comite_miembro1, '') <> ''
+				OR COALESCE(comite_miembro2, '') <> '')
+		GROUP BY nombre, seccion, turno
+		ORDER BY nombre, seccion
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, period)
+	if err != nil {
+		return nil, fmt.Errorf("list committees: %w", err)
+	}
+	defer rows.Close()
+
+	var courses []academic.CourseSummaryView
+	for rows.Next() {
+		var c academic.CourseSummaryView
+		if err := rows.Scan(
+			&c.ID,
+			&c.Name,
+			&c.Section,
+			&c.Shift,
+			&c.Committee.President,
+			&c.Committee.Member1,
+			&c.Committee.Member2,
+		); err != nil {
+			return nil, fmt.Errorf("scan committee: %w", err)
+		}
+		courses = append(courses, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate committee rows: %w", err)
+	}
+
+	return courses, nil
+}
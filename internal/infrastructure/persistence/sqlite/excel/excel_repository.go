@@ -1,8 +1,12 @@
 package sqlite
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -27,7 +31,7 @@ func (r *SQLiteExcelRepository) SaveVersion(ctx context.Context, version *excel.
 		successInt = 0
 	}
 
-	_, err := exec.ExecContext(ctx, `
+	res, err := exec.ExecContext(ctx, `
 		INSERT INTO sheet_version (
 			file_name,
 			url,
@@ -35,8 +39,11 @@ func (r *SQLiteExcelRepository) SaveVersion(ctx context.Context, version *excel.
 			error_message,
 			parsed_sheets,
 			period,
-			parsed_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?)
+			parsed_at,
+			restored_from,
+			kind,
+			hash
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`,
 		version.Name,
 		version.URL,
@@ -45,31 +52,125 @@ func (r *SQLiteExcelRepository) SaveVersion(ctx context.Context, version *excel.
 		version.ParsedSheets,
 		version.PeriodID,
 		version.ParsedAt.Format("2006-01-02 15:04:05"),
+		sql.NullInt64{Int64: int64(version.RestoredFrom), Valid: version.RestoredFrom != 0},
+		version.Kind,
+		sql.NullString{String: version.Hash, Valid: version.Hash != ""},
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to insert sheet version: %w", err)
 	}
 
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get sheet version id: %w", err)
+	}
+	version.ID = excel.SheetVersionID(id)
+
 	return nil
 }
 
+const versionColumns = `
+	v.version_id,
+	v.file_name,
+	v.url,
+	v.success,
+	v.error_message,
+	v.parsed_sheets,
+	v.period,
+	v.parsed_at,
+	v.restored_from,
+	v.kind,
+	v.hash,
+	EXISTS (SELECT 1 FROM sheet_version_snapshot s WHERE s.version_id = v.version_id),
+	EXISTS (SELECT 1 FROM sheet_version_report r WHERE r.version_id = v.version_id),
+	EXISTS (SELECT 1 FROM sheet_source_archive a WHERE a.hash = v.hash)`
+
+func scanVersion(row interface{ Scan(...any) error }) (*excel.SheetVersion, error) {
+	v := &excel.SheetVersion{}
+	var successInt int
+	var parsedAtStr string
+	var errorMessage sql.NullString
+	var periodID sql.NullInt64
+	var restoredFrom sql.NullInt64
+	var hash sql.NullString
+
+	err := row.Scan(
+		&v.ID,
+		&v.Name,
+		&v.URL,
+		&successInt,
+		&errorMessage,
+		&v.ParsedSheets,
+		&periodID,
+		&parsedAtStr,
+		&restoredFrom,
+		&v.Kind,
+		&hash,
+		&v.HasSnapshot,
+		&v.HasReport,
+		&v.HasArchive,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	v.Succeeded = successInt == 1
+
+	if errorMessage.Valid {
+		v.Error = errorMessage.String
+	}
+
+	if periodID.Valid {
+		v.PeriodID = academic.PeriodID(periodID.Int64)
+	}
+
+	if restoredFrom.Valid {
+		v.RestoredFrom = excel.SheetVersionID(restoredFrom.Int64)
+	}
+
+	if hash.Valid {
+		v.Hash = hash.String
+	}
+
+	parsedAt, err := time.Parse("2006-01-02 15:04:05", parsedAtStr)
+	if err != nil {
+		parsedAt, err = time.Parse(time.RFC3339, parsedAtStr)
+	}
+
+	if err == nil {
+		v.ParsedAt = parsedAt
+	}
+
+	return v, nil
+}
+
+func (r *SQLiteExcelRepository) GetVersion(ctx context.Context, id excel.SheetVersionID) (*excel.SheetVersion, error) {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	row := exec.QueryRowContext(ctx, `
+		SELECT `+versionColumns+` FROM sheet_version v WHERE v.version_id = ?
+		`, id)
+
+	v, err := scanVersion(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get sheet version: %w", err)
+	}
+
+	return v, nil
+}
+
 func (r *SQLiteExcelRepository) ListAllVersions(ctx context.Context) ([]*excel.SheetVersion, error) {
 	exec := txManager.GetExecutor(ctx, r.db)
 
 	rows, err := exec.QueryContext(ctx, `
-        SELECT 
-            version_id, 
-            file_name, 
-            url, 
-            success, 
-            error_message, 
-            parsed_sheets, 
-            period, 
-            parsed_at
-        FROM sheet_version
-        ORDER BY parsed_at DESC
-        `)
+		SELECT `+versionColumns+`
+		FROM sheet_version v
+		ORDER BY v.parsed_at DESC, v.version_id DESC
+		`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query sheet versions: %w", err)
 	}
@@ -78,51 +179,337 @@ func (r *SQLiteExcelRepository) ListAllVersions(ctx context.Context) ([]*excel.S
 	var versions []*excel.SheetVersion
 
 	for rows.Next() {
-		v := &excel.SheetVersion{}
-		var successInt int
-		var parsedAtStr string
-		var errorMessage sql.NullString
-		var periodID sql.NullInt64
-
-		err := rows.Scan(
-			&v.ID,
-			&v.Name,
-			&v.URL,
-			&successInt,
-			&errorMessage,
-			&v.ParsedSheets,
-			&periodID,
-			&parsedAtStr,
-		)
+		v, err := scanVersion(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan sheet version row: %w", err)
 		}
 
-		v.Succeeded = successInt == 1
+		versions = append(versions, v)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during sheet versions iteration: %w", err)
+	}
+
+	return versions, nil
+}
+
+func (r *SQLiteExcelRepository) GetImportedVersion(ctx context.Context, hash string) (*excel.SheetVersion, error) {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	row := exec.QueryRowContext(ctx, `
+		SELECT `+versionColumns+`
+		FROM sheet_version v
+		WHERE v.hash = ? AND v.success = 1 AND v.restored_from IS NULL
+		ORDER BY v.parsed_at DESC, v.version_id DESC
+		LIMIT 1
+		`, hash)
+
+	v, err := scanVersion(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get imported sheet version: %w", err)
+	}
+
+	return v, nil
+}
+
+// SaveSnapshot stores the snapshot as gzipped JSON. A whole workbook takes a few hundred KB
+// as plain JSON, but it is very repetitive and compresses well.
+func (r *SQLiteExcelRepository) SaveSnapshot(ctx context.Context, id excel.SheetVersionID, snapshot *excel.Snapshot) error {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	data, err := encodeGzipJSON(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	_, err = exec.ExecContext(ctx, `
+		INSERT INTO sheet_version_snapshot (version_id, data) VALUES (?, ?)
+		ON CONFLICT(version_id) DO UPDATE SET data = excluded.data
+		`, id, data)
+	if err != nil {
+		return fmt.Errorf("failed to insert snapshot: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteExcelRepository) GetSnapshot(ctx context.Context, id excel.SheetVersionID) (*excel.Snapshot, error) {
+	exec := txManager.GetExecutor(ctx, r.db)
 
-		if errorMessage.Valid {
-			v.Error = errorMessage.String
+	var data []byte
+	err := exec.QueryRowContext(ctx, `
+		SELECT data FROM sheet_version_snapshot WHERE version_id = ?
+		`, id).Scan(&data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
 		}
+		return nil, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+
+	snapshot := &excel.Snapshot{}
+	if err := decodeGzipJSON(data, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
 
-		if periodID.Valid {
-			v.PeriodID = academic.PeriodID(periodID.Int64)
+// SaveReport stores the import report as gzipped JSON, same as the snapshots.
+func (r *SQLiteExcelRepository) SaveReport(ctx context.Context, id excel.SheetVersionID, report *excel.ImportReport) error {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	data, err := encodeGzipJSON(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode import report: %w", err)
+	}
+
+	_, err = exec.ExecContext(ctx, `
+		INSERT INTO sheet_version_report (version_id, data) VALUES (?, ?)
+		ON CONFLICT(version_id) DO UPDATE SET data = excluded.data
+		`, id, data)
+	if err != nil {
+		return fmt.Errorf("failed to insert import report: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteExcelRepository) GetReport(ctx context.Context, id excel.SheetVersionID) (*excel.ImportReport, error) {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	var data []byte
+	err := exec.QueryRowContext(ctx, `
+		SELECT data FROM sheet_version_report WHERE version_id = ?
+		`, id).Scan(&data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
 		}
+		return nil, fmt.Errorf("failed to get import report: %w", err)
+	}
 
-		parsedAt, err := time.Parse("2006-01-02 15:04:05", parsedAtStr)
-		if err != nil {
-			parsedAt, err = time.Parse(time.RFC3339, parsedAtStr)
+	report := &excel.ImportReport{}
+	if err := decodeGzipJSON(data, report); err != nil {
+		return nil, fmt.Errorf("failed to decode import report: %w", err)
+	}
+
+	return report, nil
+}
+
+func (r *SQLiteExcelRepository) SaveArchive(ctx context.Context, archived excel.ArchivedSource) error {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	_, err := exec.ExecContext(ctx, `
+		INSERT INTO sheet_source_archive (hash, size, archived_at) VALUES (?, ?, ?)
+		ON CONFLICT(hash) DO UPDATE SET archived_at = excluded.archived_at
+		`,
+		archived.Hash,
+		archived.Size,
+		archived.ArchivedAt.Format("2006-01-02 15:04:05"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert archived source: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteExcelRepository) ListArchives(ctx context.Context) ([]excel.ArchivedSource, error) {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	rows, err := exec.QueryContext(ctx, `
+		SELECT hash, size, archived_at FROM sheet_source_archive ORDER BY archived_at DESC
+		`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archived sources: %w", err)
+	}
+	defer rows.Close()
+
+	var archives []excel.ArchivedSource
+
+	for rows.Next() {
+		var a excel.ArchivedSource
+		var archivedAtStr string
+
+		if err := rows.Scan(&a.Hash, &a.Size, &archivedAtStr); err != nil {
+			return nil, fmt.Errorf("failed to scan archived source row: %w", err)
 		}
 
+		archivedAt, err := time.Parse("2006-01-02 15:04:05", archivedAtStr)
+		if err != nil {
+			archivedAt, err = time.Parse(time.RFC3339, archivedAtStr)
+		}
 		if err == nil {
-			v.ParsedAt = parsedAt
+			a.ArchivedAt = archivedAt
 		}
 
-		versions = append(versions, v)
+		archives = append(archives, a)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error during sheet versions iteration: %w", err)
+		return nil, fmt.Errorf("error during archived sources iteration: %w", err)
 	}
 
-	return versions, nil
+	return archives, nil
+}
+
+func (r *SQLiteExcelRepository) DeleteArchive(ctx context.Context, hash string) error {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	if _, err := exec.ExecContext(ctx, `DELETE FROM sheet_source_archive WHERE hash = ?`, hash); err != nil {
+		return fmt.Errorf("failed to delete archived source: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteExcelRepository) SaveUnmatchedHeader(ctx context.Context, header *excel.UnmatchedHeader) error {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	cells, err := json.Marshal(header.Cells)
+	if err != nil {
+		return fmt.Errorf("failed to encode header cells: %w", err)
+	}
+
+	res, err := exec.ExecContext(ctx, `
+		INSERT INTO sheet_unmatched_header (version_id, sheet_name, header_row, cells) VALUES (?, ?, ?, ?)
+		`,
+		header.VersionID,
+		header.Sheet,
+		header.Row,
+		string(cells),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert unmatched header: %w", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get unmatched header id: %w", err)
+	}
+	header.ID = excel.UnmatchedHeaderID(id)
+
+	return nil
+}
+
+const unmatchedHeaderColumns = `
+	h.header_id,
+	h.version_id,
+	v.kind,
+	v.parsed_at,
+	h.sheet_name,
+	h.header_row,
+	h.cells`
+
+func scanUnmatchedHeader(row interface{ Scan(...any) error }) (*excel.UnmatchedHeader, error) {
+	h := &excel.UnmatchedHeader{}
+	var parsedAtStr string
+	var cells string
+
+	err := row.Scan(
+		&h.ID,
+		&h.VersionID,
+		&h.Kind,
+		&parsedAtStr,
+		&h.Sheet,
+		&h.Row,
+		&cells,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(cells), &h.Cells); err != nil {
+		return nil, fmt.Errorf("failed to decode header cells: %w", err)
+	}
+
+	parsedAt, err := time.Parse("2006-01-02 15:04:05", parsedAtStr)
+	if err != nil {
+		parsedAt, err = time.Parse(time.RFC3339, parsedAtStr)
+	}
+	if err == nil {
+		h.ParsedAt = parsedAt
+	}
+
+	return h, nil
+}
+
+func (r *SQLiteExcelRepository) ListUnmatchedHeaders(ctx context.Context) ([]*excel.UnmatchedHeader, error) {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	rows, err := exec.QueryContext(ctx, `
+		SELECT `+unmatchedHeaderColumns+`
+		FROM sheet_unmatched_header h
+		JOIN sheet_version v ON v.version_id = h.version_id
+		ORDER BY v.parsed_at DESC, h.header_id DESC
+		`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unmatched headers: %w", err)
+	}
+	defer rows.Close()
+
+	var headers []*excel.UnmatchedHeader
+
+	for rows.Next() {
+		h, err := scanUnmatchedHeader(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan unmatched header row: %w", err)
+		}
+
+		headers = append(headers, h)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during unmatched headers iteration: %w", err)
+	}
+
+	return headers, nil
+}
+
+func (r *SQLiteExcelRepository) GetUnmatchedHeader(ctx context.Context, id excel.UnmatchedHeaderID) (*excel.UnmatchedHeader, error) {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	row := exec.QueryRowContext(ctx, `
+		SELECT `+unmatchedHeaderColumns+`
+		FROM sheet_unmatched_header h
+		JOIN sheet_version v ON v.version_id = h.version_id
+		WHERE h.header_id = ?
+		`, id)
+
+	h, err := scanUnmatchedHeader(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get unmatched header: %w", err)
+	}
+
+	return h, nil
+}
+
+func encodeGzipJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(v); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeGzipJSON(data []byte, v any) error {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	return json.NewDecoder(zr).Decode(v)
 }
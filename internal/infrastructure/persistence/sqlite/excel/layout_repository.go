@@ -0,0 +1,105 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	txManager "github.com/elias-gill/poliplanner2/internal/infrastructure/persistence/sqlite/tx_manager"
+	"github.com/elias-gill/poliplanner2/internal/model/excel"
+)
+
+type SQLiteLayoutRepository struct {
+	db *sql.DB
+}
+
+func NewLayoutRepository(db *sql.DB) *SQLiteLayoutRepository {
+	return &SQLiteLayoutRepository{db: db}
+}
+
+func (r *SQLiteLayoutRepository) ListLayouts(ctx context.Context, kind excel.SourceKind) ([]*excel.ParserLayout, error) {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	rows, err := exec.QueryContext(ctx, `
+		SELECT name, data, enabled, updated_at FROM parser_layout WHERE kind = ? ORDER BY name
+		`, kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query parser layouts: %w", err)
+	}
+	defer rows.Close()
+
+	var layouts []*excel.ParserLayout
+
+	for rows.Next() {
+		l := &excel.ParserLayout{Kind: kind}
+		var data sql.NullString
+		var updatedAtStr string
+
+		if err := rows.Scan(&l.Name, &data, &l.Enabled, &updatedAtStr); err != nil {
+			return nil, fmt.Errorf("failed to scan parser layout row: %w", err)
+		}
+
+		l.JSON = data.String
+		l.Uploaded = data.Valid
+
+		updatedAt, err := time.Parse("2006-01-02 15:04:05", updatedAtStr)
+		if err != nil {
+			updatedAt, err = time.Parse(time.RFC3339, updatedAtStr)
+		}
+		if err == nil {
+			l.UpdatedAt = updatedAt
+		}
+
+		layouts = append(layouts, l)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during parser layouts iteration: %w", err)
+	}
+
+	return layouts, nil
+}
+
+func (r *SQLiteLayoutRepository) SaveLayout(ctx context.Context, layout *excel.ParserLayout) error {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	_, err := exec.ExecContext(ctx, `
+		INSERT INTO parser_layout (kind, name, data, enabled, updated_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(kind, name) DO UPDATE SET
+			data = excluded.data,
+			enabled = excluded.enabled,
+			updated_at = excluded.updated_at
+		`,
+		layout.Kind,
+		layout.Name,
+		layout.JSON,
+		layout.Enabled,
+		layout.UpdatedAt.Format("2006-01-02 15:04:05"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save parser layout: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteLayoutRepository) SetLayoutEnabled(ctx context.Context, kind excel.SourceKind, name string, enabled bool) error {
+	exec := txManager.GetExecutor(ctx, r.db)
+
+	_, err := exec.ExecContext(ctx, `
+		INSERT INTO parser_layout (kind, name, enabled) VALUES (?, ?, ?)
+		ON CONFLICT(kind, name) DO UPDATE SET
+			enabled = excluded.enabled,
+			updated_at = datetime('now')
+		`,
+		kind,
+		name,
+		enabled,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set parser layout state: %w", err)
+	}
+
+	return nil
+}
@@ -229,7 +229,10 @@ func (s *SqliteScheduleStore) GetDetailsByID(ctx context.Context, ID schedule.Sc
 			dia,
 			CAST(desde AS TEXT),
 			CAST(hasta AS TEXT),
-			COALESCE(aula, '')
+			COALESCE(aula, ''),
+			modalidad,
+			COALESCE(edificio, ''),
+			COALESCE(numero_aula, '')
 		FROM curso_horarios
 		WHERE curso_id IN (%s)
 		ORDER BY curso_id ASC`, placeholders)
@@ -242,16 +245,21 @@ func (s *SqliteScheduleStore) GetDetailsByID(ctx context.Context, ID schedule.Sc
 
 	for sRows.Next() {
 		var courseID int64
-		var day int
-		var startTimeStr, endTimeStr, room string
+		var day, roomKind int
+		var startTimeStr, endTimeStr, room, building, roomNumber string
 
-		if err := sRows.Scan(&courseID, &day, &startTimeStr, &endTimeStr, &room); err != nil {
+		if err := sRows.Scan(&courseID, &day, &startTimeStr, &endTimeStr, &room, &roomKind, &building, &roomNumber); err != nil {
 			return nil, fmt.Errorf("failed to scan class schedule: %w", err)
 		}
 
 		session := academic.ClassSession{
-			Day:  academic.WeekDay(day),
-			Room: room,
+			Day: academic.WeekDay(day),
+			Room: academic.Location{
+				Raw:      room,
+				Kind:     academic.RoomKind(roomKind),
+				Building: building,
+				Number:   roomNumber,
+			},
 			Time: academic.TimeSlot{
 				Start: parseTimeOnly(startTimeStr),
 				End:   parseTimeOnly(endTimeStr),
@@ -275,7 +283,10 @@ func (s *SqliteScheduleStore) GetDetailsByID(ctx context.Context, ID schedule.Sc
 			COALESCE(CAST(hora AS TEXT), ''),
 			COALESCE(aula, ''),
 			COALESCE(CAST(revision_fecha AS TEXT), ''),
-			COALESCE(CAST(revision_hora AS TEXT), '')
+			COALESCE(CAST(revision_hora AS TEXT), ''),
+			modalidad,
+			COALESCE(edificio, ''),
+			COALESCE(numero_aula, '')
 		FROM examenes
 		WHERE curso_id IN (%s)
 		ORDER BY curso_id ASC`, placeholders)
@@ -292,6 +303,8 @@ func (s *SqliteScheduleStore) GetDetailsByID(ctx context.Context, ID schedule.Sc
 		var instance int
 		var examDateStr, examTimeStr, room string
 		var revDateStr, revTimeStr string
+		var roomKind int
+		var building, roomNumber string
 
 		if err := eRows.Scan(
 			&courseID,
@@ -302,12 +315,20 @@ func (s *SqliteScheduleStore) GetDetailsByID(ctx context.Context, ID schedule.Sc
 			&room,
 			&revDateStr,
 			&revTimeStr,
+			&roomKind,
+			&building,
+			&roomNumber,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan exam: %w", err)
 		}
 
 		exam := academic.Exam{
-			Room:     room,
+			Room: academic.Location{
+				Raw:      room,
+				Kind:     academic.RoomKind(roomKind),
+				Building: building,
+				Number:   roomNumber,
+			},
 			Type:     academic.ExamType(examTypeStr),
 			Instance: academic.ExamInstance(instance),
 		}
@@ -369,7 +390,8 @@ func parseExamDateTime(dateStr, timeStr string) *time.Time {
 		layout += " 15:04"
 	}
 
-	t, err := time.Parse(layout, fullStr)
+	// Las fechas del Excel son de hora local
+	t, err := time.ParseInLocation(layout, fullStr, timezone.ParaguayTZ)
 	if err != nil {
 		return nil
 	}
@@ -24,8 +24,9 @@ type SQLiteStorage struct {
 	TxManager repository.TxManager
 
 	// Excel Repositories
-	ExcelRepo excel.ExcelRepository
-	SyncRepo  excel.SyncRepository
+	ExcelRepo  excel.ExcelRepository
+	SyncRepo   excel.SyncRepository
+	LayoutRepo excel.LayoutRepository
 
 	// Academic Repositories
 	CareerRepo     academic.CareerRepository
@@ -34,6 +35,10 @@ type SQLiteStorage struct {
 	SubjectRepo    academic.SubjectRepository
 	TeacherRepo    academic.TeacherRepository
 	CurriculumRepo academic.CurriculumRepository
+	RoomRepo       academic.RoomRepository
+	ExaminerRepo   academic.ExaminerRepository
+	SearchRepo     academic.SearchRepository
+	CalendarRepo   academic.CalendarRepository
 
 	ScheduleRepo schedule.ScheduleRepository
 
@@ -49,8 +54,9 @@ func NewSQLiteStorage(conn *sql.DB) *SQLiteStorage {
 		TxManager: txManImpl.NewSQLTxManager(conn),
 
 		// Excel
-		ExcelRepo: excelImpl.NewExcelRepository(conn),
-		SyncRepo:  excelImpl.NewSyncRepository(conn),
+		ExcelRepo:  excelImpl.NewExcelRepository(conn),
+		SyncRepo:   excelImpl.NewSyncRepository(conn),
+		LayoutRepo: excelImpl.NewLayoutRepository(conn),
 
 		// Academic
 		CareerRepo:     academicImpl.NewCareerRepository(conn),
@@ -59,6 +65,10 @@ func NewSQLiteStorage(conn *sql.DB) *SQLiteStorage {
 		SubjectRepo:    academicImpl.NewSubjectRepository(conn),
 		TeacherRepo:    academicImpl.NewTeacherRepository(conn),
 		CurriculumRepo: academicImpl.NewCurriculumRepository(conn),
+		RoomRepo:       academicImpl.NewRoomRepository(conn),
+		ExaminerRepo:   academicImpl.NewExaminerRepository(conn),
+		SearchRepo:     academicImpl.NewSearchRepository(conn),
+		CalendarRepo:   academicImpl.NewCalendarRepository(conn),
 
 		ScheduleRepo: scheduleImpl.NewScheduleRepository(conn),
 
@@ -0,0 +1,56 @@
+// Package sqlitetest opens migrated SQLite databases for the tests of the repositories and
+// services.
+package sqlitetest
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/elias-gill/poliplanner2/internal/config"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/persistence"
+)
+
+// Open creates a database on a temporary directory of the test with all the migrations
+// applied, closed when the test ends. The test is skipped when the driver was built without
+// the sqlite_fts5 tag, since the migrations need it.
+func Open(tb testing.TB) *sql.DB {
+	tb.Helper()
+
+	dbPath := filepath.Join(tb.TempDir(), "test.db")
+
+	// Foreign keys are enabled on the DSN so every connection of the pool has them
+	conn, err := sql.Open("sqlite3", "file:"+dbPath+"?_foreign_keys=on")
+	if err != nil {
+		tb.Fatalf("cannot open database: %v", err)
+	}
+	tb.Cleanup(func() { conn.Close() })
+
+	if err := persistence.CheckFTS5(conn); err != nil {
+		if errors.Is(err, persistence.ErrNoFTS5) {
+			tb.Skip("the database needs the sqlite_fts5 build tag")
+		}
+		tb.Fatal(err)
+	}
+
+	m, err := migrate.New(
+		"file://"+config.Get().Database.MigrationsDir,
+		"sqlite3://file:"+dbPath+"?cache=shared&mode=rwc",
+	)
+	if err != nil {
+		tb.Fatalf("cannot create migrations: %v", err)
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil {
+		tb.Fatalf("cannot run migrations: %v", err)
+	}
+
+	return conn
+}
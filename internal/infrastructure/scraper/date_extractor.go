@@ -2,6 +2,7 @@ package scraper
 
 import (
 	"fmt"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -12,8 +13,8 @@ import (
 )
 
 func extractDateFromFilename(filename string) (time.Time, error) {
-	// Remove .xlsx extension if present
-	nameWithoutExt := strings.TrimSuffix(filename, ".xlsx")
+	// Remove the extension if present
+	nameWithoutExt := strings.TrimSuffix(filename, filepath.Ext(filename))
 
 	// list of common date patterns in filenames
 	patterns := []*regexp.Regexp{
@@ -7,7 +7,9 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"path"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -17,8 +19,7 @@ import (
 const (
 	driveFilesAPI = "https://www.googleapis.com/drive/v3/files"
 
-	excelExtension = ".xlsx"
-
+	// Google Sheets are exported as xlsx, which keeps every sheet on a single file
 	spreadsheetExportURL = "https://docs.google.com/spreadsheets/d/%s/export?format=xlsx"
 	driveDownloadURL     = "https://drive.google.com/uc?export=download&id=%s"
 )
@@ -89,7 +90,7 @@ func (g *GoogleDriveHelper) ListSourcesInURL(ctx context.Context, url string) ([
 			return nil, err
 		}
 
-		if !g.isExcelFile(file.Name) {
+		if !isSourceFile(file.Name) {
 			continue
 		}
 
@@ -228,9 +229,9 @@ func (g *GoogleDriveHelper) extractSpreadsheetID(url string) string {
 	return ""
 }
 
-func (g *GoogleDriveHelper) isExcelFile(name string) bool {
-	return strings.HasSuffix(
-		strings.ToLower(name),
-		excelExtension,
-	)
+// Extensions of the files the parser reads, a .zip holds a .csv file for each career
+var sourceExtensions = []string{".xlsx", ".ods", ".csv", ".zip"}
+
+func isSourceFile(name string) bool {
+	return slices.Contains(sourceExtensions, strings.ToLower(path.Ext(name)))
 }
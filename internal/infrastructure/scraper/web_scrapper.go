@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/gocolly/colly/v2"
 
 	log "github.com/elias-gill/poliplanner2/logger"
@@ -26,11 +27,12 @@ var (
 )
 
 var (
-	// Permite espacios y cualquier caracter entre la palabra clave y la extension .xlsx
+	// Permite espacios y cualquier caracter entre la palabra clave y la extension. Los
+	// formatos son los que lee el parser: .xlsx, .ods, .csv o un .zip de archivos .csv
 	schedulePattern = regexp.MustCompile(
-		`(?i).*(horario|clases|examen(?:es)?|exame|exam).*\.xlsx$`)
+		`(?i).*(horario|clases|examen(?:es)?|exame|exam).*\.(?:xlsx|ods|csv|zip)$`)
 	laboratoryPattern = regexp.MustCompile(
-		`(?i).*(laboratorio(?:s)?|lab|asignacior|asignacion).*\.xlsx$`)
+		`(?i).*(laboratorio(?:s)?|lab|asignacior|asignacion).*\.(?:xlsx|ods|csv|zip)$`)
 
 	googleDriveFolderPattern = regexp.MustCompile(
 		`^https://drive\.google\.com/(?:drive/(?:u/\d+/)?folders|folders)/[\w-]+`)
@@ -156,30 +158,32 @@ func (ws *WebScrapper) discover(ctx context.Context, targetURL string, pattern *
 	return sources, nil
 }
 
+// FindSourcesFromHTML finds the schedule sources linked by a page already downloaded, like
+// DiscoverSchedules does with the faculty page.
+func (ws *WebScrapper) FindSourcesFromHTML(ctx context.Context, htmlContent string) ([]*webSource, error) {
+	return ws.extractFromHTML(ctx, htmlContent, schedulePattern)
+}
+
+// extractFromHTML finds the sources linked by the page, relative links are resolved against
+// the faculty page.
 func (ws *WebScrapper) extractFromHTML(ctx context.Context, htmlContent string, pattern *regexp.Regexp) ([]*webSource, error) {
 	sources := make([]*webSource, 0, 4)
 
-	c := colly.NewCollector(
-		colly.MaxDepth(1),
-		colly.IgnoreRobotsTxt(),
-	)
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil, err
+	}
 
-	c.OnHTML("a[href]", func(e *colly.HTMLElement) {
-		select {
-		case <-ctx.Done():
-			return
-		default:
+	doc.Find("a[href]").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		if ctx.Err() != nil {
+			return false
 		}
 
-		href := e.Attr("href")
-		absolute := ws.makeAbsoluteURL(href)
-		ws.processURL(ctx, absolute, pattern, &sources)
+		href, _ := s.Attr("href")
+		ws.processURL(ctx, ws.makeAbsoluteURL(href), pattern, &sources)
+		return true
 	})
 
-	if err := c.PostRaw(ws.targetURL, []byte(htmlContent)); err != nil {
-		return nil, err
-	}
-
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
@@ -71,3 +72,43 @@ func TestFindLatestExcelUrlWithDriveFolders(t *testing.T) {
 		t.Errorf("url mismatch\nwant: %s\ngot:  %s", expectedDriveURL, src[0].URL)
 	}
 }
+
+func TestFindSourcesFromHTMLExtensions(t *testing.T) {
+	const base = "https://www.pol.una.py/wp-content/uploads/"
+	html := `<html><body>
+		<a href="` + base + `Horario-de-clases-Primer-Academico-2026-10022026.xlsx">xlsx</a>
+		<a href="` + base + `Horario-de-clases-Primer-Academico-2026-11022026.ods">ods</a>
+		<a href="` + base + `Horario-de-clases-Primer-Academico-2026-12022026.csv">csv</a>
+		<a href="` + base + `Horario-de-clases-Primer-Academico-2026-13022026.zip">zip</a>
+		<a href="` + base + `Horario-de-clases-Primer-Academico-2026-14022026.pdf">pdf</a>
+	</body></html>`
+
+	src, err := NewWebScraper(nil).FindSourcesFromHTML(context.Background(), html)
+	if err != nil {
+		t.Fatalf("find source: %+v", err)
+	}
+
+	got := make([]string, 0, len(src))
+	for _, s := range src {
+		got = append(got, filepath.Ext(s.Name))
+	}
+	want := []string{".xlsx", ".ods", ".csv", ".zip"}
+	if !slices.Equal(got, want) {
+		t.Errorf("extensions mismatch\nwant: %v\ngot:  %v", want, got)
+	}
+}
+
+func TestIsSourceFile(t *testing.T) {
+	for name, want := range map[string]bool{
+		"Horario.xlsx": true,
+		"Horario.ODS":  true,
+		"Horario.csv":  true,
+		"Horario.zip":  true,
+		"Horario.pdf":  false,
+		"Horario":      false,
+	} {
+		if got := isSourceFile(name); got != want {
+			t.Errorf("isSourceFile(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
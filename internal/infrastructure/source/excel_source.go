@@ -18,6 +18,9 @@ type SourceMetadata struct {
 	//
 	//	- First Semester:  January to July
 	//	- Second Semester: August to December
+	//
+	// It is only a guess. When an admin configured the period that contains Date, that
+	// period is used instead.
 	Semester academic.YearSemester
 
 	// Date identifies the version of the source data.
@@ -0,0 +1,56 @@
+package academic
+
+import "time"
+
+// ==========================
+//   Academic calendar
+// ==========================
+
+type DayOffID int64
+
+type DayOffKind int8
+
+const (
+	DayOffHoliday DayOffKind = 0 // National holidays
+	DayOffRecess  DayOffKind = 1 // University recess weeks
+	DayOffOther   DayOffKind = 2 // Any other day without classes (eg: university anniversary)
+)
+
+// String returns the kind name in spanish.
+func (k DayOffKind) String() string {
+	switch k {
+	case DayOffRecess:
+		return "Receso"
+	case DayOffOther:
+		return "Sin clases"
+	default:
+		return "Feriado"
+	}
+}
+
+// DayOff is a holiday or a range of days without classes.
+type DayOff struct {
+	ID          DayOffID
+	Dates       DateRange
+	Description string
+	Kind        DayOffKind
+}
+
+// DayOffAt returns the first day off that contains the day of t, or nil when there are
+// classes.
+func DayOffAt(days []DayOff, t time.Time) *DayOff {
+	for i := range days {
+		if days[i].Dates.Contains(t) {
+			return &days[i]
+		}
+	}
+	return nil
+}
+
+// WeekOf returns the week that contains the day of t, from Monday to Sunday.
+func WeekOf(t time.Time) DateRange {
+	monday := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	monday = monday.AddDate(0, 0, -((int(monday.Weekday()) + 6) % 7))
+
+	return DateRange{Start: monday, End: monday.AddDate(0, 0, 6)}
+}
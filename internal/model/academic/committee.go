@@ -0,0 +1,117 @@
+package academic
+
+import (
+	"strings"
+	"time"
+)
+
+// CommitteeRole is the position of a person inside an exam committee.
+type CommitteeRole int
+
+const (
+	CommitteePresident CommitteeRole = iota
+	CommitteeMember
+)
+
+func (r CommitteeRole) String() string {
+	if r == CommitteePresident {
+		return "Presidente"
+	}
+	return "Miembro"
+}
+
+// CommitteeSeat is a single position of a course committee, as written on the sheets.
+type CommitteeSeat struct {
+	Name string
+	Role CommitteeRole
+}
+
+// Seats returns the filled positions of the committee, skipping empty ones and placeholders
+// like "A CONFIRMAR".
+func (c Committee) Seats() []CommitteeSeat {
+	var seats []CommitteeSeat
+
+	add := func(name string, role CommitteeRole) {
+		name = strings.TrimSpace(name)
+		if CommitteeMemberKey(name) == "" {
+			return
+		}
+		seats = append(seats, CommitteeSeat{Name: name, Role: role})
+	}
+
+	add(c.President, CommitteePresident)
+	add(c.Member1, CommitteeMember)
+	add(c.Member2, CommitteeMember)
+
+	return seats
+}
+
+// Academic titles written without the trailing dot. Titles with a dot (eg: "Ing.", "C.P.")
+// are always removed.
+var bareTitles = map[string]struct{}{
+	"abog": {}, "arq": {}, "dr": {}, "dra": {}, "econ": {}, "ing": {},
+	"lic": {}, "mag": {}, "mg": {}, "ms": {}, "msc": {}, "prof": {},
+}
+
+// CommitteeMemberKey identifies an examiner across courses. The sheets write the
+// name with its title (eg: "Ing. Juan Pérez"), so the key is the normalized name without
+// the leading titles. Returns an empty string for placeholders.
+func CommitteeMemberKey(raw string) string {
+	words := strings.Fields(NormalizeName(raw))
+
+	for len(words) > 0 {
+		word := words[0]
+		if _, ok := bareTitles[word]; !ok && !strings.HasSuffix(word, ".") {
+			break
+		}
+		words = words[1:]
+	}
+
+	key := strings.Join(words, " ")
+	if _, ok := placeholderTeacherNames[key]; ok {
+		return ""
+	}
+
+	return key
+}
+
+// ==========================
+// 	 Committee lookup views
+// ==========================
+
+type ExaminerID int64
+
+// Examiner is a person that sits on at least one exam committee. Committee members are free
+// text on the sheets, so examiners are linked to the teacher with the same name when there
+// is one.
+type Examiner struct {
+	ID        ExaminerID
+	Name      string
+	TeacherID *TeacherID
+}
+
+// ExaminerSummaryView is an entry of the examiners search.
+type ExaminerSummaryView struct {
+	Examiner Examiner
+	Courses  int // Committees on the current period
+}
+
+// ExaminerExamView is a final exam where an examiner sits on the committee.
+type ExaminerExamView struct {
+	CourseID   CourseID
+	CourseName string
+	Section    string
+	Role       CommitteeRole
+	Instance   ExamInstance
+	Date       *time.Time
+	Revision   *time.Time
+	Room       Location
+}
+
+// ExaminerDetailView lists every final exam of the current period where the examiner sits on
+// the committee.
+type ExaminerDetailView struct {
+	Examiner Examiner
+	Teacher  *Teacher // Nil when the examiner is not linked to a teacher
+	Exams    []ExaminerExamView
+}
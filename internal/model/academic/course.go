@@ -75,6 +75,34 @@ func (d WeekDay) String() string {
 	}
 }
 
+// ----------- Rooms ----------------
+
+// RoomKind classifies where a class session physically happens.
+type RoomKind int
+
+const (
+	// RoomUnknown is used when the sheet has no room or an unusable placeholder
+	// (eg: "A CONFIRMAR").
+	RoomUnknown RoomKind = 0
+
+	// RoomPhysical is a classroom inside the campus.
+	RoomPhysical RoomKind = 1
+
+	// RoomVirtual marks sessions imparted online ("virtual", "plataforma", "Teams", ...).
+	RoomVirtual RoomKind = 2
+)
+
+func (k RoomKind) String() string {
+	switch k {
+	case RoomPhysical:
+		return "Presencial"
+	case RoomVirtual:
+		return "Virtual"
+	default:
+		return "Sin asignar"
+	}
+}
+
 // ----------- Exams ----------------
 
 type ExamID int64
@@ -131,10 +159,54 @@ type Committee struct {
 
 type ClassSession struct {
 	Day  WeekDay
-	Room string
+	Room Location
 	Time TimeSlot
 }
 
+// Location is the structured version of the room text found on the sheets.
+//
+// Raw always keeps the original text so nothing is lost when the classification is not
+// accurate. Building and Number are only filled for physical rooms whose code could be
+// split (eg: "A-12" -> Building "A", Number "12").
+type Location struct {
+	Raw      string
+	Kind     RoomKind
+	Building string
+	Number   string
+}
+
+func (l Location) IsVirtual() bool {
+	return l.Kind == RoomVirtual
+}
+
+func (l Location) IsPhysical() bool {
+	return l.Kind == RoomPhysical
+}
+
+// Code returns a normalized room identifier, usable for comparing rooms between courses.
+// Returns an empty string for non physical locations.
+func (l Location) Code() string {
+	if l.Kind != RoomPhysical {
+		return ""
+	}
+	if l.Building != "" && l.Number != "" {
+		return l.Building + "-" + l.Number
+	}
+	return strings.ToUpper(strings.Join(strings.Fields(l.Raw), " "))
+}
+
+func (l Location) String() string {
+	switch l.Kind {
+	case RoomVirtual:
+		return "Virtual"
+	case RoomUnknown:
+		if l.Raw == "" {
+			return "Sin asignar"
+		}
+	}
+	return l.Raw
+}
+
 type TimeSlot struct {
 	Start *time.Time
 	End   *time.Time
@@ -158,15 +230,19 @@ func (t TimeSlot) String() string {
 
 // ------------- Exams --------------------
 
+// The sheets only publish the start hour of each exam, so we assume every exam lasts this
+// amount of time, eg: to know when its room is free again or for the end of calendar events.
+const ExamDuration = 2 * time.Hour
+
 type Exam struct {
 	date     *time.Time
 	revision *time.Time
-	Room     string
+	Room     Location
 	Type     ExamType
 	Instance ExamInstance
 }
 
-func NewExam(date *time.Time, revDate *time.Time, room string, examType ExamType, instance ExamInstance) Exam {
+func NewExam(date *time.Time, revDate *time.Time, room Location, examType ExamType, instance ExamInstance) Exam {
 	return Exam{
 		date:     date,
 		revision: revDate,
@@ -251,7 +327,7 @@ func (c Course) String() string {
 			end = s.Time.End.String()
 		}
 
-		fmt.Fprintf(&sb, "day=%v,start=%s,end=%s,room=%s", s.Day, start, end, s.Room)
+		fmt.Fprintf(&sb, "day=%v,start=%s,end=%s,room=%s", s.Day, start, end, s.Room.Raw)
 	}
 
 	sb.WriteString("] ")
@@ -0,0 +1,84 @@
+package academic
+
+import (
+	"fmt"
+	"time"
+)
+
+// ==========================
+//   Career exam calendar
+// ==========================
+
+// CareerExamView is a partial or final exam of a course offered to a career. The same course
+// can be part of several plans of the career, so plans are grouped together.
+type CareerExamView struct {
+	CourseID   CourseID
+	CourseName string
+	Section    string
+	Shift      string
+	Semester   int
+	Plans      []string
+	Emphases   []string // Emphasis codes, empty for the common subjects
+	Type       ExamType
+	Instance   ExamInstance
+	Date       *time.Time
+	Revision   *time.Time
+	Room       Location
+}
+
+// Label returns the exam name as students know it (eg: "2° Parcial").
+func (e CareerExamView) Label() string {
+	return ExamLabel(e.Type, e.Instance)
+}
+
+// ExamLabel returns the name students use for an exam (eg: "2° Parcial").
+func ExamLabel(t ExamType, instance ExamInstance) string {
+	if t == ExamFinal {
+		return fmt.Sprintf("%d° Final", instance)
+	}
+	return fmt.Sprintf("%d° Parcial", instance)
+}
+
+// CareerExamFilter narrows the exam calendar of a career. Zero values mean no filter.
+type CareerExamFilter struct {
+	Semester int
+	Plan     string
+	Emphasis string
+}
+
+// ExamDayView is a cell of the month grid.
+type ExamDayView struct {
+	Date    time.Time
+	InMonth bool     // False for the padding days of the previous and next months
+	Window  ExamType // Exam window of the period that contains the day, empty when none
+	Exams   []CareerExamView
+}
+
+// ExamMonthView is a month of the exam calendar, split into weeks starting on Monday.
+type ExamMonthView struct {
+	Month time.Time
+	Weeks [][]ExamDayView
+}
+
+var monthNames = [...]string{
+	"Enero", "Febrero", "Marzo", "Abril", "Mayo", "Junio",
+	"Julio", "Agosto", "Septiembre", "Octubre", "Noviembre", "Diciembre",
+}
+
+// Name returns the month name in spanish along with the year (eg: "Mayo 2026").
+func (m ExamMonthView) Name() string {
+	return fmt.Sprintf("%s %d", monthNames[m.Month.Month()-1], m.Month.Year())
+}
+
+// CareerExamCalendarView holds every exam of a career for the current period, along with the
+// options of the filters.
+type CareerExamCalendarView struct {
+	Career    Career
+	Period    Period
+	Filter    CareerExamFilter
+	Plans     []Plan
+	Semesters []int
+	Emphases  []Emphasis
+	Exams     []CareerExamView
+	Months    []ExamMonthView
+}
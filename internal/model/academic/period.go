@@ -1,5 +1,11 @@
 package academic
 
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
 type YearSemester int8
 
 const (
@@ -10,6 +16,77 @@ const (
 type PeriodID int64
 
 type Period struct {
+	ID       PeriodID
 	Year     int
 	Semester YearSemester
+
+	// Boundaries loaded by an admin. Zero ranges mean they were not configured yet.
+	Span     DateRange // From the first class to the last final exam
+	Classes  DateRange
+	Partials DateRange
+	Finals   DateRange
+}
+
+// String returns the period as students name it (eg: "1er semestre 2025").
+func (p Period) String() string {
+	if p.Semester == SecondSemester {
+		return fmt.Sprintf("2do semestre %d", p.Year)
+	}
+	return fmt.Sprintf("1er semestre %d", p.Year)
+}
+
+// Configured reports whether an admin loaded the dates of the period.
+func (p Period) Configured() bool {
+	return !p.Span.IsZero()
+}
+
+// ClassWeeks returns the number of weeks of classes, or 0 when they are not configured.
+func (p Period) ClassWeeks() int {
+	if p.Classes.IsZero() {
+		return 0
+	}
+	return (p.Classes.Days() + 6) / 7
+}
+
+// DateRange is an inclusive range of days. Start and End are midnights.
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (r DateRange) IsZero() bool {
+	return r.Start.IsZero() && r.End.IsZero()
+}
+
+// Contains reports whether the day of t is inside the range.
+func (r DateRange) Contains(t time.Time) bool {
+	if r.IsZero() {
+		return false
+	}
+
+	t = t.In(r.Start.Location())
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, r.Start.Location())
+
+	return !day.Before(r.Start) && !day.After(r.End)
+}
+
+// Includes reports whether other is fully inside the range.
+func (r DateRange) Includes(other DateRange) bool {
+	return !other.Start.Before(r.Start) && !other.End.After(r.End)
+}
+
+// Overlaps reports whether both ranges share at least one day.
+func (r DateRange) Overlaps(other DateRange) bool {
+	if r.IsZero() || other.IsZero() {
+		return false
+	}
+	return !r.Start.After(other.End) && !other.Start.After(r.End)
+}
+
+// Days returns the number of days of the range, both ends included.
+func (r DateRange) Days() int {
+	if r.IsZero() {
+		return 0
+	}
+	return int(math.Round(r.End.Sub(r.Start).Hours()/24)) + 1
 }
@@ -61,8 +61,62 @@ func (c CourseSummaryView) FormattedSchedule() string {
 			endStr = "--:--"
 		}
 
-		parts = append(parts, fmt.Sprintf("%s %s-%s", dayStr, startStr, endStr))
+		entry := fmt.Sprintf("%s %s-%s", dayStr, startStr, endStr)
+		if s.Room.IsVirtual() {
+			entry += " (virtual)"
+		}
+
+		parts = append(parts, entry)
 	}
 
 	return strings.Join(parts, " | ")
 }
+
+// CourseModality summarizes how the sessions of a course are imparted.
+type CourseModality int
+
+const (
+	ModalityUnknown CourseModality = iota
+	ModalityOnSite
+	ModalityVirtual
+	ModalityHybrid
+)
+
+func (m CourseModality) String() string {
+	switch m {
+	case ModalityOnSite:
+		return "Presencial"
+	case ModalityVirtual:
+		return "Virtual"
+	case ModalityHybrid:
+		return "Híbrida"
+	default:
+		return "Sin definir"
+	}
+}
+
+// Modality directly used inside HTML templates with "{{ .Modality }}".
+// Sessions with an unknown room are ignored, so a course with one virtual session and
+// one unassigned room is still considered virtual.
+func (c CourseSummaryView) Modality() CourseModality {
+	var physical, virtual bool
+	for _, s := range c.Schedules {
+		switch s.Room.Kind {
+		case RoomPhysical:
+			physical = true
+		case RoomVirtual:
+			virtual = true
+		}
+	}
+
+	switch {
+	case physical && virtual:
+		return ModalityHybrid
+	case virtual:
+		return ModalityVirtual
+	case physical:
+		return ModalityOnSite
+	default:
+		return ModalityUnknown
+	}
+}
@@ -0,0 +1,57 @@
+package academic
+
+import "time"
+
+// ==========================
+// 	 Rooms directory views
+// ==========================
+
+// RoomSessionView is a class session held on a physical room.
+type RoomSessionView struct {
+	Room       Location
+	CourseID   CourseID
+	CourseName string
+	Section    string
+	Day        WeekDay
+	Time       TimeSlot
+}
+
+// RoomExamView is an exam held on a physical room.
+type RoomExamView struct {
+	Room       Location
+	CourseID   CourseID
+	CourseName string
+	Section    string
+	Type       ExamType
+	Instance   ExamInstance
+	Date       *time.Time
+}
+
+// RoomSummaryView is an entry of the rooms index.
+type RoomSummaryView struct {
+	Code     string // Normalized code, see Location.Code
+	Name     string // Text as it appears on the sheets
+	Building string
+	Sessions int
+	Exams    int
+}
+
+// FreeRoomView is a room without classes nor exams at the requested time.
+type FreeRoomView struct {
+	Room RoomSummaryView
+	// Start of the next class or exam that same day. Nil if the room is free for the rest of
+	// the day.
+	Until *time.Time
+}
+
+type RoomDayView struct {
+	Day      WeekDay
+	Sessions []RoomSessionView
+}
+
+// RoomDetailView is the weekly occupancy of a room plus the exams held there.
+type RoomDetailView struct {
+	Room   RoomSummaryView
+	Weekly []RoomDayView // Monday to Saturday
+	Exams  []RoomExamView
+}
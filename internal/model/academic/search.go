@@ -0,0 +1,63 @@
+package academic
+
+import "net/url"
+
+// ==========================
+//      Global search
+// ==========================
+
+type SearchKind string
+
+const (
+	SearchCourse  SearchKind = "course"
+	SearchSubject SearchKind = "subject"
+	SearchTeacher SearchKind = "teacher"
+	SearchRoom    SearchKind = "room"
+)
+
+func (k SearchKind) String() string {
+	switch k {
+	case SearchCourse:
+		return "Cursos"
+	case SearchSubject:
+		return "Asignaturas"
+	case SearchTeacher:
+		return "Docentes"
+	case SearchRoom:
+		return "Aulas"
+	default:
+		return string(k)
+	}
+}
+
+// SearchResult is an entry of the search index. Ref identifies the entity within its kind:
+// the ID for courses, subjects and teachers, and the room code for rooms.
+type SearchResult struct {
+	Kind   SearchKind
+	Ref    string
+	Title  string
+	Detail string
+}
+
+// URL returns the page of the result, or an empty string when the entity has no page.
+func (r SearchResult) URL() string {
+	switch r.Kind {
+	case SearchTeacher:
+		return "/teachers/" + r.Ref
+	case SearchRoom:
+		return "/rooms/" + url.PathEscape(r.Ref)
+	default:
+		return ""
+	}
+}
+
+type SearchGroupView struct {
+	Kind    SearchKind
+	Results []SearchResult
+	More    bool // There are more results than the listed ones
+}
+
+type SearchResultsView struct {
+	Query  string
+	Groups []SearchGroupView
+}
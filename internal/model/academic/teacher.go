@@ -1,5 +1,10 @@
 package academic
 
+import (
+	"strings"
+	"unicode"
+)
+
 type TeacherID int64
 
 type Teacher struct {
@@ -9,3 +14,145 @@ type Teacher struct {
 	LastName  string
 	Email     string
 }
+
+// Names used on the sheets when the teacher is not yet assigned. Compared after
+// NormalizeName.
+var placeholderTeacherNames = map[string]struct{}{
+	"a confirmar":   {},
+	"a definir":     {},
+	"a designar":    {},
+	"por confirmar": {},
+	"por definir":   {},
+	"sin docente":   {},
+}
+
+func (t Teacher) FullName() string {
+	return strings.TrimSpace(t.FirstName + " " + t.LastName)
+}
+
+// IsPlaceholder reports if this teacher is just a placeholder like "A CONFIRMAR" instead of
+// a real person.
+func (t Teacher) IsPlaceholder() bool {
+	name := NormalizeName(t.FullName())
+	if name == "" {
+		return true
+	}
+	_, ok := placeholderTeacherNames[name]
+	return ok
+}
+
+// NormalizeName lowercases a person name, removes accents and collapses whitespaces, so
+// names written by hand on different sheets can be compared.
+func NormalizeName(raw string) string {
+	var sb strings.Builder
+	lastWasSpace := true
+
+	for _, r := range raw {
+		r = removeAccent(unicode.ToLower(r))
+
+		if unicode.IsSpace(r) {
+			if !lastWasSpace {
+				sb.WriteRune(' ')
+				lastWasSpace = true
+			}
+			continue
+		}
+
+		sb.WriteRune(r)
+		lastWasSpace = false
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+func removeAccent(c rune) rune {
+	switch c {
+	case 'á', 'à', 'ä':
+		return 'a'
+	case 'é', 'è', 'ë':
+		return 'e'
+	case 'í', 'ì', 'ï':
+		return 'i'
+	case 'ó', 'ò', 'ö':
+		return 'o'
+	case 'ú', 'ù', 'ü':
+		return 'u'
+	case 'ñ':
+		return 'n'
+	default:
+		return c
+	}
+}
+
+// ==========================
+// 	 Teacher directory views
+// ==========================
+
+// TeacherSummaryView is an entry of the teachers directory.
+type TeacherSummaryView struct {
+	Teacher Teacher
+	Courses int // Distinct courses taught, on the listed period or overall
+}
+
+// TeacherCourseView is a course section taught by a teacher, along with its weekly sessions.
+type TeacherCourseView struct {
+	CourseID  CourseID
+	Name      string
+	Section   string
+	Shift     string
+	Schedules []ClassSession
+}
+
+// TeacherCommitteeView is an exam committee where a teacher sits on.
+type TeacherCommitteeView struct {
+	CourseID   CourseID
+	CourseName string
+	Section    string
+	Role       CommitteeRole
+}
+
+// TeacherDetailView is the public profile of a teacher.
+type TeacherDetailView struct {
+	Teacher    Teacher
+	Courses    []TeacherCourseView
+	Committees []TeacherCommitteeView
+}
+
+// ==========================
+// 	 Teacher deduplication
+// ==========================
+
+// MergeReason explains why two teacher records are believed to be the same person.
+type MergeReason int
+
+const (
+	MergeSameEmail MergeReason = iota
+	MergeSameName
+	MergeSameNameAndTitle
+)
+
+func (r MergeReason) String() string {
+	switch r {
+	case MergeSameEmail:
+		return "Mismo correo"
+	case MergeSameName:
+		return "Mismo nombre"
+	case MergeSameNameAndTitle:
+		return "Mismo nombre y título, distinto correo"
+	default:
+		return ""
+	}
+}
+
+// TeacherMergeProposal groups teacher records that look like the same person. Survivor is
+// the record that keeps the course assignments once the merge is accepted.
+type TeacherMergeProposal struct {
+	Survivor   TeacherSummaryView
+	Duplicates []TeacherSummaryView
+	Reasons    []MergeReason
+}
+
+// Members returns every teacher of the proposal, starting with the survivor.
+func (p TeacherMergeProposal) Members() []TeacherSummaryView {
+	return append([]TeacherSummaryView{p.Survivor}, p.Duplicates...)
+}
@@ -0,0 +1,12 @@
+package excel
+
+// ==========================
+//   Dry run reports
+// ==========================
+
+// DryRunReport is the result of parsing and mapping a source without persisting it, so
+// admins can check a file before it goes live.
+type DryRunReport struct {
+	Source string
+	ImportReport
+}
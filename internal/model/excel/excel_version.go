@@ -8,9 +8,26 @@ import (
 
 type SheetVersionID int64
 
+// SourceKind tells apart the workbooks imported into the version history.
+type SourceKind int8
+
+const (
+	SourceSchedules SourceKind = 0 // Class and exam schedules of every career
+	SourceLabs      SourceKind = 1 // Laboratory groups
+)
+
+// String returns the kind name in spanish.
+func (k SourceKind) String() string {
+	if k == SourceLabs {
+		return "Laboratorios"
+	}
+	return "Horarios"
+}
+
 type SheetVersion struct {
 	ID       SheetVersionID
 	PeriodID academic.PeriodID
+	Kind     SourceKind
 
 	Name         string
 	URL          string
@@ -19,4 +36,24 @@ type SheetVersion struct {
 
 	Succeeded bool
 	Error     string
+
+	Hash       string // SHA-256 of the imported file, empty for rollbacks
+	HasArchive bool   // The file is still on the archive and can be parsed again
+
+	HasSnapshot  bool           // Only versions with a snapshot can be compared or restored
+	HasReport    bool           // Imports saved before the reports existed and rollbacks have none
+	RestoredFrom SheetVersionID // Set when the version is a rollback to an earlier one
+}
+
+// RollbackImpact is what a rollback removes besides restoring the courses of the version.
+type RollbackImpact struct {
+	RemovedCourses    int64 // Courses added after the version, laboratories are kept
+	AffectedSchedules int64 // Schedules of students that hold at least one removed course
+}
+
+// ArchivedSource is a workbook kept on the source archive.
+type ArchivedSource struct {
+	Hash       string
+	Size       int64
+	ArchivedAt time.Time
 }
@@ -0,0 +1,102 @@
+package excel
+
+import "time"
+
+// ==========================
+//   Import reports
+// ==========================
+
+// ImportReport describes how every sheet of a source was parsed and mapped. It is saved for
+// each import attempt, including the failed ones.
+type ImportReport struct {
+	Sheets        []SheetReport `json:"sheets"`
+	IgnoredSheets []string      `json:"ignored_sheets,omitempty"` // Sheets of the workbook that are not career schedules
+	Duration      time.Duration `json:"duration"`
+}
+
+// Valid reports whether every sheet could be imported. The real import aborts on the first
+// sheet with an error.
+func (r ImportReport) Valid() bool {
+	for _, s := range r.Sheets {
+		if s.Error != "" {
+			return false
+		}
+	}
+	return len(r.Sheets) > 0
+}
+
+// Courses returns the number of courses of the whole source.
+func (r ImportReport) Courses() int {
+	total := 0
+	for _, s := range r.Sheets {
+		total += s.Courses
+	}
+	return total
+}
+
+// Warnings returns the number of warnings of the whole source.
+func (r ImportReport) Warnings() int {
+	total := 0
+	for _, s := range r.Sheets {
+		total += len(s.Warnings)
+	}
+	return total
+}
+
+// Elapsed returns the duration rounded to milliseconds (eg: "2.345s").
+func (r ImportReport) Elapsed() string {
+	return r.Duration.Round(time.Millisecond).String()
+}
+
+// SheetReport summarizes a career sheet, or a sheet of a lab workbook.
+type SheetReport struct {
+	Career   string        `json:"career"`           // Career code, or the sheet name on the lab sources
+	Layout   string        `json:"layout,omitempty"` // File name of the matched layout, empty when none did
+	Error    string        `json:"error,omitempty"`
+	Rows     int           `json:"rows"`
+	Subjects int           `json:"subjects"` // Distinct subjects
+	Courses  int           `json:"courses"`  // Distinct courses, rows repeated for other plans count once
+	Duration time.Duration `json:"duration"` // Parsing and persistence of the sheet
+
+	Skipped    []SkippedRow       `json:"skipped,omitempty"`
+	Incomplete []IncompleteCourse `json:"incomplete,omitempty"`
+	Warnings   []ImportWarning    `json:"warnings,omitempty"`
+}
+
+// Elapsed returns the duration rounded to milliseconds (eg: "123ms").
+func (s SheetReport) Elapsed() string {
+	return s.Duration.Round(time.Millisecond).String()
+}
+
+// SkippedRow is a row that the import ignores.
+type SkippedRow struct {
+	Row    int    `json:"row"`
+	Reason string `json:"reason"`
+}
+
+// IncompleteCourse is a course that is imported, but is missing data students need.
+type IncompleteCourse struct {
+	Row     int      `json:"row"`
+	Course  string   `json:"course"`
+	Section string   `json:"section"`
+	Missing []string `json:"missing"` // "horario", "aula" or "docentes"
+}
+
+type WarningKind string
+
+const (
+	WarningDate     WarningKind = "fecha"      // A date cell that could not be parsed
+	WarningTeachers WarningKind = "docentes"   // Teacher columns with a different number of lines
+	WarningEmphasis WarningKind = "énfasis"    // Emphasis code missing on the career metadata
+	WarningMetadata WarningKind = "metadatos"  // Career, subject or department missing on the metadata
+	WarningHeader   WarningKind = "encabezado" // Header column matched to the layout with doubts
+	WarningSubject  WarningKind = "asignatura" // Row without a subject name
+)
+
+// ImportWarning is data that was imported, but may be wrong or incomplete. Row is 0 for the
+// warnings about the whole sheet.
+type ImportWarning struct {
+	Row     int         `json:"row,omitempty"`
+	Kind    WarningKind `json:"kind"`
+	Message string      `json:"message"`
+}
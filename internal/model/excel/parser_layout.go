@@ -0,0 +1,20 @@
+package excel
+
+import "time"
+
+// ParserLayout is a layout of the parser for the sheets of a kind, either a layout file of the
+// repository or one uploaded from the administration.
+type ParserLayout struct {
+	Kind SourceKind
+	Name string // File name, eg: "general.json"
+
+	// Layout in the format of the layout files, and the headers of its columns in order
+	JSON    string
+	Headers []string
+
+	Enabled  bool
+	Default  bool // There is a layout file with the name
+	Uploaded bool // Uploaded from the administration, replacing the layout file when Default
+
+	UpdatedAt time.Time // Date of the upload or of the last change of state, zero for the files
+}
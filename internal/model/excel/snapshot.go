@@ -0,0 +1,141 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ==========================
+//   Version snapshots
+// ==========================
+
+// Snapshot is the normalized academic data imported by a sheet version. It keeps plain
+// values instead of database IDs, so it stays valid after later imports change the tables.
+type Snapshot struct {
+	Careers []CareerSnapshot `json:"careers"`
+}
+
+// CareerSnapshot holds the courses of a sheet, in the same order as the rows.
+type CareerSnapshot struct {
+	Code    string           `json:"code"`
+	Courses []CourseSnapshot `json:"courses"`
+}
+
+type CourseSnapshot struct {
+	// Subject and curriculum
+	Subject    string   `json:"subject"`
+	Department string   `json:"department,omitempty"`
+	Plan       string   `json:"plan,omitempty"`
+	Level      int      `json:"level,omitempty"`
+	Semester   int      `json:"semester,omitempty"`
+	Emphases   []string `json:"emphases,omitempty"`
+
+	// Course
+	Name          string    `json:"name"`
+	Type          int       `json:"type,omitempty"`
+	Section       string    `json:"section"`
+	Shift         string    `json:"shift,omitempty"`
+	SaturdayDates string    `json:"saturday_dates,omitempty"`
+	Committee     [3]string `json:"committee"`
+
+	Sessions []SessionSnapshot `json:"sessions,omitempty"`
+	Exams    []ExamSnapshot    `json:"exams,omitempty"`
+	Teachers []TeacherSnapshot `json:"teachers,omitempty"`
+}
+
+// Key identifies the course inside its career. The same course is repeated on the sheet for
+// every plan and emphasis that includes it.
+func (c CourseSnapshot) Key() string {
+	return c.Name + "|" + c.Section + "|" + c.Shift
+}
+
+// SessionSnapshot is a weekly class. Hours use the "15:04" layout and are empty when unknown.
+type SessionSnapshot struct {
+	Day   int    `json:"day"`
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+	Room  string `json:"room,omitempty"` // Raw room text of the sheet
+}
+
+// ExamSnapshot is a dated exam. Dates use the "2006-01-02 15:04" layout, in Paraguay time.
+type ExamSnapshot struct {
+	Type     string `json:"type"`
+	Instance int    `json:"instance"`
+	Date     string `json:"date"`
+	Revision string `json:"revision,omitempty"`
+	Room     string `json:"room,omitempty"`
+}
+
+type TeacherSnapshot struct {
+	Title     string `json:"title,omitempty"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name,omitempty"`
+	Email     string `json:"email,omitempty"`
+}
+
+// FullName returns the name as shown on the sheets (eg: "Dr. Juan Pérez").
+func (t TeacherSnapshot) FullName() string {
+	return strings.Join(strings.Fields(t.Title+" "+t.FirstName+" "+t.LastName), " ")
+}
+
+// ==========================
+//   Version diff
+// ==========================
+
+type ChangeKind string
+
+const (
+	ChangeTime    ChangeKind = "Horario"
+	ChangeRoom    ChangeKind = "Aula"
+	ChangeExam    ChangeKind = "Examen"
+	ChangeTeacher ChangeKind = "Docentes"
+)
+
+// VersionDiff lists what changed on the academic data from one sheet version to another.
+type VersionDiff struct {
+	From    *SheetVersion
+	To      *SheetVersion
+	Careers []CareerDiff
+}
+
+// Empty reports whether both versions hold the same data.
+func (d VersionDiff) Empty() bool {
+	return len(d.Careers) == 0
+}
+
+// CareerDiff holds the changes of a single career. Careers without changes are omitted.
+type CareerDiff struct {
+	Career  string
+	Added   []SectionRef
+	Removed []SectionRef
+	Changed []SectionChanges
+}
+
+// SectionRef identifies a course section on the diff.
+type SectionRef struct {
+	Course  string
+	Section string
+	Shift   string
+}
+
+// String returns the section as shown on the sheets (eg: "Cálculo 1 (TQ)").
+func (s SectionRef) String() string {
+	if s.Section == "" {
+		return s.Course
+	}
+	return fmt.Sprintf("%s (%s)", s.Course, s.Section)
+}
+
+type SectionChanges struct {
+	Section SectionRef
+	Changes []FieldChange
+}
+
+// FieldChange is a single modified value. Field names what changed inside its kind (eg: the
+// weekday of a class or the exam label), and empty values mean it was added or removed.
+type FieldChange struct {
+	Kind   ChangeKind
+	Field  string
+	Before string
+	After  string
+}
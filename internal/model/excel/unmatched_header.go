@@ -0,0 +1,67 @@
+package excel
+
+import (
+	"math"
+	"time"
+)
+
+type UnmatchedHeaderID int64
+
+// UnmatchedHeader is the header row of a sheet that no layout matched, kept to write a layout
+// for it. The import of its version failed on that sheet.
+type UnmatchedHeader struct {
+	ID        UnmatchedHeaderID
+	VersionID SheetVersionID
+	Kind      SourceKind // Kind of the version, its layouts are the ones compared
+	ParsedAt  time.Time  // Date of the version
+
+	Sheet string
+	Row   int      // Number of the row on the sheet starting from 1
+	Cells []string // Cells of the row in order, including the empty ones
+}
+
+// LayoutDraft compares an unmatched header with the closest layout of its kind, and proposes
+// a new layout for it.
+type LayoutDraft struct {
+	Header *UnmatchedHeader
+
+	// File name of the closest layout and the fraction of its headers found on the row. Empty
+	// when there are no layouts of the kind.
+	Closest string
+	Score   float64
+	Matched bool // The closest layout fits the row now, eg: it was added after the import
+
+	Columns []DraftColumn
+	Missing []string // Headers of the closest layout that are not on the row
+
+	// Draft in the format of the layout files, ready to be saved
+	JSON string
+}
+
+// ScorePercent returns the score of the closest layout as a percentage (eg: 82).
+func (d LayoutDraft) ScorePercent() int {
+	return int(math.Round(d.Score * 100))
+}
+
+// DraftColumn is a non-empty cell of the unmatched header.
+type DraftColumn struct {
+	Column string // Name of the column as shown on spreadsheets, eg: A, B, ..., AA
+	Cell   string
+
+	// Header of the closest layout assigned to the cell, empty when none fits it
+	Header     string
+	Similarity float64
+	Swapped    bool // The column is swapped with the next one on the closest layout
+
+	Draft string // Header of the cell on the draft
+}
+
+// Known tells if the cell has a header of the closest layout.
+func (c DraftColumn) Known() bool {
+	return c.Header != ""
+}
+
+// SimilarityPercent returns the similarity with the header as a percentage (eg: 86).
+func (c DraftColumn) SimilarityPercent() int {
+	return int(math.Round(c.Similarity * 100))
+}
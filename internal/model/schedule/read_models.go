@@ -1,6 +1,7 @@
 package schedule
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/elias-gill/poliplanner2/internal/model/academic"
@@ -21,9 +22,11 @@ type ScheduleSummaryView struct {
 }
 
 type StudentScheduleView struct {
-	Weekly WeekScheduleView
-	Exams  ExamMapView
-	Info   []CourseDetailView
+	ID       ScheduleID
+	Weekly   WeekScheduleView
+	ThisWeek []ClassDayView
+	Exams    ExamMapView
+	Info     []CourseDetailView
 }
 
 type WeekScheduleView struct {
@@ -35,10 +38,75 @@ type WeekScheduleView struct {
 	Saturday  []ClassSlotView
 }
 
+// Day returns the classes of the given weekday. Sundays have no classes.
+func (w WeekScheduleView) Day(day time.Weekday) []ClassSlotView {
+	switch day {
+	case time.Monday:
+		return w.Monday
+	case time.Tuesday:
+		return w.Tuesday
+	case time.Wednesday:
+		return w.Wednesday
+	case time.Thursday:
+		return w.Thursday
+	case time.Friday:
+		return w.Friday
+	case time.Saturday:
+		return w.Saturday
+	}
+	return nil
+}
+
 type ClassSlotView struct {
 	Course string
-	Room   string
+	Room   academic.Location
 	Time   academic.TimeSlot
+
+	// Set when the previous class of the day ends too close to this one to walk between
+	// both buildings.
+	Transfer *TransferWarning
+}
+
+// ClassDayView is a date of the current week with its classes. Holidays and days outside of
+// the classes of the period carry the DayOff that explains why there are no classes.
+type ClassDayView struct {
+	Date    time.Time
+	Today   bool
+	DayOff  *academic.DayOff
+	Classes []ClassSlotView
+}
+
+var weekdayNames = [...]string{"Domingo", "Lunes", "Martes", "Miércoles", "Jueves", "Viernes", "Sábado"}
+
+// Name returns the weekday in spanish.
+func (d ClassDayView) Name() string {
+	return weekdayNames[d.Date.Weekday()]
+}
+
+// ClassCalendarView holds the recurring classes of a schedule for the calendar exports.
+type ClassCalendarView struct {
+	Title   string
+	Dates   academic.DateRange // Weeks of classes of the period
+	Events  []ClassEventView
+	DaysOff []academic.DayOff
+}
+
+// ClassEventView is a class that repeats every week, from its first occurrence until the
+// last day of classes. Exclusions holds the start of the occurrences that fall on days off.
+type ClassEventView struct {
+	Course     string
+	Room       academic.Location
+	Start      time.Time
+	End        time.Time
+	Until      time.Time
+	Exclusions []time.Time
+}
+
+type TransferWarning struct {
+	FromBuilding string
+	ToBuilding   string
+	GapMinutes   int // Minutes between the end of the previous class and the start of this one
+	WalkMinutes  int // Estimated walking time between both buildings
 }
 
 type ExamSlotView struct {
@@ -75,3 +143,78 @@ type CourseDetailView struct {
 	CommitteeMember1   string
 	CommitteeMember2   string
 }
+
+// TodayView is the "today" widget of the dashboard: what is left of the day and what comes
+// next, calculated for a given moment in Paraguay time.
+type TodayView struct {
+	Now       time.Time
+	DayOff    *academic.DayOff // Set when there are no classes today
+	Remaining []ClassOccurrenceView
+	Next      *ClassOccurrenceView // Next class that has not started yet, on any day
+	Exams     []UpcomingExamView
+}
+
+// Countdown returns the time left for the next class in spanish (eg: "en 2 h 15 min").
+func (t TodayView) Countdown() string {
+	if t.Next == nil {
+		return ""
+	}
+	return countdown(t.Next.Start.Sub(t.Now))
+}
+
+// ClassOccurrenceView is a single class on a given date.
+type ClassOccurrenceView struct {
+	Course     string
+	Room       academic.Location
+	Start      time.Time
+	End        time.Time
+	InProgress bool
+}
+
+// DayName returns the weekday of the class in spanish.
+func (c ClassOccurrenceView) DayName() string {
+	return weekdayNames[c.Start.Weekday()]
+}
+
+// UpcomingExamView is an exam of the schedule in the following days.
+type UpcomingExamView struct {
+	Course   string
+	Label    string // "1° Parcial"
+	Date     time.Time
+	HasHour  bool
+	Room     string
+	DaysLeft int // 0 for today, 1 for tomorrow
+}
+
+// When returns the remaining days in spanish (eg: "mañana", "en 3 días").
+func (e UpcomingExamView) When() string {
+	switch e.DaysLeft {
+	case 0:
+		return "hoy"
+	case 1:
+		return "mañana"
+	}
+	return fmt.Sprintf("en %d días", e.DaysLeft)
+}
+
+func countdown(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+
+	switch {
+	case minutes < 1:
+		return "ahora"
+	case minutes < 60:
+		return fmt.Sprintf("en %d min", minutes)
+	case minutes < 24*60:
+		if minutes%60 == 0 {
+			return fmt.Sprintf("en %d h", minutes/60)
+		}
+		return fmt.Sprintf("en %d h %d min", minutes/60, minutes%60)
+	}
+
+	days := minutes / (24 * 60)
+	if days == 1 {
+		return "en 1 día"
+	}
+	return fmt.Sprintf("en %d días", days)
+}
@@ -122,8 +122,10 @@ func (e *SchedulePDFExporter) Export(view *model.StudentScheduleView, w io.Write
 
 			pdf.SetTextColor(71, 85, 105)
 			pdf.SetXY(startX+430, currentY+5)
-			roomText := slot.Room
-			if roomText != "" {
+			roomText := slot.Room.Raw
+			if slot.Room.IsVirtual() {
+				roomText = "Virtual"
+			} else if roomText != "" {
 				roomText = "Aula: " + roomText
 			}
 			_ = pdf.Cell(nil, roomText)
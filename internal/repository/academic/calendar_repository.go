@@ -0,0 +1,21 @@
+package academic
+
+import (
+	"context"
+
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+type CalendarRepository interface {
+	// Add stores the days off, skipping the ones already loaded with the same dates and
+	// description. Returns how many were new.
+	Add(ctx context.Context, days []academic.DayOff) (int, error)
+	Delete(ctx context.Context, id academic.DayOffID) error
+	// Clear removes every day off.
+	Clear(ctx context.Context) error
+
+	// ListBetween returns the days off that overlap the range, sorted by date.
+	ListBetween(ctx context.Context, dates academic.DateRange) ([]academic.DayOff, error)
+	// List returns every day off, sorted by date.
+	List(ctx context.Context) ([]academic.DayOff, error)
+}
@@ -10,6 +10,12 @@ type CareerRepository interface {
 	Upsert(ctx context.Context, c academic.Career) (academic.CareerID, error)
 
 	GetByID(ctx context.Context, id academic.CareerID) (*academic.Career, error)
+	GetByCode(ctx context.Context, code string) (*academic.Career, error)
 	List(ctx context.Context) ([]*academic.Career, error)
 	ListPlans(ctx context.Context, id academic.CareerID) ([]academic.Plan, error)
+	ListEmphases(ctx context.Context, id academic.CareerID) ([]academic.Emphasis, error)
+
+	// ListExams returns the partials and finals of every course offered to the career on the
+	// given period, with one entry per plan and semester of the course.
+	ListExams(ctx context.Context, id academic.CareerID, period academic.PeriodID) ([]academic.CareerExamView, error)
 }
@@ -18,17 +18,34 @@ type CourseSaveParams struct {
 	Comitee       academic.Committee
 }
 
+// CourseAssignment is the related data of a course, replaced as a whole by AssignBatch.
+type CourseAssignment struct {
+	Course    academic.CourseID
+	Teachers  []academic.TeacherID
+	Schedule  []academic.ClassSession
+	Exams     []academic.Exam
+	Committee []academic.CommitteeSeat
+}
+
 type CourseRepository interface {
 	// Upsert persists the base Course entity.
 	// It only stores fields belonging to the course itself (course table).
 	// It does not modify or manage related entities such as teachers, schedules, or exams.
 	Upsert(ctx context.Context, course *CourseSaveParams) (academic.CourseID, error)
 
+	// UpsertBatch persists the courses in order, same as Upsert, returning their IDs in the
+	// same order.
+	UpsertBatch(ctx context.Context, courses []*CourseSaveParams) ([]academic.CourseID, error)
+
 	// AssignTeachers replaces all existing teacher assignments for the given course.
 	// After this operation, the course will be associated only with the provided teacher IDs.
 	// This operation is destructive: previous assignments are removed.
 	AssignTeachers(ctx context.Context, courseID academic.CourseID, teachers []academic.TeacherID) error
 
+	// AssignCommittee replaces the examiners of the course committee. Examiners are shared
+	// between courses and created on demand.
+	AssignCommittee(ctx context.Context, courseID academic.CourseID, seats []academic.CommitteeSeat) error
+
 	// AssignSchedule replaces the full schedule for the given course.
 	// Existing schedule entries are removed and replaced with the provided set.
 	AssignSchedule(ctx context.Context, courseID academic.CourseID, schedule []academic.ClassSession) error
@@ -37,6 +54,20 @@ type CourseRepository interface {
 	// After execution, only the provided exams will exist for the course.
 	AssignExams(ctx context.Context, courseID academic.CourseID, exams []academic.Exam) error
 
+	// AssignBatch replaces the teachers, schedule, exams and committee of every listed course,
+	// inserting the rows of all the courses together. When a course is listed twice the last
+	// assignment wins.
+	AssignBatch(ctx context.Context, assignments []CourseAssignment) error
+
+	// DeleteByPeriodExcept removes the courses of the period that are not listed in keep,
+	// returning how many were removed. Schedules of students lose the removed courses.
+	// Laboratories come from their own sources and are always kept.
+	DeleteByPeriodExcept(ctx context.Context, period academic.PeriodID, keep []academic.CourseID) (int64, error)
+
+	// CountByPeriodExcept counts what DeleteByPeriodExcept would remove with the same
+	// arguments: the courses and the schedules of students that hold at least one of them.
+	CountByPeriodExcept(ctx context.Context, period academic.PeriodID, keep []academic.CourseID) (courses int64, schedules int64, err error)
+
 	// -----------------------
 	// -   READ OPERATIONS   -
 	// -----------------------
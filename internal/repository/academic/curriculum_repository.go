@@ -15,5 +15,8 @@ type CurriculumSaveParams struct {
 type CurriculumRepository interface {
 	Upsert(ctx context.Context, c CurriculumSaveParams) (academic.CurriculumID, error)
 
+	// UpsertBatch upserts many records in order, returning their IDs in the same order.
+	UpsertBatch(ctx context.Context, params []CurriculumSaveParams) ([]academic.CurriculumID, error)
+
 	GetByCareerID(ctx context.Context, career academic.CareerID) ([]academic.CurriculumSubjectItem, error)
 }
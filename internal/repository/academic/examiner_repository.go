@@ -0,0 +1,28 @@
+package academic
+
+import (
+	"context"
+
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+type ExaminerRepository interface {
+	// ListByPeriod returns every examiner sitting on a committee of the period.
+	ListByPeriod(ctx context.Context, period academic.PeriodID) ([]academic.ExaminerSummaryView, error)
+
+	GetByID(ctx context.Context, id academic.ExaminerID) (*academic.Examiner, error)
+
+	// ListFinalExams returns the final exams of the period where the examiner sits on the
+	// committee.
+	ListFinalExams(ctx context.Context, id academic.ExaminerID, period academic.PeriodID) ([]academic.ExaminerExamView, error)
+
+	// ListCoursesWithoutExaminers returns the courses with committee members on their text
+	// columns but no examiners assigned, for example the ones imported before examiners
+	// existed.
+	ListCoursesWithoutExaminers(ctx context.Context) ([]academic.CourseSummaryView, error)
+
+	// ListUnlinked returns the examiners that are not linked to a teacher.
+	ListUnlinked(ctx context.Context) ([]academic.Examiner, error)
+
+	LinkTeacher(ctx context.Context, id academic.ExaminerID, teacher academic.TeacherID) error
+}
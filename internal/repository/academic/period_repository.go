@@ -2,10 +2,23 @@ package academic
 
 import (
 	"context"
+	"time"
 
 	"github.com/elias-gill/poliplanner2/internal/model/academic"
 )
 
 type PeriodRepository interface {
 	Upsert(ctx context.Context, c academic.Period) (academic.PeriodID, error)
+
+	GetByID(ctx context.Context, id academic.PeriodID) (*academic.Period, error)
+	// List returns the periods with at least one course, from the newest to the oldest.
+	List(ctx context.Context) ([]academic.Period, error)
+	// ListAll returns every period, with or without courses, from the newest to the oldest.
+	ListAll(ctx context.Context) ([]academic.Period, error)
+	// FindByDate returns the configured period whose span contains the day of t, or nil when
+	// there is none.
+	FindByDate(ctx context.Context, t time.Time) (*academic.Period, error)
+
+	// UpdateBoundaries stores the span, classes and exam windows of the period.
+	UpdateBoundaries(ctx context.Context, p academic.Period) error
 }
@@ -0,0 +1,15 @@
+package academic
+
+import (
+	"context"
+
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+type RoomRepository interface {
+	// ListSessions returns every class session of the period held on a physical room.
+	ListSessions(ctx context.Context, period academic.PeriodID) ([]academic.RoomSessionView, error)
+
+	// ListExams returns every exam of the period held on a physical room.
+	ListExams(ctx context.Context, period academic.PeriodID) ([]academic.RoomExamView, error)
+}
@@ -0,0 +1,16 @@
+package academic
+
+import (
+	"context"
+
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+type SearchRepository interface {
+	// Rebuild replaces the whole search index with the courses, subjects, teachers and rooms
+	// of the given period.
+	Rebuild(ctx context.Context, period academic.PeriodID) error
+
+	// Search returns the best matches for a full-text query, already in the index syntax.
+	Search(ctx context.Context, query string, limit int) ([]academic.SearchResult, error)
+}
@@ -9,5 +9,8 @@ import (
 type SubjectRepository interface {
 	Upsert(ctx context.Context, c academic.Subject) (academic.SubjectID, error)
 
+	// UpsertBatch upserts many records in order, returning their IDs in the same order.
+	UpsertBatch(ctx context.Context, subjects []academic.Subject) ([]academic.SubjectID, error)
+
 	GetByID(ctx context.Context, id academic.SubjectID) (*academic.Subject, error)
 }
@@ -9,5 +9,26 @@ import (
 type TeacherRepository interface {
 	Upsert(ctx context.Context, c academic.Teacher) (academic.TeacherID, error)
 
+	// UpsertBatch upserts many records in order, returning their IDs in the same order.
+	UpsertBatch(ctx context.Context, teachers []academic.Teacher) ([]academic.TeacherID, error)
+
 	GetByID(ctx context.Context, id academic.TeacherID) (*academic.Teacher, error)
+
+	// ListByPeriod returns every teacher assigned to at least one course of the period.
+	ListByPeriod(ctx context.Context, period academic.PeriodID) ([]academic.TeacherSummaryView, error)
+
+	// ListCourses returns the courses of the period taught by the given teacher. Schedules
+	// are not loaded.
+	ListCourses(ctx context.Context, id academic.TeacherID, period academic.PeriodID) ([]academic.TeacherCourseView, error)
+
+	// ListAll returns every teacher, counting the courses of all the periods.
+	ListAll(ctx context.Context) ([]academic.TeacherSummaryView, error)
+
+	// Merge moves the course assignments of the duplicates to the survivor and deletes the
+	// duplicated records. Their names are kept as aliases of the survivor, so the next
+	// import does not create them again.
+	Merge(ctx context.Context, survivor academic.TeacherID, duplicates []academic.TeacherID) error
+
+	// ListCommittees returns every course of the period with at least one committee member.
+	ListCommittees(ctx context.Context, period academic.PeriodID) ([]academic.CourseSummaryView, error)
 }
@@ -7,8 +7,43 @@ import (
 )
 
 type ExcelRepository interface {
+	// Saves the version and sets its new ID
 	SaveVersion(ctx context.Context, version *excel.SheetVersion) error
 
+	// Returns nil when there is no such version
+	GetVersion(ctx context.Context, id excel.SheetVersionID) (*excel.SheetVersion, error)
+
 	// Lists all parsed excel versions ordered by date (latest to oldest)
 	ListAllVersions(ctx context.Context) ([]*excel.SheetVersion, error)
+
+	// Returns the latest successful import of the file with the given hash, nil when there is
+	// none. Rollbacks are not imports of a file and are never returned.
+	GetImportedVersion(ctx context.Context, hash string) (*excel.SheetVersion, error)
+
+	SaveSnapshot(ctx context.Context, id excel.SheetVersionID, snapshot *excel.Snapshot) error
+
+	// Returns nil when the version has no snapshot (failed imports or older versions)
+	GetSnapshot(ctx context.Context, id excel.SheetVersionID) (*excel.Snapshot, error)
+
+	SaveReport(ctx context.Context, id excel.SheetVersionID, report *excel.ImportReport) error
+
+	// Returns nil when the version has no import report
+	GetReport(ctx context.Context, id excel.SheetVersionID) (*excel.ImportReport, error)
+
+	// Saves the archived file, or updates its date when it was already archived
+	SaveArchive(ctx context.Context, archived excel.ArchivedSource) error
+
+	// Lists the archived files ordered by date (latest to oldest)
+	ListArchives(ctx context.Context) ([]excel.ArchivedSource, error)
+
+	DeleteArchive(ctx context.Context, hash string) error
+
+	// Saves the header row that no layout matched and sets its new ID
+	SaveUnmatchedHeader(ctx context.Context, header *excel.UnmatchedHeader) error
+
+	// Lists the unmatched headers ordered by the date of their version (latest to oldest)
+	ListUnmatchedHeaders(ctx context.Context) ([]*excel.UnmatchedHeader, error)
+
+	// Returns nil when there is no such header
+	GetUnmatchedHeader(ctx context.Context, id excel.UnmatchedHeaderID) (*excel.UnmatchedHeader, error)
 }
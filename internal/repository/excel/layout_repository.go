@@ -0,0 +1,21 @@
+package excel
+
+import (
+	"context"
+
+	"github.com/elias-gill/poliplanner2/internal/model/excel"
+)
+
+// LayoutRepository stores the parser layouts changed from the administration. The layout files
+// are not stored, only the state set to them.
+type LayoutRepository interface {
+	// Lists the stored layouts of the kind ordered by name. Layouts that only store the state
+	// of a file have an empty JSON.
+	ListLayouts(ctx context.Context, kind excel.SourceKind) ([]*excel.ParserLayout, error)
+
+	// Saves the uploaded layout, replacing the one with the same kind and name
+	SaveLayout(ctx context.Context, layout *excel.ParserLayout) error
+
+	// Enables or disables the layout, storing the state of a file when it was never stored
+	SetLayoutEnabled(ctx context.Context, kind excel.SourceKind, name string, enabled bool) error
+}
@@ -0,0 +1,112 @@
+package academic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/calendar"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	"github.com/elias-gill/poliplanner2/internal/repository"
+	academicRepo "github.com/elias-gill/poliplanner2/internal/repository/academic"
+)
+
+// ErrInvalidCalendar is returned when the uploaded file cannot be read as a calendar.
+var ErrInvalidCalendar = errors.New("invalid calendar file")
+
+// defaultClassWeeks is the length assumed for the classes of a period without dates.
+const defaultClassWeeks = 16
+
+// CalendarService manages the holidays and days without classes of the academic calendar.
+type CalendarService struct {
+	calendarRepository academicRepo.CalendarRepository
+	txManager          repository.TxManager
+	periodService      *PeriodService
+}
+
+func NewCalendarService(
+	calendarRepo academicRepo.CalendarRepository,
+	txManager repository.TxManager,
+	periodService *PeriodService,
+) *CalendarService {
+	return &CalendarService{
+		calendarRepository: calendarRepo,
+		txManager:          txManager,
+		periodService:      periodService,
+	}
+}
+
+// ImportFile loads the days off of an ICS or CSV file. When replace is set, the days loaded
+// before are removed first. Returns how many new days off were stored.
+func (s *CalendarService) ImportFile(ctx context.Context, filename string, r io.Reader, replace bool) (int, error) {
+	days, err := calendar.Parse(filename, r)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidCalendar, err)
+	}
+
+	added := 0
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if replace {
+			if err := s.calendarRepository.Clear(ctx); err != nil {
+				return err
+			}
+		}
+
+		added, err = s.calendarRepository.Add(ctx, days)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("import days off: %w", err)
+	}
+
+	return added, nil
+}
+
+// ListDaysOff returns every day off loaded, sorted by date.
+func (s *CalendarService) ListDaysOff(ctx context.Context) ([]academic.DayOff, error) {
+	return s.calendarRepository.List(ctx)
+}
+
+// DaysOffBetween returns the days off that overlap the given range, sorted by date.
+func (s *CalendarService) DaysOffBetween(ctx context.Context, dates academic.DateRange) ([]academic.DayOff, error) {
+	return s.calendarRepository.ListBetween(ctx, dates)
+}
+
+func (s *CalendarService) DeleteDayOff(ctx context.Context, id academic.DayOffID) error {
+	return s.calendarRepository.Delete(ctx, id)
+}
+
+// ClassDates returns the weeks of classes of the current period. Falls back to the whole
+// period when an admin only loaded its span, and to defaultClassWeeks from the current week
+// when the period has no dates at all.
+func (s *CalendarService) ClassDates(ctx context.Context) (academic.DateRange, error) {
+	id, err := s.periodService.CalculateCurrentPeriod(ctx)
+	if err != nil {
+		return academic.DateRange{}, err
+	}
+
+	period, err := s.periodService.GetPeriod(ctx, id)
+	if err != nil {
+		return academic.DateRange{}, err
+	}
+
+	return classDates(*period, time.Now().In(timezone.ParaguayTZ)), nil
+}
+
+func classDates(period academic.Period, now time.Time) academic.DateRange {
+	switch {
+	case !period.Classes.IsZero():
+		return period.Classes
+	case !period.Span.IsZero():
+		return period.Span
+	}
+
+	week := academic.WeekOf(now)
+	return academic.DateRange{
+		Start: week.Start,
+		End:   week.Start.AddDate(0, 0, 7*defaultClassWeeks-1),
+	}
+}
@@ -2,19 +2,230 @@ package academic
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
 
 	"github.com/elias-gill/poliplanner2/internal/model/academic"
 	academicRepo "github.com/elias-gill/poliplanner2/internal/repository/academic"
 )
 
+var ErrCareerNotFound = errors.New("career not found")
+
 type CareerService struct {
 	careerRepository academicRepo.CareerRepository
+	periodService    *PeriodService
 }
 
-func NewCareerService(planStorer academicRepo.CareerRepository) *CareerService {
-	return &CareerService{careerRepository: planStorer}
+func NewCareerService(planStorer academicRepo.CareerRepository, periodService *PeriodService) *CareerService {
+	return &CareerService{careerRepository: planStorer, periodService: periodService}
 }
 
 func (a CareerService) ListCareers(ctx context.Context) ([]*academic.Career, error) {
 	return a.careerRepository.List(ctx)
 }
+
+// GetExamCalendar returns every partial and final of the current period for the given career
+// code, narrowed by the filter. Exams are listed in chronological order and also split into
+// month grids.
+func (a CareerService) GetExamCalendar(
+	ctx context.Context,
+	code string,
+	filter academic.CareerExamFilter,
+) (*academic.CareerExamCalendarView, error) {
+	career, err := a.careerRepository.GetByCode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("get career %q: %w", code, err)
+	}
+	if career == nil {
+		return nil, ErrCareerNotFound
+	}
+
+	periodID, err := a.periodService.CalculateCurrentPeriod(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	period, err := a.periodService.GetPeriod(ctx, periodID)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, err := a.careerRepository.ListPlans(ctx, career.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	emphases, err := a.careerRepository.ListEmphases(ctx, career.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	exams, err := a.careerRepository.ListExams(ctx, career.ID, periodID)
+	if err != nil {
+		return nil, err
+	}
+
+	exams = groupCareerExams(filterCareerExams(exams, filter))
+
+	return &academic.CareerExamCalendarView{
+		Career:    *career,
+		Period:    *period,
+		Filter:    filter,
+		Plans:     plans,
+		Semesters: examSemesters(exams, filter),
+		Emphases:  emphases,
+		Exams:     exams,
+		Months:    buildExamMonths(exams, *period),
+	}, nil
+}
+
+// ==================
+//  Helper functions
+// ==================
+
+// filterCareerExams keeps the exams that match the filter. Common subjects have no emphasis,
+// so they are kept for every emphasis.
+func filterCareerExams(exams []academic.CareerExamView, filter academic.CareerExamFilter) []academic.CareerExamView {
+	filtered := make([]academic.CareerExamView, 0, len(exams))
+	for _, e := range exams {
+		if filter.Semester != 0 && e.Semester != filter.Semester {
+			continue
+		}
+		if filter.Plan != "" && !slices.Contains(e.Plans, filter.Plan) {
+			continue
+		}
+		if filter.Emphasis != "" && len(e.Emphases) > 0 && !slices.Contains(e.Emphases, filter.Emphasis) {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	return filtered
+}
+
+// groupCareerExams merges the entries of the same course that only differ on the plan. Keeps
+// the order of the first appearance.
+func groupCareerExams(exams []academic.CareerExamView) []academic.CareerExamView {
+	type key struct {
+		name, section, shift string
+		examType             academic.ExamType
+		instance             academic.ExamInstance
+	}
+
+	index := make(map[key]int, len(exams))
+	grouped := make([]academic.CareerExamView, 0, len(exams))
+
+	for _, e := range exams {
+		k := key{e.CourseName, e.Section, e.Shift, e.Type, e.Instance}
+
+		i, ok := index[k]
+		if !ok {
+			index[k] = len(grouped)
+			e.Plans = slices.Clone(e.Plans)
+			e.Emphases = slices.Clone(e.Emphases)
+			grouped = append(grouped, e)
+			continue
+		}
+
+		for _, p := range e.Plans {
+			if !slices.Contains(grouped[i].Plans, p) {
+				grouped[i].Plans = append(grouped[i].Plans, p)
+			}
+		}
+		for _, emp := range e.Emphases {
+			if !slices.Contains(grouped[i].Emphases, emp) {
+				grouped[i].Emphases = append(grouped[i].Emphases, emp)
+			}
+		}
+	}
+
+	for i := range grouped {
+		sort.Strings(grouped[i].Plans)
+		sort.Strings(grouped[i].Emphases)
+	}
+
+	return grouped
+}
+
+// examSemesters lists the semesters with exams, used by the semester filter. The selected
+// semester is always listed so the filter can be cleared.
+func examSemesters(exams []academic.CareerExamView, filter academic.CareerExamFilter) []int {
+	var semesters []int
+	if filter.Semester != 0 {
+		semesters = append(semesters, filter.Semester)
+	}
+	for _, e := range exams {
+		if e.Semester != 0 && !slices.Contains(semesters, e.Semester) {
+			semesters = append(semesters, e.Semester)
+		}
+	}
+	sort.Ints(semesters)
+	return semesters
+}
+
+// buildExamMonths splits the dated exams into month grids. Only months with at least one exam
+// are returned. Weeks start on Monday and are padded with the days of the surrounding months.
+// Days inside the exam windows of the period are marked.
+func buildExamMonths(exams []academic.CareerExamView, period academic.Period) []academic.ExamMonthView {
+	byDay := make(map[time.Time][]academic.CareerExamView)
+	var months []time.Time
+
+	for _, e := range exams {
+		if e.Date == nil {
+			continue
+		}
+		d := *e.Date
+		day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, d.Location())
+		byDay[day] = append(byDay[day], e)
+
+		month := time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, d.Location())
+		if !slices.ContainsFunc(months, month.Equal) {
+			months = append(months, month)
+		}
+	}
+
+	slices.SortFunc(months, func(a, b time.Time) int { return a.Compare(b) })
+
+	views := make([]academic.ExamMonthView, 0, len(months))
+	for _, month := range months {
+		// Go back to the Monday of the first week
+		offset := (int(month.Weekday()) + 6) % 7
+		day := month.AddDate(0, 0, -offset)
+
+		view := academic.ExamMonthView{Month: month}
+		for {
+			week := make([]academic.ExamDayView, 7)
+			for i := range week {
+				week[i] = academic.ExamDayView{
+					Date:    day,
+					InMonth: day.Month() == month.Month(),
+					Window:  examWindow(period, day),
+					Exams:   byDay[day],
+				}
+				day = day.AddDate(0, 0, 1)
+			}
+			view.Weeks = append(view.Weeks, week)
+
+			if day.Month() != month.Month() {
+				break
+			}
+		}
+
+		views = append(views, view)
+	}
+
+	return views
+}
+
+// examWindow returns the exam window of the period that contains the day.
+func examWindow(period academic.Period, day time.Time) academic.ExamType {
+	switch {
+	case period.Partials.Contains(day):
+		return academic.ExamPartial
+	case period.Finals.Contains(day):
+		return academic.ExamFinal
+	}
+	return ""
+}
@@ -0,0 +1,124 @@
+package academic
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+func TestFilterAndGroupCareerExams(t *testing.T) {
+	exam := func(id academic.CourseID, name string, semester int, plan string, emphases ...string) academic.CareerExamView {
+		return academic.CareerExamView{
+			CourseID:   id,
+			CourseName: name,
+			Section:    "TQ",
+			Shift:      "T",
+			Semester:   semester,
+			Plans:      []string{plan},
+			Emphases:   emphases,
+			Type:       academic.ExamPartial,
+			Instance:   academic.Instance1,
+		}
+	}
+
+	exams := []academic.CareerExamView{
+		exam(1, "Cálculo", 1, "2013"),
+		exam(2, "Cálculo", 1, "2023"),
+		exam(3, "Óptica", 8, "2013", "EM"),
+		exam(4, "Redes", 8, "2013", "TI"),
+	}
+
+	tests := []struct {
+		name   string
+		filter academic.CareerExamFilter
+		want   []academic.CourseID
+	}{
+		{"no filter", academic.CareerExamFilter{}, []academic.CourseID{1, 3, 4}},
+		{"semester", academic.CareerExamFilter{Semester: 8}, []academic.CourseID{3, 4}},
+		{"plan", academic.CareerExamFilter{Plan: "2023"}, []academic.CourseID{2}},
+		{"emphasis keeps common subjects", academic.CareerExamFilter{Emphasis: "EM"}, []academic.CourseID{1, 3}},
+	}
+
+	for _, tc := range tests {
+		got := groupCareerExams(filterCareerExams(exams, tc.filter))
+
+		var ids []academic.CourseID
+		for _, e := range got {
+			ids = append(ids, e.CourseID)
+		}
+		if !slices.Equal(ids, tc.want) {
+			t.Errorf("%s: got courses %v; want %v", tc.name, ids, tc.want)
+		}
+	}
+
+	grouped := groupCareerExams(exams)
+	if !slices.Equal(grouped[0].Plans, []string{"2013", "2023"}) {
+		t.Errorf("grouped plans = %v; want [2013 2023]", grouped[0].Plans)
+	}
+	if !slices.Equal(exams[0].Plans, []string{"2013"}) {
+		t.Errorf("groupCareerExams modified its input: %v", exams[0].Plans)
+	}
+}
+
+func TestBuildExamMonths(t *testing.T) {
+	at := func(year int, month time.Month, day int) *time.Time {
+		d := time.Date(year, month, day, 15, 0, 0, 0, timezone.ParaguayTZ)
+		return &d
+	}
+	day := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, timezone.ParaguayTZ)
+	}
+
+	exams := []academic.CareerExamView{
+		{CourseName: "Cálculo", Date: at(2026, time.June, 1)},
+		{CourseName: "Física", Date: at(2026, time.June, 1)},
+		{CourseName: "Álgebra", Date: at(2026, time.April, 30)},
+		{CourseName: "Sin fecha"},
+	}
+
+	period := academic.Period{
+		Partials: academic.DateRange{Start: day(2026, time.April, 27), End: day(2026, time.May, 8)},
+		Finals:   academic.DateRange{Start: day(2026, time.June, 1), End: day(2026, time.July, 3)},
+	}
+
+	months := buildExamMonths(exams, period)
+
+	if len(months) != 2 {
+		t.Fatalf("buildExamMonths() returned %d months; want 2 (months without exams are skipped)", len(months))
+	}
+	if got := months[0].Name(); got != "Abril 2026" {
+		t.Errorf("first month = %q; want Abril 2026", got)
+	}
+
+	// June 2026 starts on Monday and ends on Tuesday
+	june := months[1]
+	if len(june.Weeks) != 5 {
+		t.Fatalf("June has %d weeks; want 5", len(june.Weeks))
+	}
+	first := june.Weeks[0][0]
+	if first.Date.Day() != 1 || !first.InMonth || len(first.Exams) != 2 {
+		t.Errorf("first cell = day %d, in month %v, %d exams; want day 1 with 2 exams", first.Date.Day(), first.InMonth, len(first.Exams))
+	}
+	if first.Window != academic.ExamFinal {
+		t.Errorf("June 1 window = %q; want final", first.Window)
+	}
+	last := june.Weeks[4][6]
+	if last.Date.Day() != 5 || last.InMonth {
+		t.Errorf("last cell = day %d, in month %v; want July 5 as padding", last.Date.Day(), last.InMonth)
+	}
+
+	// April 30 is a Thursday, the grid starts on Monday March 30
+	april := months[0]
+	if d := april.Weeks[0][0].Date; d.Month() != time.March || d.Day() != 30 {
+		t.Errorf("April grid starts on %v; want March 30", d.Format("2006-01-02"))
+	}
+	if w := april.Weeks[0][0].Window; w != "" {
+		t.Errorf("March 30 window = %q; want none", w)
+	}
+	if w := april.Weeks[4][3].Window; w != academic.ExamPartial {
+		t.Errorf("April 30 window = %q; want partial", w)
+	}
+}
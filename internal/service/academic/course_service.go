@@ -10,26 +10,22 @@ import (
 
 type CourseService struct {
 	courseRepository academic.CourseRepository
-	periodService    *PeriodService
 }
 
-func NewCourseService(
-	courseRepo academic.CourseRepository,
-	periodService *PeriodService,
-) *CourseService {
+func NewCourseService(courseRepo academic.CourseRepository) *CourseService {
 	return &CourseService{
-		periodService:    periodService,
 		courseRepository: courseRepo,
 	}
 }
 
-func (c *CourseService) GetOfferings(ctx context.Context, curriculum academicModel.CurriculumID) ([]academicModel.CourseSummaryView, error) {
-	period, err := c.periodService.CalculateCurrentPeriod(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	courses, err := c.courseRepository.ListByCurriculumID(ctx, curriculum, academicModel.PeriodID(period))
+// GetOfferings returns the sections of a subject offered on the given period, along with their
+// teachers and schedules.
+func (c *CourseService) GetOfferings(
+	ctx context.Context,
+	curriculum academicModel.CurriculumID,
+	period academicModel.PeriodID,
+) ([]academicModel.CourseSummaryView, error) {
+	courses, err := c.courseRepository.ListByCurriculumID(ctx, curriculum, period)
 	if err != nil {
 		return nil, fmt.Errorf("get courses: %w", err)
 	}
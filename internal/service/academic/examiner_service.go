@@ -0,0 +1,197 @@
+package academic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	"github.com/elias-gill/poliplanner2/internal/repository"
+	academicRepo "github.com/elias-gill/poliplanner2/internal/repository/academic"
+	"github.com/elias-gill/poliplanner2/logger"
+)
+
+var ErrExaminerNotFound = errors.New("examiner not found")
+
+type ExaminerService struct {
+	examinerRepository academicRepo.ExaminerRepository
+	courseRepository   academicRepo.CourseRepository
+	teacherRepository  academicRepo.TeacherRepository
+	txManager          repository.TxManager
+	periodService      *PeriodService
+}
+
+func NewExaminerService(
+	examinerRepo academicRepo.ExaminerRepository,
+	courseRepo academicRepo.CourseRepository,
+	teacherRepo academicRepo.TeacherRepository,
+	txManager repository.TxManager,
+	periodService *PeriodService,
+) *ExaminerService {
+	return &ExaminerService{
+		examinerRepository: examinerRepo,
+		courseRepository:   courseRepo,
+		teacherRepository:  teacherRepo,
+		txManager:          txManager,
+		periodService:      periodService,
+	}
+}
+
+// ListExaminers returns the examiners of the current period whose name contains the given
+// search text. An empty search returns every examiner.
+func (s *ExaminerService) ListExaminers(ctx context.Context, search string) ([]academic.ExaminerSummaryView, error) {
+	period, err := s.periodService.CalculateCurrentPeriod(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	examiners, err := s.examinerRepository.ListByPeriod(ctx, period)
+	if err != nil {
+		return nil, fmt.Errorf("list examiners: %w", err)
+	}
+
+	search = academic.NormalizeName(search)
+	if search == "" {
+		return examiners, nil
+	}
+
+	filtered := examiners[:0]
+	for _, e := range examiners {
+		if strings.Contains(academic.NormalizeName(e.Examiner.Name), search) {
+			filtered = append(filtered, e)
+		}
+	}
+
+	return filtered, nil
+}
+
+// GetExaminer returns every final exam of the current period where the examiner sits on the
+// committee.
+func (s *ExaminerService) GetExaminer(ctx context.Context, id academic.ExaminerID) (*academic.ExaminerDetailView, error) {
+	examiner, err := s.examinerRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get examiner %v: %w", id, err)
+	}
+	if examiner == nil {
+		return nil, ErrExaminerNotFound
+	}
+
+	period, err := s.periodService.CalculateCurrentPeriod(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	exams, err := s.examinerRepository.ListFinalExams(ctx, id, period)
+	if err != nil {
+		return nil, fmt.Errorf("list exams of examiner %v: %w", id, err)
+	}
+
+	detail := &academic.ExaminerDetailView{
+		Examiner: *examiner,
+		Exams:    exams,
+	}
+
+	if examiner.TeacherID != nil {
+		teacher, err := s.teacherRepository.GetByID(ctx, *examiner.TeacherID)
+		if err != nil {
+			return nil, fmt.Errorf("get teacher %v: %w", *examiner.TeacherID, err)
+		}
+		detail.Teacher = teacher
+	}
+
+	return detail, nil
+}
+
+// SyncExaminers assigns examiners to the courses that only have the committee text columns,
+// and links every examiner without teacher to the teacher with the same name. It is cheap
+// when there is nothing to do, so it runs on startup and after each import.
+func (s *ExaminerService) SyncExaminers(ctx context.Context) error {
+	courses, err := s.examinerRepository.ListCoursesWithoutExaminers(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(courses) > 0 {
+		err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+			for _, c := range courses {
+				if err := s.courseRepository.AssignCommittee(ctx, c.ID, c.Committee.Seats()); err != nil {
+					return fmt.Errorf("assign committee to course %v: %w", c.ID, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		logger.Info("examiners assigned to courses", "courses", len(courses))
+	}
+
+	return s.linkTeachers(ctx)
+}
+
+func (s *ExaminerService) linkTeachers(ctx context.Context) error {
+	examiners, err := s.examinerRepository.ListUnlinked(ctx)
+	if err != nil {
+		return err
+	}
+	if len(examiners) == 0 {
+		return nil
+	}
+
+	teachers, err := s.teacherRepository.ListAll(ctx)
+	if err != nil {
+		return fmt.Errorf("list teachers: %w", err)
+	}
+
+	byKey := teachersByKey(teachers)
+
+	linked := 0
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, e := range examiners {
+			teacherID, ok := byKey[academic.CommitteeMemberKey(e.Name)]
+			if !ok {
+				continue
+			}
+			if err := s.examinerRepository.LinkTeacher(ctx, e.ID, teacherID); err != nil {
+				return err
+			}
+			linked++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if linked > 0 {
+		logger.Info("examiners linked to teachers", "linked", linked, "unlinked", len(examiners)-linked)
+	}
+
+	return nil
+}
+
+// teachersByKey indexes the teachers by the same key used for examiners. Names shared by
+// more than one teacher are left out, as we cannot tell which one sits on the committee.
+func teachersByKey(teachers []academic.TeacherSummaryView) map[string]academic.TeacherID {
+	byKey := make(map[string]academic.TeacherID, len(teachers))
+	ambiguous := make(map[string]struct{})
+
+	for _, t := range teachers {
+		key := academic.CommitteeMemberKey(t.Teacher.FullName())
+		if key == "" {
+			continue
+		}
+		if _, ok := byKey[key]; ok {
+			ambiguous[key] = struct{}{}
+			continue
+		}
+		byKey[key] = t.Teacher.ID
+	}
+
+	for key := range ambiguous {
+		delete(byKey, key)
+	}
+
+	return byKey
+}
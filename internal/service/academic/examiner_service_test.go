@@ -0,0 +1,74 @@
+package academic
+
+import (
+	"testing"
+
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+func TestCommitteeMemberKey(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected string
+	}{
+		{"Ing. Juan Pérez", "juan perez"},
+		{"  ÍNG.   Juan  PEREZ ", "juan perez"},
+		{"C.P. Leidy Ríos", "leidy rios"},
+		{"Fco. Dionisio Isasi", "dionisio isasi"},
+		{"Lic Ana Gómez", "ana gomez"},
+		{"Ms. Dr. Ana Gómez", "ana gomez"},
+		{"Juan Pérez", "juan perez"},
+		{"A CONFIRMAR", ""},
+		{"", ""},
+	}
+
+	for _, tc := range tests {
+		if got := academic.CommitteeMemberKey(tc.raw); got != tc.expected {
+			t.Errorf("CommitteeMemberKey(%q) = %q; want %q", tc.raw, got, tc.expected)
+		}
+	}
+}
+
+func TestCommitteeSeats(t *testing.T) {
+	committee := academic.Committee{
+		President: "Ing. Juan Pérez",
+		Member1:   "A CONFIRMAR",
+		Member2:   " Lic. Ana Gómez ",
+	}
+
+	got := committee.Seats()
+	want := []academic.CommitteeSeat{
+		{Name: "Ing. Juan Pérez", Role: academic.CommitteePresident},
+		{Name: "Lic. Ana Gómez", Role: academic.CommitteeMember},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Seats() = %+v; want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("seat %d = %+v; want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTeachersByKey(t *testing.T) {
+	teachers := []academic.TeacherSummaryView{
+		{Teacher: academic.Teacher{ID: 1, FirstName: "Juan", LastName: "Pérez"}},
+		{Teacher: academic.Teacher{ID: 2, FirstName: "Ana", LastName: "Gómez"}},
+		{Teacher: academic.Teacher{ID: 3, FirstName: "Ana", LastName: "Gomez"}},
+		{Teacher: academic.Teacher{ID: 4, FirstName: "A CONFIRMAR"}},
+	}
+
+	byKey := teachersByKey(teachers)
+
+	if id, ok := byKey["juan perez"]; !ok || id != 1 {
+		t.Errorf("juan perez = %v, %v; want 1, true", id, ok)
+	}
+	if _, ok := byKey["ana gomez"]; ok {
+		t.Errorf("ana gomez is shared by two teachers and should not be linked")
+	}
+	if len(byKey) != 1 {
+		t.Errorf("len(byKey) = %d; want 1", len(byKey))
+	}
+}
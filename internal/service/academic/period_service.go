@@ -2,6 +2,8 @@ package academic
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/elias-gill/poliplanner2/internal/config/timezone"
@@ -10,6 +12,11 @@ import (
 	academicRepo "github.com/elias-gill/poliplanner2/internal/repository/academic"
 )
 
+var (
+	ErrPeriodNotFound    = errors.New("period not found")
+	ErrInvalidBoundaries = errors.New("invalid period boundaries")
+)
+
 type PeriodService struct {
 	periodRepo academicRepo.PeriodRepository
 }
@@ -20,8 +27,36 @@ func NewPeriodService(periodRepo academicRepo.PeriodRepository) *PeriodService {
 	}
 }
 
+// CalculateCurrentPeriod returns the period of today. Periods configured by an admin are
+// preferred; outside of them the semester is calculated from the date.
 func (p *PeriodService) CalculateCurrentPeriod(ctx context.Context) (academic.PeriodID, error) {
-	period := p.NewPeriodFromTime(time.Now().In(timezone.ParaguayTZ))
+	return p.ResolvePeriod(ctx, time.Now().In(timezone.ParaguayTZ), 0)
+}
+
+// ResolvePeriod returns the period that contains t, creating it when needed. When no admin
+// configured a period for t, the given semester is used, or the one calculated from the date
+// if it is zero.
+//
+// A semester given that differs from the one of the configured period wins: sources name
+// the semester they are for, and the ones of the next semester are published during the
+// finals of the current one. The period of that semester in the year of t is used instead.
+func (p *PeriodService) ResolvePeriod(
+	ctx context.Context,
+	t time.Time,
+	semester academic.YearSemester,
+) (academic.PeriodID, error) {
+	configured, err := p.PeriodAt(ctx, t)
+	if err != nil {
+		return -1, err
+	}
+	if configured != nil && (semester == 0 || configured.Semester == semester) {
+		return configured.ID, nil
+	}
+
+	period := p.NewPeriodFromTime(t)
+	if semester != 0 {
+		period.Semester = semester
+	}
 
 	id, err := p.periodRepo.Upsert(ctx, period)
 	if err != nil {
@@ -31,6 +66,92 @@ func (p *PeriodService) CalculateCurrentPeriod(ctx context.Context) (academic.Pe
 	return id, nil
 }
 
+// PeriodAt returns the period configured by an admin whose span contains t, or nil when
+// there is none.
+func (p *PeriodService) PeriodAt(ctx context.Context, t time.Time) (*academic.Period, error) {
+	period, err := p.periodRepo.FindByDate(ctx, t)
+	if err != nil {
+		return nil, fmt.Errorf("find period at %v: %w", t, err)
+	}
+
+	return period, nil
+}
+
+// GetPeriod returns the period with the given ID.
+func (p *PeriodService) GetPeriod(ctx context.Context, id academic.PeriodID) (*academic.Period, error) {
+	period, err := p.periodRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get period %v: %w", id, err)
+	}
+	if period == nil {
+		return nil, ErrPeriodNotFound
+	}
+
+	return period, nil
+}
+
+// ListPeriods returns the periods with imported courses, from the newest to the oldest. The
+// current period is always listed first, even before its first import.
+func (p *PeriodService) ListPeriods(ctx context.Context) ([]academic.Period, error) {
+	current, err := p.CalculateCurrentPeriod(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	periods, err := p.periodRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, period := range periods {
+		if period.ID == current {
+			return periods, nil
+		}
+	}
+
+	currentPeriod, err := p.GetPeriod(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]academic.Period{*currentPeriod}, periods...), nil
+}
+
+// ListAllPeriods returns every known period, with or without courses, from the newest to the
+// oldest. Used by the admin screen to load the period boundaries.
+func (p *PeriodService) ListAllPeriods(ctx context.Context) ([]academic.Period, error) {
+	if _, err := p.CalculateCurrentPeriod(ctx); err != nil {
+		return nil, err
+	}
+
+	return p.periodRepo.ListAll(ctx)
+}
+
+// SaveBoundaries stores the dates of the period identified by its year and semester,
+// creating it when it does not exist yet.
+func (p *PeriodService) SaveBoundaries(ctx context.Context, period academic.Period) (academic.PeriodID, error) {
+	others, err := p.periodRepo.ListAll(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	if err := validateBoundaries(period, others); err != nil {
+		return -1, err
+	}
+
+	id, err := p.periodRepo.Upsert(ctx, period)
+	if err != nil {
+		return -1, fmt.Errorf("upsert period: %w", err)
+	}
+
+	period.ID = id
+	if err := p.periodRepo.UpdateBoundaries(ctx, period); err != nil {
+		return -1, err
+	}
+
+	return id, nil
+}
+
 func (p *PeriodService) NewPeriodFromTime(t time.Time) academic.Period {
 	return academic.Period{
 		Year:     t.Year(),
@@ -38,6 +159,55 @@ func (p *PeriodService) NewPeriodFromTime(t time.Time) academic.Period {
 	}
 }
 
+// validateBoundaries checks that the span is set, that every other range is inside of it and
+// that it does not overlap the span of another period.
+func validateBoundaries(period academic.Period, others []academic.Period) error {
+	if period.Semester != academic.FirstSemester && period.Semester != academic.SecondSemester {
+		return fmt.Errorf("%w: semester must be 1 or 2", ErrInvalidBoundaries)
+	}
+
+	ranges := []struct {
+		name string
+		r    academic.DateRange
+	}{
+		{"period", period.Span},
+		{"classes", period.Classes},
+		{"partials", period.Partials},
+		{"finals", period.Finals},
+	}
+
+	for _, rng := range ranges {
+		if rng.r.Start.IsZero() != rng.r.End.IsZero() {
+			return fmt.Errorf("%w: %s needs both start and end dates", ErrInvalidBoundaries, rng.name)
+		}
+		if rng.r.End.Before(rng.r.Start) {
+			return fmt.Errorf("%w: %s ends before it starts", ErrInvalidBoundaries, rng.name)
+		}
+	}
+
+	if period.Span.IsZero() {
+		return fmt.Errorf("%w: the period dates are required", ErrInvalidBoundaries)
+	}
+
+	for _, rng := range ranges[1:] {
+		if !rng.r.IsZero() && !period.Span.Includes(rng.r) {
+			return fmt.Errorf("%w: %s must be inside the period dates", ErrInvalidBoundaries, rng.name)
+		}
+	}
+
+	for _, other := range others {
+		if other.Year == period.Year && other.Semester == period.Semester {
+			continue
+		}
+		if period.Span.Overlaps(other.Span) {
+			return fmt.Errorf("%w: overlaps with %s", ErrInvalidBoundaries, other)
+		}
+	}
+
+	return nil
+}
+
+// calculateSemester is the fallback for the dates without a period configured by an admin.
 func calculateSemester(t time.Time) academic.YearSemester {
 	if t.Month() > time.July || (t.Month() == time.July && t.Day() >= 23) {
 		return academic.SecondSemester // 23 de July -> December
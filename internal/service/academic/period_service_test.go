@@ -0,0 +1,223 @@
+package academic
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+// fakePeriodRepository keeps the periods in memory. IDs are the position in the slice plus one.
+type fakePeriodRepository struct {
+	periods []academic.Period
+
+	// Periods with imported courses, the only ones listed by List
+	withCourses map[academic.PeriodID]bool
+}
+
+func (f *fakePeriodRepository) Upsert(ctx context.Context, p academic.Period) (academic.PeriodID, error) {
+	for _, existing := range f.periods {
+		if existing.Year == p.Year && existing.Semester == p.Semester {
+			return existing.ID, nil
+		}
+	}
+	p.ID = academic.PeriodID(len(f.periods) + 1)
+	f.periods = append(f.periods, p)
+	return p.ID, nil
+}
+
+func (f *fakePeriodRepository) GetByID(ctx context.Context, id academic.PeriodID) (*academic.Period, error) {
+	for _, p := range f.periods {
+		if p.ID == id {
+			return &p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakePeriodRepository) List(ctx context.Context) ([]academic.Period, error) {
+	var periods []academic.Period
+	for _, p := range f.periods {
+		if f.withCourses[p.ID] {
+			periods = append(periods, p)
+		}
+	}
+	return periods, nil
+}
+
+func (f *fakePeriodRepository) ListAll(ctx context.Context) ([]academic.Period, error) {
+	return f.periods, nil
+}
+
+func (f *fakePeriodRepository) FindByDate(ctx context.Context, t time.Time) (*academic.Period, error) {
+	for _, p := range f.periods {
+		if p.Span.Contains(t) {
+			return &p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakePeriodRepository) UpdateBoundaries(ctx context.Context, p academic.Period) error {
+	f.periods[p.ID-1] = p
+	return nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, timezone.ParaguayTZ)
+}
+
+func TestResolvePeriod(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakePeriodRepository{}
+	service := NewPeriodService(repo)
+
+	// The second semester of 2026 starts on August 3 instead of July 23
+	first, err := service.SaveBoundaries(ctx, academic.Period{
+		Year:     2026,
+		Semester: academic.FirstSemester,
+		Span:     academic.DateRange{Start: date(2026, time.February, 9), End: date(2026, time.July, 24)},
+	})
+	if err != nil {
+		t.Fatalf("SaveBoundaries() error = %v", err)
+	}
+	second, err := service.SaveBoundaries(ctx, academic.Period{
+		Year:     2026,
+		Semester: academic.SecondSemester,
+		Span:     academic.DateRange{Start: date(2026, time.August, 3), End: date(2026, time.December, 18)},
+	})
+	if err != nil {
+		t.Fatalf("SaveBoundaries() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		guess    academic.YearSemester
+		expected academic.Period
+	}{
+		{"calculated before the cutoff", date(2025, time.July, 10), 0, academic.Period{Year: 2025, Semester: academic.FirstSemester}},
+		{"calculated after the cutoff", date(2026, time.July, 28), 0, academic.Period{Year: 2026, Semester: academic.SecondSemester}},
+		{"configured span", date(2026, time.August, 3), 0, academic.Period{ID: second}},
+		{"configured span of the guess", date(2026, time.December, 18).Add(20 * time.Hour), academic.SecondSemester, academic.Period{ID: second}},
+		// A workbook of the second semester published during the finals of the first one
+		{"guess of the next semester", date(2026, time.July, 10), academic.SecondSemester, academic.Period{ID: second}},
+		{"guess of the previous semester", date(2026, time.August, 5), academic.FirstSemester, academic.Period{ID: first}},
+		{"guess outside of the span", date(2027, time.January, 10), academic.SecondSemester, academic.Period{Year: 2027, Semester: academic.SecondSemester}},
+	}
+
+	for _, tc := range tests {
+		id, err := service.ResolvePeriod(ctx, tc.at, tc.guess)
+		if err != nil {
+			t.Fatalf("%s: ResolvePeriod() error = %v", tc.name, err)
+		}
+
+		got, _ := repo.GetByID(ctx, id)
+		if tc.expected.ID != 0 {
+			if id != tc.expected.ID {
+				t.Errorf("%s: ResolvePeriod() = %v; want the configured period %v", tc.name, id, tc.expected.ID)
+			}
+			continue
+		}
+		if got.Year != tc.expected.Year || got.Semester != tc.expected.Semester {
+			t.Errorf("%s: ResolvePeriod() = %s; want %s", tc.name, got, tc.expected)
+		}
+	}
+}
+
+func TestValidateBoundaries(t *testing.T) {
+	first := academic.Period{
+		Year:     2026,
+		Semester: academic.FirstSemester,
+		Span:     academic.DateRange{Start: date(2026, time.February, 9), End: date(2026, time.July, 17)},
+	}
+	others := []academic.Period{first}
+
+	valid := academic.Period{
+		Year:     2026,
+		Semester: academic.SecondSemester,
+		Span:     academic.DateRange{Start: date(2026, time.August, 3), End: date(2026, time.December, 18)},
+		Classes:  academic.DateRange{Start: date(2026, time.August, 3), End: date(2026, time.November, 20)},
+		Partials: academic.DateRange{Start: date(2026, time.September, 21), End: date(2026, time.October, 2)},
+		Finals:   academic.DateRange{Start: date(2026, time.November, 23), End: date(2026, time.December, 18)},
+	}
+	if err := validateBoundaries(valid, others); err != nil {
+		t.Fatalf("validateBoundaries(valid) error = %v", err)
+	}
+	if weeks := valid.ClassWeeks(); weeks != 16 {
+		t.Errorf("ClassWeeks() = %d; want 16", weeks)
+	}
+
+	// The same period can be saved again with new dates
+	moved := first
+	moved.Span.End = date(2026, time.July, 24)
+	if err := validateBoundaries(moved, others); err != nil {
+		t.Errorf("validateBoundaries(first moved) error = %v", err)
+	}
+
+	invalid := map[string]func(p *academic.Period){
+		"missing span":     func(p *academic.Period) { p.Span = academic.DateRange{} },
+		"half range":       func(p *academic.Period) { p.Finals.End = time.Time{} },
+		"reversed range":   func(p *academic.Period) { p.Partials.Start, p.Partials.End = p.Partials.End, p.Partials.Start },
+		"outside the span": func(p *academic.Period) { p.Finals.End = date(2026, time.December, 22) },
+		"overlap":          func(p *academic.Period) { p.Span.Start = date(2026, time.July, 17) },
+		"bad semester":     func(p *academic.Period) { p.Semester = 3 },
+	}
+
+	for name, mutate := range invalid {
+		p := valid
+		mutate(&p)
+		if err := validateBoundaries(p, others); !errors.Is(err, ErrInvalidBoundaries) {
+			t.Errorf("%s: validateBoundaries() error = %v; want ErrInvalidBoundaries", name, err)
+		}
+	}
+}
+
+func TestListPeriods(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakePeriodRepository{withCourses: make(map[academic.PeriodID]bool)}
+	service := NewPeriodService(repo)
+
+	old, _ := repo.Upsert(ctx, academic.Period{Year: time.Now().Year() - 1, Semester: academic.FirstSemester})
+	repo.withCourses[old] = true
+
+	current, err := service.CalculateCurrentPeriod(ctx)
+	if err != nil {
+		t.Fatalf("CalculateCurrentPeriod() error = %v", err)
+	}
+
+	ids := func(periods []academic.Period) []academic.PeriodID {
+		var ids []academic.PeriodID
+		for _, p := range periods {
+			ids = append(ids, p.ID)
+		}
+		return ids
+	}
+
+	// The current period is listed before its first import
+	periods, err := service.ListPeriods(ctx)
+	if err != nil {
+		t.Fatalf("ListPeriods() error = %v", err)
+	}
+	if got := ids(periods); !slices.Equal(got, []academic.PeriodID{current, old}) {
+		t.Errorf("ListPeriods() = %v; want the current period %d and then %d", got, current, old)
+	}
+
+	// And only once after it
+	repo.withCourses[current] = true
+	periods, err = service.ListPeriods(ctx)
+	if err != nil {
+		t.Fatalf("ListPeriods() error = %v", err)
+	}
+	if got := ids(periods); len(got) != 2 || !slices.Contains(got, current) {
+		t.Errorf("ListPeriods() = %v; want %d and %d once each", got, old, current)
+	}
+
+	if _, err := service.GetPeriod(ctx, 99); !errors.Is(err, ErrPeriodNotFound) {
+		t.Errorf("GetPeriod(unknown) error = %v; want ErrPeriodNotFound", err)
+	}
+}
@@ -0,0 +1,287 @@
+package academic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	academicRepo "github.com/elias-gill/poliplanner2/internal/repository/academic"
+)
+
+var ErrRoomNotFound = errors.New("room not found")
+
+type RoomService struct {
+	roomRepository academicRepo.RoomRepository
+	periodService  *PeriodService
+}
+
+func NewRoomService(roomRepo academicRepo.RoomRepository, periodService *PeriodService) *RoomService {
+	return &RoomService{
+		roomRepository: roomRepo,
+		periodService:  periodService,
+	}
+}
+
+// ListRooms returns every physical room used by a class or an exam in the current period.
+func (s *RoomService) ListRooms(ctx context.Context) ([]academic.RoomSummaryView, error) {
+	sessions, exams, err := s.loadCurrentPeriod(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildRoomIndex(sessions, exams), nil
+}
+
+// GetRoom returns the weekly occupancy and the exams of the given room code.
+func (s *RoomService) GetRoom(ctx context.Context, code string) (*academic.RoomDetailView, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+
+	sessions, exams, err := s.loadCurrentPeriod(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var room *academic.RoomSummaryView
+	for _, r := range buildRoomIndex(sessions, exams) {
+		if r.Code == code {
+			room = &r
+			break
+		}
+	}
+	if room == nil {
+		return nil, ErrRoomNotFound
+	}
+
+	detail := &academic.RoomDetailView{Room: *room}
+	for day := academic.Monday; day <= academic.Saturday; day++ {
+		detail.Weekly = append(detail.Weekly, academic.RoomDayView{Day: day})
+	}
+
+	// Sessions are already sorted by day and start hour
+	for _, session := range sessions {
+		if session.Room.Code() != code || session.Day < academic.Monday || session.Day > academic.Saturday {
+			continue
+		}
+		idx := int(session.Day - academic.Monday)
+		detail.Weekly[idx].Sessions = append(detail.Weekly[idx].Sessions, session)
+	}
+
+	for _, exam := range exams {
+		if exam.Room.Code() == code {
+			detail.Exams = append(detail.Exams, exam)
+		}
+	}
+
+	return detail, nil
+}
+
+// FreeRooms returns the rooms that have no class nor exam at the given moment, along with
+// the hour until which each one stays free.
+func (s *RoomService) FreeRooms(ctx context.Context, at time.Time) ([]academic.FreeRoomView, error) {
+	sessions, exams, err := s.loadCurrentPeriod(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return freeRooms(sessions, exams, at), nil
+}
+
+// freeRooms returns the rooms of the index that are not busy at the given moment. A class
+// or exam that ends at that moment leaves its room free, one that starts then takes it.
+func freeRooms(sessions []academic.RoomSessionView, exams []academic.RoomExamView, at time.Time) []academic.FreeRoomView {
+	// Sunday maps to 0, wich is not a valid WeekDay, so no session matches
+	day := academic.WeekDay(at.Weekday())
+	now := minutesOfDay(at)
+
+	busy := make(map[string]bool)
+	nextStart := make(map[string]int)
+
+	markNext := func(code string, start int) {
+		if prev, ok := nextStart[code]; !ok || start < prev {
+			nextStart[code] = start
+		}
+	}
+
+	for _, session := range sessions {
+		if session.Day != day {
+			continue
+		}
+
+		code := session.Room.Code()
+		start := minutesOfDay(*session.Time.Start)
+		end := minutesOfDay(*session.Time.End)
+
+		if start <= now && now < end {
+			busy[code] = true
+		} else if start > now {
+			markNext(code, start)
+		}
+	}
+
+	for _, exam := range exams {
+		if exam.Date == nil || !sameDate(*exam.Date, at) {
+			continue
+		}
+
+		code := exam.Room.Code()
+		start := minutesOfDay(*exam.Date)
+		end := start + int(academic.ExamDuration/time.Minute)
+
+		if start <= now && now < end {
+			busy[code] = true
+		} else if start > now {
+			markNext(code, start)
+		}
+	}
+
+	var free []academic.FreeRoomView
+	for _, room := range buildRoomIndex(sessions, exams) {
+		if busy[room.Code] {
+			continue
+		}
+
+		entry := academic.FreeRoomView{Room: room}
+		if start, ok := nextStart[room.Code]; ok {
+			until := time.Date(at.Year(), at.Month(), at.Day(), start/60, start%60, 0, 0, at.Location())
+			entry.Until = &until
+		}
+		free = append(free, entry)
+	}
+
+	return free
+}
+
+func (s *RoomService) loadCurrentPeriod(ctx context.Context) ([]academic.RoomSessionView, []academic.RoomExamView, error) {
+	period, err := s.periodService.CalculateCurrentPeriod(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sessions, err := s.roomRepository.ListSessions(ctx, period)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get room sessions: %w", err)
+	}
+
+	exams, err := s.roomRepository.ListExams(ctx, period)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get room exams: %w", err)
+	}
+
+	return uniqueSessions(sessions), uniqueExams(exams), nil
+}
+
+// ==================
+//  Helper functions
+// ==================
+
+// uniqueSessions removes repeated sessions. The same class is listed once per career that
+// shares the subject, so we consider equal every session with the same room, section and
+// time slot.
+func uniqueSessions(sessions []academic.RoomSessionView) []academic.RoomSessionView {
+	seen := make(map[string]struct{}, len(sessions))
+	unique := sessions[:0]
+
+	for _, session := range sessions {
+		key := fmt.Sprintf("%s|%s|%d|%s", session.Room.Code(), session.Section, session.Day, session.Time)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		unique = append(unique, session)
+	}
+
+	return unique
+}
+
+// uniqueExams removes repeated exams, same criteria as uniqueSessions.
+func uniqueExams(exams []academic.RoomExamView) []academic.RoomExamView {
+	seen := make(map[string]struct{}, len(exams))
+	unique := exams[:0]
+
+	for _, exam := range exams {
+		date := ""
+		if exam.Date != nil {
+			date = exam.Date.Format("2006-01-02 15:04")
+		}
+
+		key := fmt.Sprintf("%s|%s|%s|%d|%s", exam.Room.Code(), exam.Section, exam.Type, exam.Instance, date)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		unique = append(unique, exam)
+	}
+
+	return unique
+}
+
+func buildRoomIndex(sessions []academic.RoomSessionView, exams []academic.RoomExamView) []academic.RoomSummaryView {
+	index := make(map[string]*academic.RoomSummaryView)
+
+	get := func(loc academic.Location) *academic.RoomSummaryView {
+		code := loc.Code()
+		room, ok := index[code]
+		if !ok {
+			name := loc.Raw
+			if loc.Building != "" && loc.Number != "" {
+				name = code
+			}
+			room = &academic.RoomSummaryView{Code: code, Name: name, Building: loc.Building}
+			index[code] = room
+		}
+		return room
+	}
+
+	for _, session := range sessions {
+		get(session.Room).Sessions++
+	}
+	for _, exam := range exams {
+		get(exam.Room).Exams++
+	}
+
+	rooms := make([]academic.RoomSummaryView, 0, len(index))
+	for _, room := range index {
+		if room.Code == "" {
+			continue
+		}
+		rooms = append(rooms, *room)
+	}
+
+	sort.Slice(rooms, func(i, j int) bool {
+		return roomLess(rooms[i], rooms[j])
+	})
+
+	return rooms
+}
+
+// roomLess sorts rooms by building and then by room number, comparing numbers numerically
+// so "A-2" comes before "A-10". Rooms without a building go last.
+func roomLess(a, b academic.RoomSummaryView) bool {
+	if (a.Building == "") != (b.Building == "") {
+		return a.Building != ""
+	}
+	if a.Building != b.Building {
+		return a.Building < b.Building
+	}
+
+	numA, errA := strconv.Atoi(strings.TrimLeft(strings.TrimPrefix(a.Code, a.Building+"-"), "0"))
+	numB, errB := strconv.Atoi(strings.TrimLeft(strings.TrimPrefix(b.Code, b.Building+"-"), "0"))
+	if errA == nil && errB == nil && numA != numB {
+		return numA < numB
+	}
+
+	return a.Code < b.Code
+}
+
+func minutesOfDay(t time.Time) int {
+	return t.Hour()*60 + t.Minute()
+}
+
+func sameDate(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
@@ -0,0 +1,132 @@
+package academic
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+// room builds a physical location, splitting the parsed codes of the tests (eg: "A-12").
+func room(code string) academic.Location {
+	loc := academic.Location{Raw: code, Kind: academic.RoomPhysical}
+	if building, number, ok := strings.Cut(code, "-"); ok {
+		loc.Building, loc.Number = building, number
+	}
+	return loc
+}
+
+func session(code, section string, day academic.WeekDay, start, end string) academic.RoomSessionView {
+	s, _ := time.Parse("15:04", start)
+	e, _ := time.Parse("15:04", end)
+	return academic.RoomSessionView{
+		Room:    room(code),
+		Section: section,
+		Day:     day,
+		Time:    academic.TimeSlot{Start: &s, End: &e},
+	}
+}
+
+func TestUniqueSessions(t *testing.T) {
+	sessions := []academic.RoomSessionView{
+		session("A-12", "TQ", academic.Monday, "07:00", "08:30"),
+		// Same class listed for another career
+		session("A-12", "TQ", academic.Monday, "07:00", "08:30"),
+		// Another section, day or hour on the same room
+		session("A-12", "TR", academic.Monday, "07:00", "08:30"),
+		session("A-12", "TQ", academic.Tuesday, "07:00", "08:30"),
+		session("A-12", "TQ", academic.Monday, "08:30", "10:00"),
+	}
+
+	got := uniqueSessions(sessions)
+
+	if len(got) != 4 {
+		t.Fatalf("uniqueSessions() returned %d sessions; want 4: %+v", len(got), got)
+	}
+	if got[1].Section != "TR" {
+		t.Errorf("second session = %+v; want the order kept", got[1])
+	}
+}
+
+func TestRoomIndexOrder(t *testing.T) {
+	sessions := []academic.RoomSessionView{
+		session("Aula Magna", "TQ", academic.Monday, "07:00", "08:30"),
+		session("B-1", "TQ", academic.Monday, "07:00", "08:30"),
+		session("A-10", "TQ", academic.Monday, "07:00", "08:30"),
+		session("A-2", "TQ", academic.Monday, "07:00", "08:30"),
+		session("A-10", "TR", academic.Tuesday, "07:00", "08:30"),
+		session("A-03", "TQ", academic.Monday, "07:00", "08:30"),
+		session("Cetuna", "TQ", academic.Monday, "07:00", "08:30"),
+	}
+
+	rooms := buildRoomIndex(sessions, nil)
+
+	want := []string{"A-2", "A-03", "A-10", "B-1", "AULA MAGNA", "CETUNA"}
+	if len(rooms) != len(want) {
+		t.Fatalf("buildRoomIndex() returned %d rooms; want %d: %+v", len(rooms), len(want), rooms)
+	}
+	for i, code := range want {
+		if rooms[i].Code != code {
+			t.Errorf("room %d = %s; want %s", i, rooms[i].Code, code)
+		}
+	}
+	if rooms[2].Sessions != 2 {
+		t.Errorf("A-10 has %d sessions; want 2", rooms[2].Sessions)
+	}
+}
+
+func TestFreeRooms(t *testing.T) {
+	// Monday, June 8 2026
+	at := time.Date(2026, time.June, 8, 8, 30, 0, 0, timezone.ParaguayTZ)
+	examAt := func(day, hour int) *time.Time {
+		d := time.Date(2026, time.June, day, hour, 0, 0, 0, timezone.ParaguayTZ)
+		return &d
+	}
+
+	sessions := []academic.RoomSessionView{
+		// Ends right at that moment
+		session("A-1", "TQ", academic.Monday, "07:00", "08:30"),
+		// Starts right at that moment
+		session("A-2", "TQ", academic.Monday, "08:30", "10:00"),
+		// Later that day, the earliest one sets until when the room is free
+		session("A-3", "TQ", academic.Monday, "14:00", "15:30"),
+		session("A-3", "TQ", academic.Monday, "10:00", "11:30"),
+		// On another day
+		session("A-4", "TQ", academic.Tuesday, "08:00", "09:30"),
+	}
+	exams := []academic.RoomExamView{
+		// Keeps the room busy for the length of an exam
+		{Room: room("B-1"), Date: examAt(8, 7)},
+		// Ended before, the exams only have a start hour
+		{Room: room("B-2"), Date: examAt(8, 6)},
+		// Same hour of another day
+		{Room: room("B-3"), Date: examAt(9, 8)},
+	}
+
+	got := freeRooms(sessions, exams, at)
+
+	want := map[string]string{"A-1": "", "A-3": "10:00", "A-4": "", "B-2": "", "B-3": ""}
+	if len(got) != len(want) {
+		t.Fatalf("freeRooms() returned %d rooms; want %d: %+v", len(got), len(want), got)
+	}
+	for _, free := range got {
+		until, ok := want[free.Room.Code]
+		if !ok {
+			t.Errorf("%s is free; want it busy", free.Room.Code)
+			continue
+		}
+
+		gotUntil := ""
+		if free.Until != nil {
+			gotUntil = free.Until.Format("15:04")
+			if !sameDate(*free.Until, at) {
+				t.Errorf("%s is free until %v; want the same day", free.Room.Code, *free.Until)
+			}
+		}
+		if gotUntil != until {
+			t.Errorf("%s is free until %q; want %q", free.Room.Code, gotUntil, until)
+		}
+	}
+}
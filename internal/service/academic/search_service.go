@@ -0,0 +1,128 @@
+package academic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	"github.com/elias-gill/poliplanner2/internal/repository"
+	academicRepo "github.com/elias-gill/poliplanner2/internal/repository/academic"
+)
+
+const (
+	// searchLimit bounds the matches read from the index, which are then split by kind
+	searchLimit = 200
+	// searchGroupLimit is the amount of results listed on each group
+	searchGroupLimit = 8
+)
+
+type SearchService struct {
+	searchRepository academicRepo.SearchRepository
+	txManager        repository.TxManager
+	periodService    *PeriodService
+}
+
+func NewSearchService(
+	searchRepo academicRepo.SearchRepository,
+	txManager repository.TxManager,
+	periodService *PeriodService,
+) *SearchService {
+	return &SearchService{
+		searchRepository: searchRepo,
+		txManager:        txManager,
+		periodService:    periodService,
+	}
+}
+
+// RebuildIndex replaces the search index with the data of the current period. Runs after each
+// successful import and on startup.
+func (s *SearchService) RebuildIndex(ctx context.Context) error {
+	period, err := s.periodService.CalculateCurrentPeriod(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		return s.searchRepository.Rebuild(ctx, period)
+	})
+	if err != nil {
+		return fmt.Errorf("rebuild search index: %w", err)
+	}
+
+	return nil
+}
+
+// Search looks for courses, subjects, teachers and rooms matching every word of the given
+// text, ignoring accents and case. Words match by prefix, so partial words while typing
+// already return results.
+func (s *SearchService) Search(ctx context.Context, text string) (*academic.SearchResultsView, error) {
+	view := &academic.SearchResultsView{Query: text}
+
+	query := buildSearchQuery(text)
+	if query == "" {
+		return view, nil
+	}
+
+	results, err := s.searchRepository.Search(ctx, query, searchLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	view.Groups = groupSearchResults(results, searchGroupLimit)
+
+	return view, nil
+}
+
+// ==================
+//  Helper functions
+// ==================
+
+// buildSearchQuery turns free text into an FTS5 query where every word is a quoted prefix
+// term (eg: "Cálculo II" -> `"calculo"* "ii"*`). Quoting keeps the user input from being
+// parsed as FTS5 operators.
+func buildSearchQuery(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, academic.NormalizeName(text))
+
+	words := strings.Fields(text)
+	for i, w := range words {
+		words[i] = `"` + w + `"*`
+	}
+
+	return strings.Join(words, " ")
+}
+
+// groupSearchResults splits the ranked results by kind, keeping the rank order within each
+// group. Groups are sorted by their best result, so searching a teacher name lists teachers
+// before the courses they teach. Teacher placeholders like "A CONFIRMAR" are skipped.
+func groupSearchResults(results []academic.SearchResult, limit int) []academic.SearchGroupView {
+	byKind := make(map[academic.SearchKind]int)
+	var groups []academic.SearchGroupView
+
+	for _, res := range results {
+		if res.Kind == academic.SearchTeacher && (academic.Teacher{FirstName: res.Title}).IsPlaceholder() {
+			continue
+		}
+
+		i, ok := byKind[res.Kind]
+		if !ok {
+			i = len(groups)
+			byKind[res.Kind] = i
+			groups = append(groups, academic.SearchGroupView{Kind: res.Kind})
+		}
+
+		if len(groups[i].Results) == limit {
+			groups[i].More = true
+			continue
+		}
+		groups[i].Results = append(groups[i].Results, res)
+	}
+
+	return groups
+}
@@ -0,0 +1,51 @@
+package academic
+
+import (
+	"testing"
+
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+func TestBuildSearchQuery(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"Cálculo II", `"calculo"* "ii"*`},
+		{"  PÉREZ  ", `"perez"*`},
+		{`algebra" OR "fisica`, `"algebra"* "or"* "fisica"*`},
+		{"F-15", `"f"* "15"*`},
+		{`")(*`, ""},
+		{"", ""},
+	}
+
+	for _, tc := range tests {
+		if got := buildSearchQuery(tc.text); got != tc.expected {
+			t.Errorf("buildSearchQuery(%q) = %q; want %q", tc.text, got, tc.expected)
+		}
+	}
+}
+
+func TestGroupSearchResults(t *testing.T) {
+	results := []academic.SearchResult{
+		{Kind: academic.SearchTeacher, Ref: "1", Title: "Juan Pérez"},
+		{Kind: academic.SearchCourse, Ref: "10", Title: "Cálculo I"},
+		{Kind: academic.SearchTeacher, Ref: "2", Title: "A CONFIRMAR"},
+		{Kind: academic.SearchCourse, Ref: "11", Title: "Cálculo II"},
+		{Kind: academic.SearchCourse, Ref: "12", Title: "Cálculo III"},
+	}
+
+	groups := groupSearchResults(results, 2)
+
+	if len(groups) != 2 {
+		t.Fatalf("groupSearchResults() returned %d groups; want 2", len(groups))
+	}
+
+	teachers, courses := groups[0], groups[1]
+	if teachers.Kind != academic.SearchTeacher || len(teachers.Results) != 1 || teachers.More {
+		t.Errorf("first group = %+v; want only Juan Pérez (placeholders skipped)", teachers)
+	}
+	if courses.Kind != academic.SearchCourse || len(courses.Results) != 2 || !courses.More {
+		t.Errorf("second group = %+v; want 2 courses and more results", courses)
+	}
+}
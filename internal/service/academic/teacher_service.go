@@ -0,0 +1,344 @@
+package academic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	"github.com/elias-gill/poliplanner2/internal/repository"
+	academicRepo "github.com/elias-gill/poliplanner2/internal/repository/academic"
+)
+
+var (
+	ErrTeacherNotFound = errors.New("teacher not found")
+	ErrInvalidMerge    = errors.New("invalid teacher merge")
+)
+
+type TeacherService struct {
+	teacherRepository academicRepo.TeacherRepository
+	courseRepository  academicRepo.CourseRepository
+	txManager         repository.TxManager
+	periodService     *PeriodService
+}
+
+func NewTeacherService(
+	teacherRepo academicRepo.TeacherRepository,
+	courseRepo academicRepo.CourseRepository,
+	txManager repository.TxManager,
+	periodService *PeriodService,
+) *TeacherService {
+	return &TeacherService{
+		teacherRepository: teacherRepo,
+		courseRepository:  courseRepo,
+		txManager:         txManager,
+		periodService:     periodService,
+	}
+}
+
+// ListTeachers returns the teachers of the current period whose name or email contains the
+// given search text. An empty search returns every teacher. Placeholders like "A CONFIRMAR"
+// are never listed.
+func (s *TeacherService) ListTeachers(ctx context.Context, search string) ([]academic.TeacherSummaryView, error) {
+	period, err := s.periodService.CalculateCurrentPeriod(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	teachers, err := s.teacherRepository.ListByPeriod(ctx, period)
+	if err != nil {
+		return nil, fmt.Errorf("list teachers: %w", err)
+	}
+
+	search = academic.NormalizeName(search)
+
+	filtered := teachers[:0]
+	for _, t := range teachers {
+		if t.Teacher.IsPlaceholder() {
+			continue
+		}
+
+		if search != "" {
+			haystack := academic.NormalizeName(t.Teacher.FullName() + " " + t.Teacher.Email)
+			if !strings.Contains(haystack, search) {
+				continue
+			}
+		}
+
+		filtered = append(filtered, t)
+	}
+
+	return filtered, nil
+}
+
+// GetTeacher returns the profile of a teacher, with the courses taught on the current period
+// and the exam committees where the teacher sits on.
+func (s *TeacherService) GetTeacher(ctx context.Context, id academic.TeacherID) (*academic.TeacherDetailView, error) {
+	teacher, err := s.teacherRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get teacher %v: %w", id, err)
+	}
+	if teacher == nil || teacher.IsPlaceholder() {
+		return nil, ErrTeacherNotFound
+	}
+
+	period, err := s.periodService.CalculateCurrentPeriod(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	courses, err := s.teacherRepository.ListCourses(ctx, id, period)
+	if err != nil {
+		return nil, fmt.Errorf("list courses of teacher %v: %w", id, err)
+	}
+
+	for i := range courses {
+		schedules, err := s.courseRepository.GetCourseSchedules(ctx, courses[i].CourseID)
+		if err != nil {
+			return nil, fmt.Errorf("get schedules for course %v: %w", courses[i].CourseID, err)
+		}
+
+		sort.Slice(schedules, func(a, b int) bool {
+			if schedules[a].Day != schedules[b].Day {
+				return schedules[a].Day < schedules[b].Day
+			}
+			if schedules[a].Time.Start == nil || schedules[b].Time.Start == nil {
+				return schedules[a].Time.Start != nil
+			}
+			return schedules[a].Time.Start.Before(*schedules[b].Time.Start)
+		})
+
+		courses[i].Schedules = schedules
+	}
+
+	committees, err := s.teacherRepository.ListCommittees(ctx, period)
+	if err != nil {
+		return nil, fmt.Errorf("list committees: %w", err)
+	}
+
+	return &academic.TeacherDetailView{
+		Teacher:    *teacher,
+		Courses:    courses,
+		Committees: matchCommittees(*teacher, committees),
+	}, nil
+}
+
+// ProposeMerges looks for teacher records that probably belong to the same person. Teachers
+// are upserted from free text on every import, so spelling, accents or a missing email end
+// up creating a new record.
+func (s *TeacherService) ProposeMerges(ctx context.Context) ([]academic.TeacherMergeProposal, error) {
+	teachers, err := s.teacherRepository.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list teachers: %w", err)
+	}
+
+	return proposeMerges(teachers), nil
+}
+
+// Merge moves every course of the duplicated teachers to the survivor and removes the
+// duplicated records.
+func (s *TeacherService) Merge(ctx context.Context, survivor academic.TeacherID, duplicates []academic.TeacherID) error {
+	if len(duplicates) == 0 {
+		return fmt.Errorf("%w: no duplicates selected", ErrInvalidMerge)
+	}
+	for _, dup := range duplicates {
+		if dup == survivor {
+			return fmt.Errorf("%w: teacher %v cannot be merged into itself", ErrInvalidMerge, dup)
+		}
+	}
+
+	teacher, err := s.teacherRepository.GetByID(ctx, survivor)
+	if err != nil {
+		return fmt.Errorf("get teacher %v: %w", survivor, err)
+	}
+	if teacher == nil {
+		return ErrTeacherNotFound
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		return s.teacherRepository.Merge(ctx, survivor, duplicates)
+	})
+	if err != nil {
+		return fmt.Errorf("merge teachers %v into %v: %w", duplicates, survivor, err)
+	}
+
+	return nil
+}
+
+// ==================
+//  Helper functions
+// ==================
+
+// proposeMerges groups the teachers that share the email or the normalized full name.
+// Teachers with the same name but different emails are only grouped when they also share
+// the title, as it can be two different people. Groups are transitive, so a teacher linked
+// by email to one record and by name to another ends up in a single proposal.
+func proposeMerges(teachers []academic.TeacherSummaryView) []academic.TeacherMergeProposal {
+	parent := make([]int, len(teachers))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	type link struct {
+		a, b   int
+		reason academic.MergeReason
+	}
+	var links []link
+
+	byEmail := make(map[string][]int)
+	byName := make(map[string][]int)
+
+	for i, t := range teachers {
+		if t.Teacher.IsPlaceholder() {
+			continue
+		}
+		if email := strings.ToLower(strings.TrimSpace(t.Teacher.Email)); email != "" {
+			byEmail[email] = append(byEmail[email], i)
+		}
+		name := academic.NormalizeName(t.Teacher.FullName())
+		byName[name] = append(byName[name], i)
+	}
+
+	for _, group := range byEmail {
+		for _, i := range group[1:] {
+			links = append(links, link{group[0], i, academic.MergeSameEmail})
+		}
+	}
+
+	for _, group := range byName {
+		for x := 0; x < len(group); x++ {
+			for y := x + 1; y < len(group); y++ {
+				a, b := teachers[group[x]].Teacher, teachers[group[y]].Teacher
+
+				emailA := strings.ToLower(strings.TrimSpace(a.Email))
+				emailB := strings.ToLower(strings.TrimSpace(b.Email))
+
+				switch {
+				case emailA == emailB:
+					// Already linked by email when not empty
+					if emailA == "" {
+						links = append(links, link{group[x], group[y], academic.MergeSameName})
+					}
+				case emailA == "" || emailB == "":
+					links = append(links, link{group[x], group[y], academic.MergeSameName})
+				case normalizeTitle(a.Title) != "" && normalizeTitle(a.Title) == normalizeTitle(b.Title):
+					links = append(links, link{group[x], group[y], academic.MergeSameNameAndTitle})
+				}
+			}
+		}
+	}
+
+	for _, l := range links {
+		parent[find(l.a)] = find(l.b)
+	}
+
+	members := make(map[int][]int)
+	reasons := make(map[int]map[academic.MergeReason]struct{})
+	for _, l := range links {
+		root := find(l.a)
+		if reasons[root] == nil {
+			reasons[root] = make(map[academic.MergeReason]struct{})
+		}
+		reasons[root][l.reason] = struct{}{}
+	}
+	for i := range teachers {
+		root := find(i)
+		if _, ok := reasons[root]; ok {
+			members[root] = append(members[root], i)
+		}
+	}
+
+	var proposals []academic.TeacherMergeProposal
+	for root, group := range members {
+		sort.Slice(group, func(x, y int) bool {
+			return survivorLess(teachers[group[x]], teachers[group[y]])
+		})
+
+		proposal := academic.TeacherMergeProposal{Survivor: teachers[group[0]]}
+		for _, i := range group[1:] {
+			proposal.Duplicates = append(proposal.Duplicates, teachers[i])
+		}
+		for reason := range reasons[root] {
+			proposal.Reasons = append(proposal.Reasons, reason)
+		}
+		sort.Slice(proposal.Reasons, func(x, y int) bool {
+			return proposal.Reasons[x] < proposal.Reasons[y]
+		})
+
+		proposals = append(proposals, proposal)
+	}
+
+	sort.Slice(proposals, func(x, y int) bool {
+		a, b := proposals[x].Survivor.Teacher, proposals[y].Survivor.Teacher
+		if a.LastName != b.LastName {
+			return a.LastName < b.LastName
+		}
+		return a.ID < b.ID
+	})
+
+	return proposals
+}
+
+// survivorLess prefers teachers with email, then the ones with more courses and finally the
+// oldest record.
+func survivorLess(a, b academic.TeacherSummaryView) bool {
+	if (a.Teacher.Email != "") != (b.Teacher.Email != "") {
+		return a.Teacher.Email != ""
+	}
+	if a.Courses != b.Courses {
+		return a.Courses > b.Courses
+	}
+	return a.Teacher.ID < b.Teacher.ID
+}
+
+// normalizeTitle makes "Ing." and "ing" equal.
+func normalizeTitle(title string) string {
+	return strings.ReplaceAll(academic.NormalizeName(title), ".", "")
+}
+
+// matchCommittees finds the committees where the teacher sits on. Committee members are free
+// text that usually includes the title (eg: "Ing. Juan Pérez"), so they are compared with the
+// same key used to link the examiners. A longer name (eg: with the second surname) is another
+// person.
+func matchCommittees(teacher academic.Teacher, courses []academic.CourseSummaryView) []academic.TeacherCommitteeView {
+	key := academic.CommitteeMemberKey(teacher.FullName())
+	if key == "" {
+		return nil
+	}
+
+	isMember := func(member string) bool {
+		return academic.CommitteeMemberKey(member) == key
+	}
+
+	var committees []academic.TeacherCommitteeView
+	for _, c := range courses {
+		var role academic.CommitteeRole
+		switch {
+		case isMember(c.Committee.President):
+			role = academic.CommitteePresident
+		case isMember(c.Committee.Member1), isMember(c.Committee.Member2):
+			role = academic.CommitteeMember
+		default:
+			continue
+		}
+
+		committees = append(committees, academic.TeacherCommitteeView{
+			CourseID:   c.ID,
+			CourseName: c.Name,
+			Section:    c.Section,
+			Role:       role,
+		})
+	}
+
+	return committees
+}
@@ -0,0 +1,114 @@
+package academic
+
+import (
+	"testing"
+
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+func TestMatchCommittees(t *testing.T) {
+	teacher := academic.Teacher{Title: "Ing.", FirstName: "Edgar", LastName: "Benítez Penayo"}
+
+	courses := []academic.CourseSummaryView{
+		{ID: 1, Name: "Algoritmo", Committee: academic.Committee{President: "Ms. Édgar Benitez Penayo"}},
+		{ID: 2, Name: "Cálculo", Committee: academic.Committee{President: "Lic. Ana Gómez", Member2: "Ing. EDGAR BENÍTEZ PENAYO"}},
+		{ID: 3, Name: "Física", Committee: academic.Committee{President: "Ing. Edgar Benítez Penayo", Member1: "Edgar Benítez Penayo"}},
+		// Other people whose names contain or are contained on the name of the teacher
+		{ID: 4, Name: "Química", Committee: academic.Committee{Member1: "Ing. Edgardo Benítez Penayo"}},
+		{ID: 5, Name: "Electricidad", Committee: academic.Committee{President: "Ing. Edgar Benítez Penayo Rolón"}},
+		{ID: 6, Name: "Geometría", Committee: academic.Committee{Member2: "Ing. Benítez Penayo"}},
+		{ID: 7, Name: "Inglés", Committee: academic.Committee{President: "A confirmar"}},
+	}
+
+	got := matchCommittees(teacher, courses)
+
+	want := []academic.TeacherCommitteeView{
+		{CourseID: 1, CourseName: "Algoritmo", Role: academic.CommitteePresident},
+		{CourseID: 2, CourseName: "Cálculo", Role: academic.CommitteeMember},
+		{CourseID: 3, CourseName: "Física", Role: academic.CommitteePresident},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("matchCommittees() returned %d committees; want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("committee %d = %+v; want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Placeholders are never members, even of the committees with a placeholder
+	placeholder := academic.Teacher{FirstName: "A", LastName: "CONFIRMAR"}
+	if got := matchCommittees(placeholder, courses); len(got) != 0 {
+		t.Errorf("matchCommittees(placeholder) = %+v; want none", got)
+	}
+}
+
+func TestTeacherIsPlaceholder(t *testing.T) {
+	tests := []struct {
+		teacher  academic.Teacher
+		expected bool
+	}{
+		{academic.Teacher{FirstName: "A CONFIRMAR"}, true},
+		{academic.Teacher{FirstName: "a", LastName: "confirmar"}, true},
+		{academic.Teacher{FirstName: "  Por  Definir "}, true},
+		{academic.Teacher{}, true},
+		{academic.Teacher{FirstName: "Ana", LastName: "Gómez"}, false},
+	}
+
+	for _, tc := range tests {
+		if got := tc.teacher.IsPlaceholder(); got != tc.expected {
+			t.Errorf("IsPlaceholder(%+v) = %v; want %v", tc.teacher, got, tc.expected)
+		}
+	}
+}
+
+func TestProposeMerges(t *testing.T) {
+	summary := func(id academic.TeacherID, title, first, last, email string, courses int) academic.TeacherSummaryView {
+		return academic.TeacherSummaryView{
+			Teacher: academic.Teacher{ID: id, Title: title, FirstName: first, LastName: last, Email: email},
+			Courses: courses,
+		}
+	}
+
+	teachers := []academic.TeacherSummaryView{
+		// Same person written with and without accents, only one has email
+		summary(1, "Lic.", "Armin", "Arce", "", 3),
+		summary(2, "Lic.", "Armín", "Arce", "arce@pol.una.py", 1),
+		// Same email, different spelling. Transitively grouped with the previous ones
+		summary(3, "Lic.", "Armin M.", "Arce", "ARCE@pol.una.py", 0),
+		// Same name and different emails: only grouped when the title matches
+		summary(4, "Ing.", "Juan", "Pérez", "juan@pol.una.py", 1),
+		summary(5, "Ing", "Juan", "Perez", "jperez@pol.una.py", 2),
+		summary(6, "Dr.", "Ana", "Gómez", "ana@pol.una.py", 1),
+		summary(7, "Lic.", "Ana", "Gómez", "agomez@pol.una.py", 1),
+		// Placeholders are never merged
+		summary(8, "", "A CONFIRMAR", "", "", 5),
+		summary(9, "", "A", "CONFIRMAR", "", 5),
+	}
+
+	got := proposeMerges(teachers)
+
+	if len(got) != 2 {
+		t.Fatalf("proposeMerges() returned %d proposals; want 2: %+v", len(got), got)
+	}
+
+	arce := got[0]
+	if arce.Survivor.Teacher.ID != 2 {
+		t.Errorf("Arce survivor = %v; want 2 (has email)", arce.Survivor.Teacher.ID)
+	}
+	if len(arce.Duplicates) != 2 {
+		t.Errorf("Arce duplicates = %d; want 2", len(arce.Duplicates))
+	}
+	if len(arce.Reasons) != 2 || arce.Reasons[0] != academic.MergeSameEmail || arce.Reasons[1] != academic.MergeSameName {
+		t.Errorf("Arce reasons = %v; want [email name]", arce.Reasons)
+	}
+
+	perez := got[1]
+	if perez.Survivor.Teacher.ID != 5 {
+		t.Errorf("Pérez survivor = %v; want 5 (more courses)", perez.Survivor.Teacher.ID)
+	}
+	if len(perez.Reasons) != 1 || perez.Reasons[0] != academic.MergeSameNameAndTitle {
+		t.Errorf("Pérez reasons = %v; want [name and title]", perez.Reasons)
+	}
+}
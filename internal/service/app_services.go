@@ -1,7 +1,10 @@
 package service
 
 import (
+	"path/filepath"
+
 	"github.com/elias-gill/poliplanner2/internal/config"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/archive"
 	"github.com/elias-gill/poliplanner2/internal/repository"
 	"github.com/elias-gill/poliplanner2/internal/repository/academic"
 	"github.com/elias-gill/poliplanner2/internal/repository/auth"
@@ -13,8 +16,10 @@ import (
 	authSrv "github.com/elias-gill/poliplanner2/internal/service/auth"
 	"github.com/elias-gill/poliplanner2/internal/service/email"
 	excelSrv "github.com/elias-gill/poliplanner2/internal/service/excel"
+	"github.com/elias-gill/poliplanner2/internal/service/metadata"
 	scheduleSrv "github.com/elias-gill/poliplanner2/internal/service/schedule"
 	userSrv "github.com/elias-gill/poliplanner2/internal/service/user"
+	"github.com/elias-gill/poliplanner2/logger"
 )
 
 // AppServices centralizes all instantiated application services.
@@ -24,9 +29,15 @@ type AppServices struct {
 	CourseService     *academicSrv.CourseService
 	CurriculumService *academicSrv.CurriculumService
 	CareerService     *academicSrv.CareerService
+	RoomService       *academicSrv.RoomService
+	TeacherService    *academicSrv.TeacherService
+	ExaminerService   *academicSrv.ExaminerService
+	SearchService     *academicSrv.SearchService
+	CalendarService   *academicSrv.CalendarService
 
 	ExcelService    *excelSrv.ExcelService
 	SyncService     *excelSrv.SyncService
+	LayoutService   *excelSrv.LayoutService
 	UserService     *userSrv.UserService
 	SessionService  *authSrv.SessionService
 	EmailService    *email.EmailSender
@@ -42,10 +53,15 @@ type RepositoriesInput struct {
 	PeriodRepo     academic.PeriodRepository
 	SubjectRepo    academic.SubjectRepository
 	CareerRepo     academic.CareerRepository
+	RoomRepo       academic.RoomRepository
+	ExaminerRepo   academic.ExaminerRepository
+	SearchRepo     academic.SearchRepository
+	CalendarRepo   academic.CalendarRepository
 
 	// Parsing repos
-	ExcelRepo excel.ExcelRepository
-	SyncRepo  excel.SyncRepository
+	ExcelRepo  excel.ExcelRepository
+	SyncRepo   excel.SyncRepository
+	LayoutRepo excel.LayoutRepository
 
 	// Schedules
 	ScheduleRepo schedule.ScheduleRepository
@@ -66,16 +82,32 @@ func NewAppServices(repos RepositoriesInput) *AppServices {
 	emailService := email.New(config.Get().Email.APIKey)
 
 	// Services that depend on previously created services
+	examinerService := academicSrv.NewExaminerService(
+		repos.ExaminerRepo,
+		repos.CourseRepo,
+		repos.TeacherRepo,
+		repos.TxManager,
+		periodService,
+	)
+
+	searchService := academicSrv.NewSearchService(repos.SearchRepo, repos.TxManager, periodService)
+
+	layoutService := excelSrv.NewLayoutService(repos.LayoutRepo)
+
 	excelService := excelSrv.NewExcelService(
 		repos.ExcelRepo,
 		repos.CourseRepo,
 		repos.TeacherRepo,
 		repos.CurriculumRepo,
-		repos.PeriodRepo,
 		repos.SubjectRepo,
 		repos.CareerRepo,
 		repos.TxManager,
+		archive.New(filepath.Join(config.Get().Paths.DownloadsDir, "sources")),
+		config.Get().Excel.ArchiveRetention,
 		periodService,
+		examinerService,
+		searchService,
+		layoutService,
 	)
 
 	syncService := excelSrv.NewSyncService(
@@ -84,13 +116,30 @@ func NewAppServices(repos RepositoriesInput) *AppServices {
 		repos.SyncRepo,
 	)
 
-	courseService := academicSrv.NewCourseService(repos.CourseRepo, periodService)
+	courseService := academicSrv.NewCourseService(repos.CourseRepo)
 
 	curriculumService := academicSrv.NewCurriculumService(repos.CurriculumRepo, repos.CareerRepo)
 
-	careerService := academicSrv.NewCareerService(repos.CareerRepo)
+	careerService := academicSrv.NewCareerService(repos.CareerRepo, periodService)
+
+	roomService := academicSrv.NewRoomService(repos.RoomRepo, periodService)
+
+	teacherService := academicSrv.NewTeacherService(
+		repos.TeacherRepo,
+		repos.CourseRepo,
+		repos.TxManager,
+		periodService,
+	)
+
+	// The campus map is optional, without it the dashboard just skips walking warnings
+	campus, err := metadata.LoadCampusMap()
+	if err != nil {
+		logger.Warn("cannot load campus map, walking distance warnings disabled", "error", err)
+	}
+
+	calendarService := academicSrv.NewCalendarService(repos.CalendarRepo, repos.TxManager, periodService)
 
-	scheduleService := scheduleSrv.New(repos.ScheduleRepo)
+	scheduleService := scheduleSrv.New(repos.ScheduleRepo, calendarService, campus)
 
 	return &AppServices{
 		// Academic
@@ -98,10 +147,16 @@ func NewAppServices(repos RepositoriesInput) *AppServices {
 		CourseService:     courseService,
 		CurriculumService: curriculumService,
 		CareerService:     careerService,
+		RoomService:       roomService,
+		TeacherService:    teacherService,
+		ExaminerService:   examinerService,
+		SearchService:     searchService,
+		CalendarService:   calendarService,
 
 		// Parsing
-		ExcelService: excelService,
-		SyncService:  syncService,
+		ExcelService:  excelService,
+		SyncService:   syncService,
+		LayoutService: layoutService,
 
 		// User
 		SessionService:  authService,
@@ -0,0 +1,120 @@
+package excel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/source"
+	"github.com/elias-gill/poliplanner2/internal/model/excel"
+	"github.com/elias-gill/poliplanner2/logger"
+)
+
+// archiveSource stores the content of the source on the archive and returns its hash.
+// Returns ErrAlreadyImported when a file with the same content was already imported
+// successfully, since importing it again would not change anything.
+func (e ExcelService) archiveSource(ctx context.Context, src source.ScheduleSource) (string, error) {
+	content, err := src.Content(ctx)
+	if err != nil {
+		return "", fmt.Errorf("cannot open Excel source: %w", err)
+	}
+	defer content.Close()
+
+	hash, size, err := e.archive.Store(content)
+	if err != nil {
+		return "", fmt.Errorf("cannot archive Excel source: %w", err)
+	}
+
+	err = e.excelRepository.SaveArchive(ctx, excel.ArchivedSource{
+		Hash:       hash,
+		Size:       size,
+		ArchivedAt: time.Now().In(timezone.ParaguayTZ),
+	})
+	if err != nil {
+		return "", fmt.Errorf("cannot save archived Excel source: %w", err)
+	}
+
+	imported, err := e.excelRepository.GetImportedVersion(ctx, hash)
+	if err != nil {
+		return "", fmt.Errorf("cannot check previous imports: %w", err)
+	}
+	if imported != nil {
+		return "", fmt.Errorf("%w on version %d", ErrAlreadyImported, imported.ID)
+	}
+
+	return hash, nil
+}
+
+// Reparse imports again the archived file of a version, on the same period, eg: after a
+// layout fix. The new attempt is saved on the history as another version.
+func (e ExcelService) Reparse(ctx context.Context, id excel.SheetVersionID) (*excel.SheetVersion, error) {
+	target, err := e.excelRepository.GetVersion(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get sheet version %d: %w", id, err)
+	}
+	if target == nil {
+		return nil, ErrNoSheetVersion
+	}
+	if !target.HasArchive {
+		return nil, ErrNoArchive
+	}
+
+	if target.Kind == excel.SourceLabs {
+		return e.importLabs(ctx, target.Hash, target.Name, target.URL, target.PeriodID)
+	}
+	return e.importSchedules(ctx, target.Hash, target.Name, target.URL, target.PeriodID)
+}
+
+// pruneArchive removes the archived files beyond the retention, counting the files of the
+// latest versions first. The file of the latest successful import of each kind is always
+// kept. Failures are only logged, the archive is pruned again on the next import.
+func (e ExcelService) pruneArchive(ctx context.Context) {
+	if e.archiveRetention <= 0 {
+		return
+	}
+
+	versions, err := e.excelRepository.ListAllVersions(ctx)
+	if err != nil {
+		logger.Warn("cannot list versions to prune the archive", "error", err)
+		return
+	}
+
+	keep := make(map[string]struct{})
+	imported := make(map[excel.SourceKind]bool)
+	for _, v := range versions {
+		if v.Hash == "" {
+			continue
+		}
+		if len(keep) < e.archiveRetention {
+			keep[v.Hash] = struct{}{}
+		}
+		if v.Succeeded && !imported[v.Kind] {
+			keep[v.Hash] = struct{}{}
+			imported[v.Kind] = true
+		}
+	}
+
+	archives, err := e.excelRepository.ListArchives(ctx)
+	if err != nil {
+		logger.Warn("cannot list archived sources", "error", err)
+		return
+	}
+
+	for _, a := range archives {
+		if _, ok := keep[a.Hash]; ok {
+			continue
+		}
+
+		if err := e.archive.Remove(a.Hash); err != nil {
+			logger.Warn("cannot remove archived source", "hash", a.Hash, "error", err)
+			continue
+		}
+		if err := e.excelRepository.DeleteArchive(ctx, a.Hash); err != nil {
+			logger.Warn("cannot delete archived source", "hash", a.Hash, "error", err)
+			continue
+		}
+
+		logger.Info("archived source removed", "hash", a.Hash, "size", a.Size)
+	}
+}
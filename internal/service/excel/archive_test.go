@@ -0,0 +1,175 @@
+package excel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/archive"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/source"
+	"github.com/elias-gill/poliplanner2/internal/model/excel"
+	excelRepo "github.com/elias-gill/poliplanner2/internal/repository/excel"
+)
+
+// memoryExcelRepository keeps the versions and the archived files in memory, latest first.
+// Only the methods used by the archive are implemented.
+type memoryExcelRepository struct {
+	excelRepo.ExcelRepository
+
+	versions []*excel.SheetVersion
+	archives []excel.ArchivedSource
+}
+
+func (r *memoryExcelRepository) SaveVersion(_ context.Context, version *excel.SheetVersion) error {
+	version.ID = excel.SheetVersionID(len(r.versions) + 1)
+	r.versions = slices.Insert(r.versions, 0, version)
+	return nil
+}
+
+func (r *memoryExcelRepository) ListAllVersions(context.Context) ([]*excel.SheetVersion, error) {
+	return r.versions, nil
+}
+
+func (r *memoryExcelRepository) GetImportedVersion(_ context.Context, hash string) (*excel.SheetVersion, error) {
+	for _, v := range r.versions {
+		if v.Hash == hash && v.Succeeded && v.RestoredFrom == 0 {
+			return v, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryExcelRepository) SaveArchive(_ context.Context, archived excel.ArchivedSource) error {
+	r.archives = slices.DeleteFunc(r.archives, func(a excel.ArchivedSource) bool { return a.Hash == archived.Hash })
+	r.archives = slices.Insert(r.archives, 0, archived)
+	return nil
+}
+
+func (r *memoryExcelRepository) ListArchives(context.Context) ([]excel.ArchivedSource, error) {
+	return r.archives, nil
+}
+
+func (r *memoryExcelRepository) DeleteArchive(_ context.Context, hash string) error {
+	r.archives = slices.DeleteFunc(r.archives, func(a excel.ArchivedSource) bool { return a.Hash == hash })
+	return nil
+}
+
+func newArchiveService(t *testing.T, retention int) (ExcelService, *memoryExcelRepository) {
+	repo := &memoryExcelRepository{}
+	return ExcelService{
+		excelRepository:  repo,
+		archive:          archive.New(t.TempDir()),
+		archiveRetention: retention,
+	}, repo
+}
+
+func readerSource(content string) source.ScheduleSource {
+	return source.NewExcelSourceFromReader(
+		io.NopCloser(bytes.NewBufferString(content)),
+		source.SourceMetadata{Name: "horario.xlsx", Date: time.Now()})
+}
+
+func TestArchiveSourceAlreadyImported(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newArchiveService(t, 0)
+
+	hash, err := service.archiveSource(ctx, readerSource("primer archivo"))
+	if err != nil {
+		t.Fatalf("archiveSource() = %v", err)
+	}
+
+	// A failed import of the file does not stop it from being imported again
+	repo.SaveVersion(ctx, &excel.SheetVersion{Kind: excel.SourceSchedules, Hash: hash, Succeeded: false})
+	if again, err := service.archiveSource(ctx, readerSource("primer archivo")); err != nil || again != hash {
+		t.Fatalf("archiveSource() after a failed import = %q, %v; want %q", again, err, hash)
+	}
+
+	// Neither does a rollback to a version of the file
+	repo.SaveVersion(ctx, &excel.SheetVersion{Kind: excel.SourceSchedules, Hash: hash, Succeeded: true, RestoredFrom: 1})
+	if _, err := service.archiveSource(ctx, readerSource("primer archivo")); err != nil {
+		t.Fatalf("archiveSource() after a rollback = %v", err)
+	}
+
+	repo.SaveVersion(ctx, &excel.SheetVersion{Kind: excel.SourceSchedules, Hash: hash, Succeeded: true})
+	if _, err := service.archiveSource(ctx, readerSource("primer archivo")); !errors.Is(err, ErrAlreadyImported) {
+		t.Errorf("archiveSource() after a successful import = %v; want ErrAlreadyImported", err)
+	}
+
+	// Another file is still imported
+	if _, err := service.archiveSource(ctx, readerSource("segundo archivo")); err != nil {
+		t.Errorf("archiveSource() of another file = %v", err)
+	}
+
+	if len(repo.archives) != 2 {
+		t.Errorf("archived %d files; want 2, the same file is archived once", len(repo.archives))
+	}
+}
+
+func TestPruneArchive(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newArchiveService(t, 2)
+
+	// From the oldest to the latest: the latest successful import of each kind comes
+	// before the last two files
+	versions := []struct {
+		content   string
+		kind      excel.SourceKind
+		succeeded bool
+	}{
+		{"laboratorios", excel.SourceLabs, true},
+		{"horario anterior", excel.SourceSchedules, true},
+		{"horario", excel.SourceSchedules, true},
+		{"laboratorios fallidos", excel.SourceLabs, false},
+		{"horario fallido", excel.SourceSchedules, false},
+		{"horario fallido otra vez", excel.SourceSchedules, false},
+	}
+
+	hashes := make([]string, len(versions))
+	for i, v := range versions {
+		hash, err := service.archiveSource(ctx, readerSource(v.content))
+		if err != nil {
+			t.Fatalf("archiveSource(%q) = %v", v.content, err)
+		}
+		hashes[i] = hash
+		repo.SaveVersion(ctx, &excel.SheetVersion{Kind: v.kind, Hash: hash, Succeeded: v.succeeded})
+	}
+	// Rollbacks have no file
+	repo.SaveVersion(ctx, &excel.SheetVersion{Kind: excel.SourceSchedules, Succeeded: true, RestoredFrom: 2})
+
+	service.pruneArchive(ctx)
+
+	kept := []bool{true, false, true, false, true, true}
+	for i, v := range versions {
+		archived := slices.ContainsFunc(repo.archives, func(a excel.ArchivedSource) bool { return a.Hash == hashes[i] })
+		content, err := service.archive.Open(hashes[i])
+		if err == nil {
+			content.Close()
+		}
+		if archived != kept[i] || (err == nil) != kept[i] {
+			t.Errorf("%q archived = %v, file open error = %v; want kept = %v", v.content, archived, err, kept[i])
+		}
+	}
+}
+
+func TestPruneArchiveDisabled(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newArchiveService(t, 0)
+
+	for _, content := range []string{"primero", "segundo", "tercero"} {
+		hash, err := service.archiveSource(ctx, readerSource(content))
+		if err != nil {
+			t.Fatalf("archiveSource(%q) = %v", content, err)
+		}
+		repo.SaveVersion(ctx, &excel.SheetVersion{Kind: excel.SourceSchedules, Hash: hash})
+	}
+
+	service.pruneArchive(ctx)
+
+	if len(repo.archives) != 3 {
+		t.Errorf("archived %d files; want 3, no retention keeps them all", len(repo.archives))
+	}
+}
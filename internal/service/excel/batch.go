@@ -0,0 +1,156 @@
+package excel
+
+import (
+	"context"
+	"fmt"
+
+	academicModel "github.com/elias-gill/poliplanner2/internal/model/academic"
+	"github.com/elias-gill/poliplanner2/internal/model/excel"
+	academicRepo "github.com/elias-gill/poliplanner2/internal/repository/academic"
+)
+
+// Rows persisted together. Sheets have a few hundred rows, so most of them are saved in one
+// or two batches while the memory used stays bounded.
+const rowBatchSize = 200
+
+// rowBatch buffers the enriched rows of an import to persist them together. Saved is called
+// with each persisted row, in the order they were added.
+type rowBatch struct {
+	service  ExcelService
+	periodID academicModel.PeriodID
+	saved    func(academicModel.CourseID, excel.CourseSnapshot)
+
+	rows []batchRow
+}
+
+type batchRow struct {
+	careerID academicModel.CareerID
+	row      courseRow
+}
+
+func (e ExcelService) newRowBatch(
+	periodID academicModel.PeriodID,
+	saved func(academicModel.CourseID, excel.CourseSnapshot),
+) *rowBatch {
+	return &rowBatch{
+		service:  e,
+		periodID: periodID,
+		saved:    saved,
+		rows:     make([]batchRow, 0, rowBatchSize),
+	}
+}
+
+// add buffers a row of the career, persisting the batch once it is full.
+func (b *rowBatch) add(ctx context.Context, careerID academicModel.CareerID, row courseRow) error {
+	b.rows = append(b.rows, batchRow{careerID: careerID, row: row})
+	if len(b.rows) < rowBatchSize {
+		return nil
+	}
+
+	return b.flush(ctx)
+}
+
+// flush persists the buffered rows. Must be called once all the rows were added.
+func (b *rowBatch) flush(ctx context.Context) error {
+	if len(b.rows) == 0 {
+		return nil
+	}
+
+	courseIDs, snapshots, err := b.service.persistRows(ctx, b.periodID, b.rows)
+	if err != nil {
+		return err
+	}
+
+	if b.saved != nil {
+		for i, id := range courseIDs {
+			b.saved(id, snapshots[i])
+		}
+	}
+
+	b.rows = b.rows[:0]
+	return nil
+}
+
+// persistRows persists enriched rows along with their subjects, curriculums and teachers.
+// Each kind of record is saved with a single batch call, in the order of the rows. Returns the
+// ID and snapshot of the course of each row.
+func (e ExcelService) persistRows(
+	ctx context.Context,
+	periodID academicModel.PeriodID,
+	rows []batchRow,
+) ([]academicModel.CourseID, []excel.CourseSnapshot, error) {
+	subjects := make([]academicModel.Subject, len(rows))
+	var teachers []academicModel.Teacher
+	for i, r := range rows {
+		subjects[i] = r.row.subject
+		teachers = append(teachers, r.row.teachers...)
+	}
+
+	subjectIDs, err := e.subjectRepository.UpsertBatch(ctx, subjects)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to upsert subjects: %w", err)
+	}
+
+	curriculums := make([]academicRepo.CurriculumSaveParams, len(rows))
+	for i, r := range rows {
+		curriculums[i] = academicRepo.CurriculumSaveParams{
+			SubjectID:  subjectIDs[i],
+			CareerID:   r.careerID,
+			Curriculum: r.row.curriculum,
+		}
+	}
+
+	curriculumIDs, err := e.curriculumRepository.UpsertBatch(ctx, curriculums)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to upsert curriculums: %w", err)
+	}
+
+	teacherIDs, err := e.teacherRepository.UpsertBatch(ctx, teachers)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to upsert teachers: %w", err)
+	}
+
+	courses := make([]*academicRepo.CourseSaveParams, len(rows))
+	for i, r := range rows {
+		course := r.row.course
+		courses[i] = &academicRepo.CourseSaveParams{
+			Name:          course.Name,
+			Type:          course.Type,
+			Section:       course.Section,
+			Shift:         course.Shift,
+			Period:        periodID,
+			Curriculum:    curriculumIDs[i],
+			SaturdayDates: course.SaturdayDates,
+			Comitee:       course.Comitee,
+		}
+	}
+
+	courseIDs, err := e.courseRepository.UpsertBatch(ctx, courses)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to upsert courses: %w", err)
+	}
+
+	// Exams, schedules, teachers and committees of the new courses
+	assignments := make([]academicRepo.CourseAssignment, len(rows))
+	snapshots := make([]excel.CourseSnapshot, len(rows))
+	for i, r := range rows {
+		course := r.row.course
+		ids := teacherIDs[:len(r.row.teachers)]
+		teacherIDs = teacherIDs[len(ids):]
+
+		assignments[i] = academicRepo.CourseAssignment{
+			Course:    courseIDs[i],
+			Teachers:  ids,
+			Schedule:  course.Schedule,
+			Exams:     course.Exams,
+			Committee: course.Comitee.Seats(),
+		}
+		snapshots[i] = buildCourseSnapshot(r.row.subject, r.row.curriculum, course, r.row.teachers)
+	}
+
+	if err := e.courseRepository.AssignBatch(ctx, assignments); err != nil {
+		return nil, nil, fmt.Errorf("failed to assign course data: %w", err)
+	}
+
+	return courseIDs, snapshots, nil
+}
@@ -21,12 +21,12 @@ func NewDiscoveryService(googleApikey string) *DiscoveryService {
 	}
 }
 
-type sourcesResult struct {
-	Sources []source.ScheduleSource
+type sourcesResult[S source.ScheduleSource] struct {
+	Sources []S
 	Date    time.Time
 }
 
-func (i DiscoveryService) FindLatestSources(ctx context.Context) (*sourcesResult, error) {
+func (i DiscoveryService) FindLatestSources(ctx context.Context) (*sourcesResult[source.ScheduleSource], error) {
 	if i.scraper == nil {
 		return nil, fmt.Errorf("error searching for Excel versions: web scraper not initialized")
 	}
@@ -42,7 +42,44 @@ func (i DiscoveryService) FindLatestSources(ctx context.Context) (*sourcesResult
 		return nil, nil
 	}
 
-	var latestSources []source.ScheduleSource
+	list := make([]source.ScheduleSource, len(sources))
+	for i, s := range sources {
+		list[i] = s
+	}
+
+	return latestSources(list), nil
+}
+
+// FindLatestLabSources works like FindLatestSources, for the laboratory workbooks. Returns nil
+// when there are none, labs are not published every period.
+func (i DiscoveryService) FindLatestLabSources(ctx context.Context) (*sourcesResult[source.LabSource], error) {
+	if i.scraper == nil {
+		return nil, fmt.Errorf("error searching for lab sources: web scraper not initialized")
+	}
+
+	sources, err := i.scraper.DiscoverLabs(ctx)
+	if err != nil {
+		logger.Error("Web scraper failed to find lab sources", "error", err)
+		return nil, fmt.Errorf("error searching for lab sources: %w", err)
+	}
+
+	if len(sources) == 0 {
+		logger.Info("No lab sources found by scraper")
+		return nil, nil
+	}
+
+	list := make([]source.LabSource, len(sources))
+	for i, s := range sources {
+		list[i] = s
+	}
+
+	return latestSources(list), nil
+}
+
+// latestSources keeps the sources of the newest day. Several files are published at once, so
+// every source of that day is part of the same version.
+func latestSources[S source.ScheduleSource](sources []S) *sourcesResult[S] {
+	var latestSources []S
 	var newestDate time.Time
 
 	for _, s := range sources {
@@ -56,7 +93,7 @@ func (i DiscoveryService) FindLatestSources(ctx context.Context) (*sourcesResult
 		if len(latestSources) == 0 || currDay.After(newestDay) {
 			// Found a source from a newer day
 			newestDate = currentDate
-			latestSources = []source.ScheduleSource{s}
+			latestSources = []S{s}
 			logger.Info("Source from a newer day found", "name", meta.Name, "uri", meta.URI, "date", currentDate)
 
 		} else if currDay.Equal(newestDay) {
@@ -77,8 +114,8 @@ func (i DiscoveryService) FindLatestSources(ctx context.Context) (*sourcesResult
 		"newest_date", newestDate,
 	)
 
-	return &sourcesResult{
+	return &sourcesResult[S]{
 		Sources: latestSources,
 		Date:    newestDate,
-	}, nil
+	}
 }
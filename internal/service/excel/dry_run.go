@@ -0,0 +1,75 @@
+package excel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/source"
+	"github.com/elias-gill/poliplanner2/internal/model/excel"
+	metaServices "github.com/elias-gill/poliplanner2/internal/service/metadata"
+)
+
+// DryRun parses and maps the source the same way PersistSource does, without writing
+// anything. Unlike the import it does not stop on the first broken sheet, so the report lists
+// every problem of the file at once.
+func (e ExcelService) DryRun(ctx context.Context, src source.ScheduleSource) (*excel.DryRunReport, error) {
+	content, err := src.Content(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open Excel source: %w", err)
+	}
+	defer content.Close()
+
+	layouts, err := e.layoutService.Layouts(ctx, excel.SourceSchedules)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load parser layouts: %w", err)
+	}
+
+	p, err := parser.NewParserWithLayouts(content, src.Metadata().Name, layouts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize excel parser: %w", err)
+	}
+	defer p.Close()
+
+	report := &excel.DryRunReport{Source: src.Metadata().Name}
+	report.IgnoredSheets = p.IgnoredSheets()
+	start := time.Now()
+
+	for p.NextSheet() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		sheetStart := time.Now()
+		code := buildCareerFromDTO(p.SheetName()).Code
+
+		metadataService, err := metaServices.NewMetadataService(code)
+		if err != nil {
+			return nil, fmt.Errorf("error while loading metadata: %w", err)
+		}
+
+		audit := newScheduleAudit(sheetStart, code, metadataService)
+		sheet, err := p.StreamCurrentSheet(func(data *parser.SubjectDTO) error {
+			audit.count()
+
+			audit.add(*data, enrichRow(metadataService, mapRow(*data)), metadataService)
+			return nil
+		})
+		audit.parsed(sheet.Layout, sheet.HeaderRow, sheet.Unsure)
+
+		// Only the dry run lists the rows the parser does not read, the titles and notes
+		// before the header, in case the header was found too late
+		audit.ignored(sheet.IgnoredRows)
+
+		if err != nil {
+			report.Sheets = append(report.Sheets, audit.fail(err))
+			continue
+		}
+		report.Sheets = append(report.Sheets, audit.finish())
+	}
+
+	report.Duration = time.Since(start)
+
+	return report, nil
+}
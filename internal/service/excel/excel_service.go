@@ -4,11 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"runtime"
-
 	"time"
 
 	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/archive"
 	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser"
 	"github.com/elias-gill/poliplanner2/internal/infrastructure/source"
 	academicModel "github.com/elias-gill/poliplanner2/internal/model/academic"
@@ -18,26 +17,51 @@ import (
 	excelRepo "github.com/elias-gill/poliplanner2/internal/repository/excel"
 	academicService "github.com/elias-gill/poliplanner2/internal/service/academic"
 	metaServices "github.com/elias-gill/poliplanner2/internal/service/metadata"
+	"github.com/elias-gill/poliplanner2/logger"
 )
 
 var (
 	ErrNoSheetVersion = errors.New("No sheet version found")
+	ErrNoSnapshot     = errors.New("sheet version has no snapshot")
+	ErrNoReport       = errors.New("sheet version has no import report")
+	ErrRollbackPeriod = errors.New("sheet version belongs to another period")
+	ErrNoArchive      = errors.New("sheet version file is not archived")
+
+	// The file of the source was already imported successfully, use Reparse to import it again
+	ErrAlreadyImported = errors.New("source file already imported")
 )
 
+// RollbackImpactError is returned by Rollback when restoring the version removes courses saved
+// on the schedules of students and that was not confirmed. Nothing is changed.
+type RollbackImpactError struct {
+	Impact excel.RollbackImpact
+}
+
+func (e RollbackImpactError) Error() string {
+	return fmt.Sprintf("rollback removes %d courses saved on %d schedules",
+		e.Impact.RemovedCourses, e.Impact.AffectedSchedules)
+}
+
 type ExcelService struct {
 	excelRepository      excelRepo.ExcelRepository
 	courseRepository     academicRepo.CourseRepository
 	teacherRepository    academicRepo.TeacherRepository
 	curriculumRepository academicRepo.CurriculumRepository
-	periodRepository     academicRepo.PeriodRepository
 	subjectRepository    academicRepo.SubjectRepository
 	careerRepository     academicRepo.CareerRepository
 
 	txManager repository.TxManager
 
+	// Imported files, the oldest ones beyond the retention are removed after each import
+	archive          *archive.Archive
+	archiveRetention int
+
 	// --- External services ---
 
-	periodService *academicService.PeriodService
+	periodService   *academicService.PeriodService
+	examinerService *academicService.ExaminerService
+	searchService   *academicService.SearchService
+	layoutService   *LayoutService
 }
 
 func NewExcelService(
@@ -45,27 +69,36 @@ func NewExcelService(
 	courseRepo academicRepo.CourseRepository,
 	teacherRepo academicRepo.TeacherRepository,
 	curriculumRepo academicRepo.CurriculumRepository,
-	periodRepo academicRepo.PeriodRepository,
 	subjectRepo academicRepo.SubjectRepository,
 	careerRepo academicRepo.CareerRepository,
 	txManager repository.TxManager,
+	sourceArchive *archive.Archive,
+	archiveRetention int,
 	periodService *academicService.PeriodService,
+	examinerService *academicService.ExaminerService,
+	searchService *academicService.SearchService,
+	layoutService *LayoutService,
 ) *ExcelService {
 	return &ExcelService{
 		excelRepository:      excelRepo,
 		courseRepository:     courseRepo,
 		teacherRepository:    teacherRepo,
 		curriculumRepository: curriculumRepo,
-		periodRepository:     periodRepo,
 		subjectRepository:    subjectRepo,
 		careerRepository:     careerRepo,
 		txManager:            txManager,
+		archive:              sourceArchive,
+		archiveRetention:     archiveRetention,
 		periodService:        periodService,
+		examinerService:      examinerService,
+		searchService:        searchService,
+		layoutService:        layoutService,
 	}
 }
 
-// GetLatestValidVersion lists the latest SUCCESFULLY parsed excel file version.
-func (e ExcelService) GetLatestValidVersion(ctx context.Context) (*excel.SheetVersion, error) {
+// GetLatestValidVersion lists the latest SUCCESFULLY parsed excel file version of the given
+// kind.
+func (e ExcelService) GetLatestValidVersion(ctx context.Context, kind excel.SourceKind) (*excel.SheetVersion, error) {
 	versions, err := e.excelRepository.ListAllVersions(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("cannot find excel versions: %w", err)
@@ -74,7 +107,7 @@ func (e ExcelService) GetLatestValidVersion(ctx context.Context) (*excel.SheetVe
 	// The repository returns an ordered list from latest to oldest.
 	for _, v := range versions {
 		// Check for the first correctly parsed version
-		if v.Succeeded {
+		if v.Succeeded && v.Kind == kind {
 			return v, nil
 		}
 	}
@@ -86,129 +119,154 @@ func (e ExcelService) ListVersions(ctx context.Context) ([]*excel.SheetVersion,
 	return e.excelRepository.ListAllVersions(ctx)
 }
 
+// GetReport returns the import report of a version. Returns ErrNoReport when the version has
+// none.
+func (e ExcelService) GetReport(ctx context.Context, id excel.SheetVersionID) (*excel.ImportReport, error) {
+	report, err := e.excelRepository.GetReport(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get import report of version %d: %w", id, err)
+	}
+	if report == nil {
+		return nil, ErrNoReport
+	}
+
+	return report, nil
+}
+
+// DiffVersions compares the academic data imported by two sheet versions. Returns
+// ErrNoSheetVersion when a version does not exist and ErrNoSnapshot when it was not imported
+// correctly or predates the snapshots.
+func (e ExcelService) DiffVersions(ctx context.Context, from, to excel.SheetVersionID) (*excel.VersionDiff, error) {
+	diff := &excel.VersionDiff{}
+
+	var snapshots [2]*excel.Snapshot
+	for i, id := range []excel.SheetVersionID{from, to} {
+		version, err := e.excelRepository.GetVersion(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get sheet version %d: %w", id, err)
+		}
+		if version == nil {
+			return nil, ErrNoSheetVersion
+		}
+
+		snapshot, err := e.excelRepository.GetSnapshot(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get snapshot of version %d: %w", id, err)
+		}
+		if snapshot == nil {
+			return nil, ErrNoSnapshot
+		}
+
+		if i == 0 {
+			diff.From = version
+		} else {
+			diff.To = version
+		}
+		snapshots[i] = snapshot
+	}
+
+	diff.Careers = diffSnapshots(snapshots[0], snapshots[1])
+
+	return diff, nil
+}
+
+// PersistSource archives the source and imports it. Returns ErrAlreadyImported when the same
+// file was already imported successfully.
 func (e ExcelService) PersistSource(ctx context.Context, source source.ScheduleSource) error {
-	content, err := source.Content(ctx)
+	hash, err := e.archiveSource(ctx, source)
 	if err != nil {
-		return fmt.Errorf("cannot open Excel source: %w", err)
+		return err
+	}
+
+	// The period configured by an admin for the source date wins over the semester guessed
+	// from the file name
+	metadata := source.Metadata()
+	periodID, err := e.periodService.ResolvePeriod(ctx, metadata.Date, metadata.Semester)
+	if err != nil {
+		return fmt.Errorf("failed to resolve period: %w", err)
+	}
+
+	_, err = e.importSchedules(ctx, hash, metadata.Name, metadata.URI, periodID)
+	return err
+}
+
+// importSchedules parses an archived schedules workbook and persists its courses on the
+// period. The attempt is saved on the version history even when it fails.
+func (e ExcelService) importSchedules(
+	ctx context.Context,
+	hash, name, url string,
+	periodID academicModel.PeriodID,
+) (*excel.SheetVersion, error) {
+	content, err := e.archive.Open(hash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open archived Excel source: %w", err)
 	}
 	defer content.Close()
 
-	p, err := parser.NewParser(content)
+	layouts, err := e.layoutService.Layouts(ctx, excel.SourceSchedules)
 	if err != nil {
-		return fmt.Errorf("cannot initialize excel parser: %w", err)
+		return nil, fmt.Errorf("cannot load parser layouts: %w", err)
 	}
 
-	// Upsert the period based on the provided source metadata
-	periodID, err := e.periodRepository.Upsert(ctx, academicModel.Period{
-		Year:     source.Metadata().Date.Year(),
-		Semester: academicModel.YearSemester(source.Metadata().Semester),
-	})
+	p, err := parser.NewParserWithLayouts(content, name, layouts)
 	if err != nil {
-		return fmt.Errorf("failed to upsert period: %w", err)
+		return nil, fmt.Errorf("cannot initialize excel parser: %w", err)
 	}
 
 	sheetCount := 0
+	snapshot := &excel.Snapshot{}
+	report := &excel.ImportReport{IgnoredSheets: p.IgnoredSheets()}
+	start := time.Now()
+
+	// Header row of the sheet that no layout matched, kept once the version is saved
+	var unmatched *excel.UnmatchedHeader
 
 	txErr := e.txManager.WithTransaction(ctx, func(ctx context.Context) error {
 		for p.NextSheet() {
-			sheet, err := p.ParseCurrentSheet()
-			if err != nil {
-				if sheet != nil {
-					return fmt.Errorf("error parsing sheet '%s': %w", sheet.Name, err)
-				}
-				return fmt.Errorf("error while parsing: %w", err)
-			}
+			sheetStart := time.Now()
+			code := buildCareerFromDTO(p.SheetName()).Code
 
-			career := buildCareerFromDTO(sheet.Name)
-
-			metadataService, err := metaServices.NewMetadataService(career.Code)
+			careerID, metadataService, err := e.persistCareer(ctx, code)
 			if err != nil {
-				return fmt.Errorf("error while loading metadata: %w", err)
+				report.Sheets = append(report.Sheets, newScheduleAudit(sheetStart, code, nil).fail(err))
+				return err
 			}
 
-			metadataService.EnrichCareer(&career)
-
-			careerID, err := e.careerRepository.Upsert(ctx, career)
-			if err != nil {
-				return fmt.Errorf("failed to upsert career '%s': %w", career.Code, err)
-			}
-
-			for _, data := range sheet.Subjects {
-				// Load and enrich subject with known metadata
-				sub := buildSubject(data)
-				metadataService.EnrichSubject(&sub)
-
-				subjectID, err := e.subjectRepository.Upsert(ctx, sub)
-				if err != nil {
-					return fmt.Errorf("failed to upsert subject '%s': %w", sub.Name, err)
-				}
-
-				// Load and enrich curriculum with known metadata
-				curriculum := buildCurriculum(data)
-				metadataService.EnrichCurriculum(sub, &curriculum)
-
-				curriculumID, err := e.curriculumRepository.Upsert(ctx, academicRepo.CurriculumSaveParams{
-					SubjectID:  subjectID,
-					CareerID:   careerID,
-					Curriculum: curriculum,
-				})
-				if err != nil {
-					return fmt.Errorf("failed to upsert curriculum for subject '%s': %w", sub.Name, err)
-				}
-
-				// Teachers persistence
-				teachers := buildTeachers(data.Teachers, data.TeacherCount)
-
-				var teacherIDs []academicModel.TeacherID
-				for _, t := range teachers {
-					teacherID, err := e.teacherRepository.Upsert(ctx, t)
-					if err != nil {
-						return fmt.Errorf("failed to upsert teacher '%s': %w", t.FirstName, err)
-					}
-					teacherIDs = append(teacherIDs, teacherID)
-				}
-
-				// Build and persist course
-				course := buildOfferingFromDTO(data)
-
-				courseID, err := e.courseRepository.Upsert(ctx, &academicRepo.CourseSaveParams{
-					Name:          course.Name,
-					Type:          course.Type,
-					Section:       course.Section,
-					Shift:         course.Shift,
-					Period:        periodID,
-					Curriculum:    curriculumID,
-					SaturdayDates: course.SaturdayDates,
-					Comitee:       course.Comitee,
-				})
-				if err != nil {
-					return fmt.Errorf("failed to upsert course '%s': %w", course.Name, err)
-				}
+			audit := newScheduleAudit(sheetStart, code, metadataService)
+			careerSnapshot := excel.CareerSnapshot{Code: code}
+			batch := e.newRowBatch(periodID, func(_ academicModel.CourseID, snap excel.CourseSnapshot) {
+				careerSnapshot.Courses = append(careerSnapshot.Courses, snap)
+			})
 
-				// Assign exams, schedule and teachers to the new course
-				if err := e.courseRepository.AssignExams(ctx, courseID, course.Exams); err != nil {
-					return fmt.Errorf("failed to assign exams to course '%s': %w", course.Name, err)
-				}
+			// Rows are persisted in batches as they are read, the sheet is never fully loaded
+			sheet, err := p.StreamCurrentSheet(func(data *parser.SubjectDTO) error {
+				audit.count()
 
-				if err := e.courseRepository.AssignSchedule(ctx, courseID, course.Schedule); err != nil {
-					return fmt.Errorf("failed to assign schedule to course '%s': %w", course.Name, err)
-				}
+				row := enrichRow(metadataService, mapRow(*data))
+				audit.add(*data, row, metadataService)
 
-				if err := e.courseRepository.AssignTeachers(ctx, courseID, teacherIDs); err != nil {
-					return fmt.Errorf("failed to assign teachers to course '%s': %w", course.Name, err)
-				}
+				return batch.add(ctx, careerID, row)
+			})
+			if err == nil {
+				err = batch.flush(ctx)
+			}
+			audit.parsed(sheet.Layout, sheet.HeaderRow, sheet.Unsure)
+			if err != nil {
+				unmatched = unmatchedHeader(err, p.SheetName())
+				report.Sheets = append(report.Sheets, audit.fail(err))
+				return fmt.Errorf("error importing sheet '%s': %w", code, err)
 			}
 
+			report.Sheets = append(report.Sheets, audit.finish())
+			snapshot.Careers = append(snapshot.Careers, careerSnapshot)
 			sheetCount++
-
-			// Force memmory cleaning
-			sheet.Subjects = nil
-			runtime.GC()
 		}
 
 		return nil
 	})
 
+	report.Duration = time.Since(start)
+
 	// Close parser after all sheets are processed
 	p.Close()
 
@@ -224,24 +282,202 @@ func (e ExcelService) PersistSource(ctx context.Context, source source.ScheduleS
 
 	// Save audit entry, independently if the parsing and persistence process was succesfull or
 	// not
-	auditErr := e.excelRepository.SaveVersion(ctx, &excel.SheetVersion{
+	version := &excel.SheetVersion{
 		PeriodID:     periodID,
-		Name:         source.Metadata().Name,
-		URL:          source.Metadata().URI,
+		Name:         name,
+		URL:          url,
 		ParsedAt:     time.Now().In(timezone.ParaguayTZ),
 		ParsedSheets: sheetCount,
 		Succeeded:    txErr == nil,
 		Error:        errMsg,
-	})
+		Hash:         hash,
+	}
+	auditErr := e.excelRepository.SaveVersion(ctx, version)
 	if auditErr != nil {
-		return fmt.Errorf("failed to save excel version audit (original error: %v): %w", txErr, auditErr)
+		return nil, fmt.Errorf("failed to save excel version audit (original error: %v): %w", txErr, auditErr)
 	}
 
+	// The version is already saved, without the report only the error message is kept
+	if err := e.excelRepository.SaveReport(ctx, version.ID, report); err != nil {
+		logger.Warn("cannot save sheet version import report", "version", version.ID, "error", err)
+	}
+	e.saveUnmatchedHeader(ctx, version, unmatched)
+
+	// Older files can only be removed once the version of this one is saved
+	e.pruneArchive(ctx)
+
 	// Return error if the parsing and persist fails after saving the audit entry
 	if txErr != nil {
-		return fmt.Errorf("excel persistence transaction failed: %w", txErr)
+		return version, fmt.Errorf("excel persistence transaction failed: %w", txErr)
 	}
 
+	e.afterImport(ctx, version, snapshot)
+
 	// Correctly parsed and persisted
-	return nil
+	return version, nil
+}
+
+// Rollback restores the academic data of the current period to the state imported by an
+// earlier version, using its snapshot. Courses added after that version are removed, except
+// the laboratories, which are imported from other sources. The rollback is saved on the
+// history as a new version that points to the restored one.
+//
+// Removed courses are also removed from the schedules of the students, so unless confirmed
+// a rollback that empties any schedule slot returns a RollbackImpactError instead.
+func (e ExcelService) Rollback(ctx context.Context, id excel.SheetVersionID, confirmed bool) (*excel.SheetVersion, error) {
+	target, err := e.excelRepository.GetVersion(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get sheet version %d: %w", id, err)
+	}
+	if target == nil {
+		return nil, ErrNoSheetVersion
+	}
+
+	snapshot, err := e.excelRepository.GetSnapshot(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get snapshot of version %d: %w", id, err)
+	}
+	if snapshot == nil {
+		return nil, ErrNoSnapshot
+	}
+
+	// Restoring another period would mix its courses with the current ones
+	periodID, err := e.periodService.CalculateCurrentPeriod(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve period: %w", err)
+	}
+	if target.PeriodID != periodID {
+		return nil, ErrRollbackPeriod
+	}
+
+	restored := &excel.Snapshot{}
+	var removed int64
+
+	txErr := e.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		keep := []academicModel.CourseID{}
+
+		for _, career := range snapshot.Careers {
+			careerID, metadataService, err := e.persistCareer(ctx, career.Code)
+			if err != nil {
+				return err
+			}
+
+			careerSnapshot := excel.CareerSnapshot{Code: career.Code}
+			batch := e.newRowBatch(periodID, func(id academicModel.CourseID, snap excel.CourseSnapshot) {
+				keep = append(keep, id)
+				careerSnapshot.Courses = append(careerSnapshot.Courses, snap)
+			})
+
+			for _, course := range career.Courses {
+				row := enrichRow(metadataService, restoreRow(course))
+				if err := batch.add(ctx, careerID, row); err != nil {
+					return err
+				}
+			}
+			if err := batch.flush(ctx); err != nil {
+				return err
+			}
+
+			restored.Careers = append(restored.Careers, careerSnapshot)
+		}
+
+		courses, schedules, err := e.courseRepository.CountByPeriodExcept(ctx, periodID, keep)
+		if err != nil {
+			return fmt.Errorf("failed to count newer courses: %w", err)
+		}
+		if schedules > 0 && !confirmed {
+			return RollbackImpactError{Impact: excel.RollbackImpact{RemovedCourses: courses, AffectedSchedules: schedules}}
+		}
+
+		removed, err = e.courseRepository.DeleteByPeriodExcept(ctx, periodID, keep)
+		if err != nil {
+			return fmt.Errorf("failed to remove newer courses: %w", err)
+		}
+
+		return nil
+	})
+
+	// Nothing was restored, so it is not an attempt for the history
+	var impactErr RollbackImpactError
+	if errors.As(txErr, &impactErr) {
+		return nil, impactErr
+	}
+
+	var errMsg string
+	if txErr != nil {
+		errMsg = txErr.Error()
+	}
+
+	version := &excel.SheetVersion{
+		PeriodID:     periodID,
+		Name:         target.Name,
+		URL:          target.URL,
+		ParsedAt:     time.Now().In(timezone.ParaguayTZ),
+		ParsedSheets: len(snapshot.Careers),
+		Succeeded:    txErr == nil,
+		Error:        errMsg,
+		RestoredFrom: target.ID,
+	}
+	if err := e.excelRepository.SaveVersion(ctx, version); err != nil {
+		return nil, fmt.Errorf("failed to save rollback audit (original error: %v): %w", txErr, err)
+	}
+
+	if txErr != nil {
+		return nil, fmt.Errorf("rollback transaction failed: %w", txErr)
+	}
+
+	logger.Info("sheet version restored", "version", target.ID, "rollback", version.ID, "removedCourses", removed)
+
+	e.afterImport(ctx, version, restored)
+
+	return version, nil
+}
+
+// afterImport runs the steps that follow a successful import. The data is already
+// persisted, so failures are only logged.
+func (e ExcelService) afterImport(ctx context.Context, version *excel.SheetVersion, snapshot *excel.Snapshot) {
+	// Without a snapshot the version can not be compared with others, but the data is
+	// already imported
+	if err := e.excelRepository.SaveSnapshot(ctx, version.ID, snapshot); err != nil {
+		logger.Warn("cannot save sheet version snapshot", "version", version.ID, "error", err)
+	}
+
+	// New examiners have to be linked with their teachers. A failure here is not critical
+	// and is retried on the next startup.
+	if err := e.examinerService.SyncExaminers(ctx); err != nil {
+		logger.Warn("cannot link examiners to teachers", "error", err)
+	}
+
+	// Same as above, the previous index keeps working until the next rebuild
+	if err := e.searchService.RebuildIndex(ctx); err != nil {
+		logger.Warn("cannot rebuild search index", "error", err)
+	}
+}
+
+// courseRow is a sheet row already mapped to the domain, before the metadata enrichment.
+type courseRow struct {
+	subject    academicModel.Subject
+	curriculum academicModel.Curriculum
+	course     academicModel.Course
+	teachers   []academicModel.Teacher
+}
+
+// persistCareer enriches and upserts the career of a sheet, returning the metadata service
+// used to enrich its rows.
+func (e ExcelService) persistCareer(ctx context.Context, code string) (academicModel.CareerID, *metaServices.MetadataService, error) {
+	career := buildCareerFromDTO(code)
+
+	metadataService, err := metaServices.NewMetadataService(career.Code)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error while loading metadata: %w", err)
+	}
+
+	metadataService.EnrichCareer(&career)
+
+	careerID, err := e.careerRepository.Upsert(ctx, career)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to upsert career '%s': %w", career.Code, err)
+	}
+
+	return careerID, metadataService, nil
 }
@@ -0,0 +1,44 @@
+package excel_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/source"
+)
+
+// BenchmarkImportWorkbook imports the real test workbook on a migrated database, then
+// reparses it on each iteration, the same work an admin triggers from the versions page.
+// Needs the sqlite_fts5 build tag, like the rest of the app.
+func BenchmarkImportWorkbook(b *testing.B) {
+	ctx := context.Background()
+	service, repos, _ := newImportService(b)
+
+	file, err := os.Open(filepath.Join(config.Get().Paths.BaseDir, "test_data", "excel", "real_test_excel.xlsx"))
+	if err != nil {
+		b.Fatalf("cannot open workbook: %v", err)
+	}
+	src := source.NewExcelSourceFromReader(file, source.SourceMetadata{
+		Name: "real_test_excel.xlsx",
+		URI:  "local",
+		Date: time.Now(),
+	})
+	if err := service.PersistSource(ctx, src); err != nil {
+		b.Fatalf("cannot import workbook: %v", err)
+	}
+
+	versions, err := repos.ExcelRepo.ListAllVersions(ctx)
+	if err != nil || len(versions) == 0 {
+		b.Fatalf("cannot get imported version: %v", err)
+	}
+
+	for b.Loop() {
+		if _, err := service.Reparse(ctx, versions[0].ID); err != nil {
+			b.Fatalf("cannot reparse workbook: %v", err)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package excel_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/elias-gill/poliplanner2/internal/config"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/archive"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/persistence/sqlite"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/persistence/sqlite/sqlitetest"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/source"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	"github.com/elias-gill/poliplanner2/internal/model/excel"
+	academicRepo "github.com/elias-gill/poliplanner2/internal/repository/academic"
+	academicService "github.com/elias-gill/poliplanner2/internal/service/academic"
+	excelService "github.com/elias-gill/poliplanner2/internal/service/excel"
+)
+
+// newImportService builds the excel service over a migrated database.
+func newImportService(tb testing.TB) (*excelService.ExcelService, *sqlite.SQLiteStorage, *sql.DB) {
+	tb.Helper()
+
+	conn := sqlitetest.Open(tb)
+	repos := sqlite.NewSQLiteStorage(conn)
+	periodService := academicService.NewPeriodService(repos.PeriodRepo)
+
+	service := excelService.NewExcelService(
+		repos.ExcelRepo,
+		repos.CourseRepo,
+		repos.TeacherRepo,
+		repos.CurriculumRepo,
+		repos.SubjectRepo,
+		repos.CareerRepo,
+		repos.TxManager,
+		archive.New(filepath.Join(tb.TempDir(), "sources")),
+		0,
+		periodService,
+		academicService.NewExaminerService(repos.ExaminerRepo, repos.CourseRepo, repos.TeacherRepo, repos.TxManager, periodService),
+		academicService.NewSearchService(repos.SearchRepo, repos.TxManager, periodService),
+		excelService.NewLayoutService(repos.LayoutRepo),
+	)
+
+	return service, repos, conn
+}
+
+func TestPersistLabSourceMergesSessions(t *testing.T) {
+	ctx := context.Background()
+	service, repos, conn := newImportService(t)
+
+	// A lab group with a row per session, the layout of laboratorios_por_sesion.json
+	f := excelize.NewFile()
+	rows := [][]any{
+		{"Laboratorios - Primer semestre"},
+		{"Item", "Dpto.", "Asignatura", "Nivel", "Semestre", "Carrera", "Plan", "Turno", "Sección",
+			"Laboratorio", "Día", "Horario", "Tít", "Apellido", "Nombre", "Correo"},
+		{1, "DCB", "Fisica I", 1, 1, "IIN", "2013", "M", "A",
+			"Lab. Fisica", "Lunes", "07:30 - 09:00", "Ing.", "Gonzalez", "Juan", "jgonzalez@pol.una.py"},
+		{2, "DCB", "Fisica I", 1, 1, "IIN", "2013", "M", "A",
+			"Lab. Fisica", "Miércoles", "09:10 - 10:40", "Lic.", "Benitez", "Ana", "abenitez@pol.una.py"},
+		{3, "DCB", "Fisica I", 1, 1, "IIN", "2013", "M", "B",
+			"Lab. Fisica", "Viernes", "07:30 - 09:00", "Ing.", "Gonzalez", "Juan", "jgonzalez@pol.una.py"},
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow("Sheet1", cell, &row); err != nil {
+			t.Fatalf("cannot build workbook: %v", err)
+		}
+	}
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("cannot build workbook: %v", err)
+	}
+
+	src := source.NewExcelSourceFromReader(io.NopCloser(buf), source.SourceMetadata{
+		Name: "laboratorios.xlsx",
+		URI:  "local",
+		Date: time.Now(),
+	})
+	if err := service.PersistLabSource(ctx, src); err != nil {
+		t.Fatalf("PersistLabSource() = %v", err)
+	}
+
+	sessions := map[string][]academic.WeekDay{}
+	teachers := map[string]int{}
+	for _, section := range []string{"A", "B"} {
+		var id academic.CourseID
+		err := conn.QueryRow(`SELECT id FROM cursos WHERE nombre = ? AND seccion = ?`,
+			"Fisica I (Laboratorio)", section).Scan(&id)
+		if err != nil {
+			t.Fatalf("cannot find the lab of section %s: %v", section, err)
+		}
+
+		schedule, err := repos.CourseRepo.GetCourseSchedules(ctx, id)
+		if err != nil {
+			t.Fatalf("GetCourseSchedules() = %v", err)
+		}
+		for _, s := range schedule {
+			sessions[section] = append(sessions[section], s.Day)
+		}
+
+		list, err := repos.CourseRepo.GetCourseTeachers(ctx, id)
+		if err != nil {
+			t.Fatalf("GetCourseTeachers() = %v", err)
+		}
+		teachers[section] = len(list)
+	}
+
+	if got := sessions["A"]; len(got) != 2 || got[0] != academic.Monday || got[1] != academic.Wednesday {
+		t.Errorf("sessions of section A = %v; want Monday and Wednesday", got)
+	}
+	if got := sessions["B"]; len(got) != 1 || got[0] != academic.Friday {
+		t.Errorf("sessions of section B = %v; want Friday", got)
+	}
+	if teachers["A"] != 2 || teachers["B"] != 1 {
+		t.Errorf("teachers = %v; want 2 on section A and 1 on B", teachers)
+	}
+}
+
+func TestRollbackConfirmsScheduleLoss(t *testing.T) {
+	if testing.Short() {
+		t.Skip("imports the real workbook")
+	}
+
+	ctx := context.Background()
+	service, repos, conn := newImportService(t)
+
+	file, err := os.Open(filepath.Join(config.Get().Paths.BaseDir, "test_data", "excel", "real_test_excel.xlsx"))
+	if err != nil {
+		t.Fatalf("cannot open workbook: %v", err)
+	}
+	src := source.NewExcelSourceFromReader(file, source.SourceMetadata{
+		Name: "real_test_excel.xlsx",
+		URI:  "local",
+		Date: time.Now(),
+	})
+	if err := service.PersistSource(ctx, src); err != nil {
+		t.Fatalf("cannot import workbook: %v", err)
+	}
+	versions, err := repos.ExcelRepo.ListAllVersions(ctx)
+	if err != nil || len(versions) != 1 {
+		t.Fatalf("ListAllVersions() = %d versions, %v; want the import", len(versions), err)
+	}
+	imported := versions[0]
+
+	// A section added after the import, saved by a student
+	var curriculum academic.CurriculumID
+	if err := conn.QueryRow(`SELECT malla FROM cursos LIMIT 1`).Scan(&curriculum); err != nil {
+		t.Fatalf("cannot find a curriculum: %v", err)
+	}
+	ids, err := repos.CourseRepo.UpsertBatch(ctx, []*academicRepo.CourseSaveParams{
+		{Name: "Seccion nueva", Section: "Z", Shift: "N", Period: imported.PeriodID, Curriculum: curriculum},
+	})
+	if err != nil {
+		t.Fatalf("cannot create course: %v", err)
+	}
+	res, err := conn.Exec(`INSERT INTO users (username, password, email) VALUES ('alumno', '', 'alumno@fpuna.edu.py')`)
+	if err != nil {
+		t.Fatalf("cannot create student: %v", err)
+	}
+	userID, _ := res.LastInsertId()
+	res, err = conn.Exec(`INSERT INTO horarios (usuario_id) VALUES (?)`, userID)
+	if err != nil {
+		t.Fatalf("cannot create schedule: %v", err)
+	}
+	scheduleID, _ := res.LastInsertId()
+	if _, err := conn.Exec(`INSERT INTO horarios_detalle (horario_id, curso_id) VALUES (?, ?)`, scheduleID, ids[0]); err != nil {
+		t.Fatalf("cannot save course on schedule: %v", err)
+	}
+
+	saved := func() int {
+		var n int
+		if err := conn.QueryRow(`SELECT COUNT(*) FROM horarios_detalle WHERE curso_id = ?`, ids[0]).Scan(&n); err != nil {
+			t.Fatalf("cannot count saved courses: %v", err)
+		}
+		return n
+	}
+
+	// Without confirmation nothing changes, not even the history
+	_, err = service.Rollback(ctx, imported.ID, false)
+	var impactErr excelService.RollbackImpactError
+	if !errors.As(err, &impactErr) {
+		t.Fatalf("Rollback() = %v; want RollbackImpactError", err)
+	}
+	if want := (excel.RollbackImpact{RemovedCourses: 1, AffectedSchedules: 1}); impactErr.Impact != want {
+		t.Errorf("Rollback() impact = %+v; want %+v", impactErr.Impact, want)
+	}
+	if saved() != 1 {
+		t.Errorf("the course was removed from the schedule without confirmation")
+	}
+	if versions, _ := repos.ExcelRepo.ListAllVersions(ctx); len(versions) != 1 {
+		t.Errorf("history has %d versions; want the unconfirmed rollback left out", len(versions))
+	}
+
+	if _, err := service.Rollback(ctx, imported.ID, true); err != nil {
+		t.Fatalf("Rollback(confirmed) = %v", err)
+	}
+	if saved() != 0 {
+		t.Errorf("the confirmed rollback kept the removed course on the schedule")
+	}
+}
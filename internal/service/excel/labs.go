@@ -0,0 +1,238 @@
+package excel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/exceptions"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/source"
+	academicModel "github.com/elias-gill/poliplanner2/internal/model/academic"
+	"github.com/elias-gill/poliplanner2/internal/model/excel"
+	metaServices "github.com/elias-gill/poliplanner2/internal/service/metadata"
+	"github.com/elias-gill/poliplanner2/logger"
+)
+
+// PersistLabSource imports the laboratory groups of a lab workbook as Laboratory courses of
+// each listed career. Labs are only added or updated, a workbook does not remove the labs
+// of the others, since several are published at once. Returns ErrAlreadyImported when the
+// same file was already imported successfully.
+func (e ExcelService) PersistLabSource(ctx context.Context, src source.LabSource) error {
+	hash, err := e.archiveSource(ctx, src)
+	if err != nil {
+		return err
+	}
+
+	metadata := src.Metadata()
+	periodID, err := e.periodService.ResolvePeriod(ctx, metadata.Date, metadata.Semester)
+	if err != nil {
+		return fmt.Errorf("failed to resolve period: %w", err)
+	}
+
+	_, err = e.importLabs(ctx, hash, metadata.Name, metadata.URI, periodID)
+	return err
+}
+
+// importLabs parses an archived lab workbook and persists its labs on the period.
+func (e ExcelService) importLabs(
+	ctx context.Context,
+	hash, name, url string,
+	periodID academicModel.PeriodID,
+) (*excel.SheetVersion, error) {
+	content, err := e.archive.Open(hash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open archived lab source: %w", err)
+	}
+	defer content.Close()
+
+	layouts, err := e.layoutService.Layouts(ctx, excel.SourceLabs)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load parser layouts: %w", err)
+	}
+
+	p, err := parser.NewLabsParserWithLayouts(content, name, layouts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize labs parser: %w", err)
+	}
+	defer p.Close()
+
+	sheetCount := 0
+	report := &excel.ImportReport{}
+	start := time.Now()
+
+	// Header row of the sheet that no layout matched, kept once the version is saved
+	var unmatched *excel.UnmatchedHeader
+
+	txErr := e.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		careers := make(map[string]labCareer)
+
+		// Labs read so far on each career. Sheets with a row per session list a lab group once
+		// per session, and each row is persisted merged with the previous ones: the course is
+		// the same, and its schedule would be replaced by the last session otherwise.
+		labs := make(map[labKey]courseRow)
+
+		for p.NextSheet() {
+			audit := newSheetAudit(time.Now(), "")
+			batch := e.newRowBatch(periodID, nil)
+
+			sheet, err := p.StreamCurrentSheet(func(data *parser.LabDTO) error {
+				audit.count()
+
+				row, skip := mapLab(*data)
+				if skip != "" {
+					audit.skip(data.Row, skip)
+					return nil
+				}
+				audit.checkTeachers(data.Row, data.TeacherLinesMismatch())
+
+				for _, code := range data.Careers {
+					career, ok := careers[code]
+					if !ok {
+						var err error
+						career.id, career.metadata, err = e.persistCareer(ctx, code)
+						if err != nil {
+							return err
+						}
+						careers[code] = career
+						audit.checkCareer(code, career.metadata)
+					}
+
+					key := newLabKey(code, row)
+					lab, ok := labs[key]
+					if ok {
+						lab = mergeLab(lab, row)
+					} else {
+						lab = row
+					}
+					labs[key] = lab
+
+					enriched := enrichRow(career.metadata, lab)
+					audit.checkRow(data.Row, enriched, career.metadata)
+
+					// The batch can hold the same lab more than once, the last and most complete
+					// one is the one persisted
+					if err := batch.add(ctx, career.id, enriched); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			})
+			if err == nil {
+				err = batch.flush(ctx)
+			}
+			audit.report.Career = sheet.Name
+			audit.parsed(sheet.Layout, sheet.HeaderRow, sheet.Unsure)
+
+			if err != nil {
+				// Cover pages and notes have no table
+				var missingHeader exceptions.MissingHeaderException
+				if errors.As(err, &missingHeader) {
+					report.IgnoredSheets = append(report.IgnoredSheets, sheet.Name)
+					continue
+				}
+
+				unmatched = unmatchedHeader(err, sheet.Name)
+				report.Sheets = append(report.Sheets, audit.fail(err))
+				return fmt.Errorf("error importing sheet '%s': %w", sheet.Name, err)
+			}
+
+			report.Sheets = append(report.Sheets, audit.finish())
+			sheetCount++
+		}
+
+		if sheetCount == 0 {
+			return errors.New("no lab sheets found")
+		}
+
+		return nil
+	})
+
+	report.Duration = time.Since(start)
+
+	var errMsg string
+	if txErr != nil {
+		errMsg = txErr.Error()
+	}
+
+	version := &excel.SheetVersion{
+		PeriodID:     periodID,
+		Kind:         excel.SourceLabs,
+		Name:         name,
+		URL:          url,
+		ParsedAt:     time.Now().In(timezone.ParaguayTZ),
+		ParsedSheets: sheetCount,
+		Succeeded:    txErr == nil,
+		Error:        errMsg,
+		Hash:         hash,
+	}
+	if err := e.excelRepository.SaveVersion(ctx, version); err != nil {
+		return nil, fmt.Errorf("failed to save lab version audit (original error: %v): %w", txErr, err)
+	}
+
+	if err := e.excelRepository.SaveReport(ctx, version.ID, report); err != nil {
+		logger.Warn("cannot save lab version import report", "version", version.ID, "error", err)
+	}
+	e.saveUnmatchedHeader(ctx, version, unmatched)
+
+	e.pruneArchive(ctx)
+
+	if txErr != nil {
+		return version, fmt.Errorf("lab persistence transaction failed: %w", txErr)
+	}
+
+	// The new courses have to be searchable, the previous index keeps working otherwise
+	if err := e.searchService.RebuildIndex(ctx); err != nil {
+		logger.Warn("cannot rebuild search index", "error", err)
+	}
+
+	return version, nil
+}
+
+// labCareer is a career already persisted during a lab import.
+type labCareer struct {
+	id       academicModel.CareerID
+	metadata *metaServices.MetadataService
+}
+
+// labKey identifies the lab course of a row on a career, by the same fields courses are
+// unique by on a period.
+type labKey struct {
+	career, name, section, shift, plan string
+}
+
+func newLabKey(career string, row courseRow) labKey {
+	return labKey{
+		career:  career,
+		name:    row.course.Name,
+		section: row.course.Section,
+		shift:   row.course.Shift,
+		plan:    row.curriculum.Plan.Code,
+	}
+}
+
+// mergeLab adds the sessions and teachers of another row of the same lab group to the lab.
+// The lab is not modified, the merged row has its own slices.
+func mergeLab(lab, row courseRow) courseRow {
+	lab.course.Schedule = slices.Clone(lab.course.Schedule)
+	for _, s := range row.course.Schedule {
+		if !slices.ContainsFunc(lab.course.Schedule, func(o academicModel.ClassSession) bool {
+			return o.Day == s.Day && o.Room.Raw == s.Room.Raw && o.Time.String() == s.Time.String()
+		}) {
+			lab.course.Schedule = append(lab.course.Schedule, s)
+		}
+	}
+
+	lab.teachers = slices.Clone(lab.teachers)
+	for _, t := range row.teachers {
+		if !slices.Contains(lab.teachers, t) {
+			lab.teachers = append(lab.teachers, t)
+		}
+	}
+
+	return lab
+}
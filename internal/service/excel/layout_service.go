@@ -0,0 +1,234 @@
+package excel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/layout"
+	excelModel "github.com/elias-gill/poliplanner2/internal/model/excel"
+	"github.com/elias-gill/poliplanner2/internal/repository/excel"
+	"github.com/elias-gill/poliplanner2/logger"
+)
+
+var (
+	ErrInvalidLayout = errors.New("invalid parser layout")
+	ErrNoLayout      = errors.New("parser layout not found")
+
+	// Disabling the layout would leave the kind without layouts, so no workbook could be parsed
+	ErrLastLayout = errors.New("cannot disable the last enabled layout")
+)
+
+var layoutNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+\.json$`)
+
+// layoutSource tells how to load and validate the layouts of a kind of workbook.
+type layoutSource struct {
+	files func() ([]layout.Layout, error)
+	parse func(name string, data []byte) (*layout.Layout, error)
+}
+
+var layoutSources = map[excelModel.SourceKind]layoutSource{
+	excelModel.SourceSchedules: {files: parser.ScheduleLayouts, parse: parser.ParseScheduleLayout},
+	excelModel.SourceLabs:      {files: parser.LabLayouts, parse: parser.ParseLabLayout},
+}
+
+// LayoutService merges the layout files of the parser with the layouts uploaded from the
+// administration, so a new workbook format does not need a deploy.
+type LayoutService struct {
+	layoutRepository excel.LayoutRepository
+}
+
+func NewLayoutService(layoutRepo excel.LayoutRepository) *LayoutService {
+	return &LayoutService{layoutRepository: layoutRepo}
+}
+
+// parserLayout is a merged layout with the parsed version used by the parser.
+type parserLayout struct {
+	info   *excelModel.ParserLayout
+	layout *layout.Layout
+}
+
+// ListLayouts lists the layouts of the kind ordered by name, both the files and the uploaded
+// ones, enabled or not.
+func (s *LayoutService) ListLayouts(ctx context.Context, kind excelModel.SourceKind) ([]*excelModel.ParserLayout, error) {
+	merged, err := s.merge(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	layouts := make([]*excelModel.ParserLayout, len(merged))
+	for i, l := range merged {
+		layouts[i] = l.info
+	}
+	return layouts, nil
+}
+
+// Layouts returns the enabled layouts of the kind, the ones the parser has to use.
+func (s *LayoutService) Layouts(ctx context.Context, kind excelModel.SourceKind) ([]layout.Layout, error) {
+	merged, err := s.merge(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	var layouts []layout.Layout
+	for _, l := range merged {
+		if l.info.Enabled {
+			layouts = append(layouts, *l.layout)
+		}
+	}
+	return layouts, nil
+}
+
+// Validate checks the layout without saving it. Returns ErrInvalidLayout with the reason when
+// it cannot be read or names a header the parser does not know.
+func (s *LayoutService) Validate(kind excelModel.SourceKind, name string, data []byte) (*excelModel.ParserLayout, error) {
+	src, ok := layoutSources[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown workbook kind %d", ErrInvalidLayout, kind)
+	}
+
+	name = strings.TrimSpace(name)
+	if !strings.HasSuffix(name, ".json") {
+		name += ".json"
+	}
+	if !layoutNameRegex.MatchString(name) {
+		return nil, fmt.Errorf("%w: the name %q can only have letters, digits, '-' and '_'", ErrInvalidLayout, name)
+	}
+
+	l, err := src.parse(name, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLayout, err)
+	}
+
+	return &excelModel.ParserLayout{
+		Kind:    kind,
+		Name:    name,
+		JSON:    string(data),
+		Headers: l.Headers,
+		Enabled: true,
+	}, nil
+}
+
+// Upload validates and saves the layout enabled, replacing the file or the uploaded layout
+// with the same name.
+func (s *LayoutService) Upload(ctx context.Context, kind excelModel.SourceKind, name string, data []byte) (*excelModel.ParserLayout, error) {
+	l, err := s.Validate(kind, name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Uploaded = true
+	l.UpdatedAt = time.Now().In(timezone.ParaguayTZ)
+	if err := s.layoutRepository.SaveLayout(ctx, l); err != nil {
+		return nil, fmt.Errorf("cannot save parser layout: %w", err)
+	}
+
+	logger.Info("Parser layout uploaded", "kind", kind, "name", l.Name, "headers", len(l.Headers))
+	return l, nil
+}
+
+// SetEnabled enables or disables the layout. Returns ErrNoLayout when the kind has no layout
+// with the name, and ErrLastLayout when disabling the only enabled one.
+func (s *LayoutService) SetEnabled(ctx context.Context, kind excelModel.SourceKind, name string, enabled bool) error {
+	merged, err := s.merge(ctx, kind)
+	if err != nil {
+		return err
+	}
+
+	i := slices.IndexFunc(merged, func(l parserLayout) bool { return l.info.Name == name })
+	if i < 0 {
+		return ErrNoLayout
+	}
+
+	if !enabled && merged[i].info.Enabled {
+		others := slices.ContainsFunc(merged, func(l parserLayout) bool {
+			return l.info.Enabled && l.info.Name != name
+		})
+		if !others {
+			return ErrLastLayout
+		}
+	}
+
+	if err := s.layoutRepository.SetLayoutEnabled(ctx, kind, name, enabled); err != nil {
+		return fmt.Errorf("cannot change parser layout state: %w", err)
+	}
+
+	logger.Info("Parser layout state changed", "kind", kind, "name", name, "enabled", enabled)
+	return nil
+}
+
+// merge applies the stored layouts over the layout files of the kind. Stored layouts that are
+// no longer valid, eg: a setter was renamed, are skipped so they cannot break the imports.
+func (s *LayoutService) merge(ctx context.Context, kind excelModel.SourceKind) ([]parserLayout, error) {
+	src, ok := layoutSources[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown workbook kind %d", kind)
+	}
+
+	files, err := src.files()
+	if err != nil {
+		return nil, fmt.Errorf("cannot load layout files: %w", err)
+	}
+
+	merged := make(map[string]parserLayout, len(files))
+	for i := range files {
+		data, err := files[i].EncodeJSON()
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode layout %s: %w", files[i].FileName, err)
+		}
+
+		merged[files[i].FileName] = parserLayout{
+			info: &excelModel.ParserLayout{
+				Kind:    kind,
+				Name:    files[i].FileName,
+				JSON:    string(data),
+				Headers: files[i].Headers,
+				Enabled: true,
+				Default: true,
+			},
+			layout: &files[i],
+		}
+	}
+
+	stored, err := s.layoutRepository.ListLayouts(ctx, kind)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list stored layouts: %w", err)
+	}
+
+	for _, info := range stored {
+		file, isFile := merged[info.Name]
+		info.Default = isFile
+
+		if !info.Uploaded {
+			// Only the state of a file, which may have been removed since
+			if isFile {
+				file.info.Enabled = info.Enabled
+				file.info.UpdatedAt = info.UpdatedAt
+			}
+			continue
+		}
+
+		l, err := src.parse(info.Name, []byte(info.JSON))
+		if err != nil {
+			logger.Warn("Skipping invalid stored layout", "kind", kind, "name", info.Name, "error", err)
+			continue
+		}
+
+		info.Headers = l.Headers
+		merged[info.Name] = parserLayout{info: info, layout: l}
+	}
+
+	layouts := make([]parserLayout, 0, len(merged))
+	for _, l := range merged {
+		layouts = append(layouts, l)
+	}
+	slices.SortFunc(layouts, func(a, b parserLayout) int { return strings.Compare(a.info.Name, b.info.Name) })
+
+	return layouts, nil
+}
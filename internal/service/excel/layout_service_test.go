@@ -0,0 +1,169 @@
+package excel
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/layout"
+	"github.com/elias-gill/poliplanner2/internal/model/excel"
+)
+
+// memoryLayoutRepository keeps the stored layouts in memory, by kind and name.
+type memoryLayoutRepository struct {
+	layouts map[excel.SourceKind]map[string]*excel.ParserLayout
+}
+
+func newMemoryLayoutRepository() *memoryLayoutRepository {
+	return &memoryLayoutRepository{layouts: make(map[excel.SourceKind]map[string]*excel.ParserLayout)}
+}
+
+func (r *memoryLayoutRepository) ListLayouts(_ context.Context, kind excel.SourceKind) ([]*excel.ParserLayout, error) {
+	var layouts []*excel.ParserLayout
+	for _, l := range r.layouts[kind] {
+		stored := *l
+		layouts = append(layouts, &stored)
+	}
+	slices.SortFunc(layouts, func(a, b *excel.ParserLayout) int { return strings.Compare(a.Name, b.Name) })
+	return layouts, nil
+}
+
+func (r *memoryLayoutRepository) SaveLayout(_ context.Context, layout *excel.ParserLayout) error {
+	stored := *layout
+	r.kind(layout.Kind)[layout.Name] = &stored
+	return nil
+}
+
+func (r *memoryLayoutRepository) SetLayoutEnabled(_ context.Context, kind excel.SourceKind, name string, enabled bool) error {
+	l, ok := r.kind(kind)[name]
+	if !ok {
+		l = &excel.ParserLayout{Kind: kind, Name: name}
+		r.layouts[kind][name] = l
+	}
+	l.Enabled = enabled
+	return nil
+}
+
+func (r *memoryLayoutRepository) kind(kind excel.SourceKind) map[string]*excel.ParserLayout {
+	if r.layouts[kind] == nil {
+		r.layouts[kind] = make(map[string]*excel.ParserLayout)
+	}
+	return r.layouts[kind]
+}
+
+const labLayoutJSON = `{
+  "lista": [
+    { "encabezado": "item", "patron": ["item"] },
+    { "encabezado": "asignatura", "patron": ["asignatura"] },
+    { "encabezado": "laboratorio", "patron": ["laboratorio", "lab"] }
+  ]
+}`
+
+func TestLayoutServiceUpload(t *testing.T) {
+	ctx := context.Background()
+	service := NewLayoutService(newMemoryLayoutRepository())
+
+	files, err := service.ListLayouts(ctx, excel.SourceLabs)
+	if err != nil || len(files) == 0 {
+		t.Fatalf("ListLayouts() = %d layouts, %v; want the layout files", len(files), err)
+	}
+
+	// A new layout is added to the files
+	if _, err := service.Upload(ctx, excel.SourceLabs, "nuevo", []byte(labLayoutJSON)); err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+	layouts, _ := service.Layouts(ctx, excel.SourceLabs)
+	if len(layouts) != len(files)+1 || !slices.ContainsFunc(layouts, func(l layout.Layout) bool { return l.FileName == "nuevo.json" }) {
+		t.Errorf("Layouts() = %d layouts; want the files plus nuevo.json", len(layouts))
+	}
+
+	// A layout with the name of a file replaces it
+	if _, err := service.Upload(ctx, excel.SourceLabs, files[0].Name, []byte(labLayoutJSON)); err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+	listed, _ := service.ListLayouts(ctx, excel.SourceLabs)
+	if len(listed) != len(files)+1 {
+		t.Fatalf("ListLayouts() = %d layouts; want %d", len(listed), len(files)+1)
+	}
+	if l := listed[0]; l.Name != files[0].Name || !l.Default || !l.Uploaded || len(l.Headers) != 3 {
+		t.Errorf("replaced layout = %+v; want the uploaded one over the file", l)
+	}
+}
+
+func TestLayoutServiceValidate(t *testing.T) {
+	service := NewLayoutService(newMemoryLayoutRepository())
+
+	tests := []struct {
+		name string
+		kind excel.SourceKind
+		data string
+		ok   bool
+	}{
+		{"nuevo.json", excel.SourceLabs, labLayoutJSON, true},
+		{"../nuevo", excel.SourceLabs, labLayoutJSON, false},
+		{"nuevo", excel.SourceLabs, `{"lista": [`, false},
+		{"nuevo", excel.SourceLabs, `{"lista": []}`, false},
+		// Headers of the schedules are not read on the labs
+		{"nuevo", excel.SourceLabs, `{"lista": [{ "encabezado": "aulaParcial1", "patron": ["aula"] }]}`, false},
+		{"nuevo", excel.SourceSchedules, `{"lista": [{ "encabezado": "aulaParcial1", "patron": ["aula"] }]}`, true},
+	}
+
+	for _, tt := range tests {
+		l, err := service.Validate(tt.kind, tt.name, []byte(tt.data))
+		if tt.ok && err != nil {
+			t.Errorf("Validate(%q, %s) = %v; want a valid layout", tt.name, tt.data, err)
+		}
+		if !tt.ok && !errors.Is(err, ErrInvalidLayout) {
+			t.Errorf("Validate(%q, %s) = %+v, %v; want ErrInvalidLayout", tt.name, tt.data, l, err)
+		}
+	}
+}
+
+func TestLayoutServiceSetEnabled(t *testing.T) {
+	ctx := context.Background()
+	service := NewLayoutService(newMemoryLayoutRepository())
+
+	files, _ := service.ListLayouts(ctx, excel.SourceLabs)
+	for _, f := range files[1:] {
+		if err := service.SetEnabled(ctx, excel.SourceLabs, f.Name, false); err != nil {
+			t.Fatalf("SetEnabled(%s, false) = %v", f.Name, err)
+		}
+	}
+
+	layouts, _ := service.Layouts(ctx, excel.SourceLabs)
+	if len(layouts) != 1 || layouts[0].FileName != files[0].Name {
+		t.Errorf("Layouts() = %d layouts; want only %s", len(layouts), files[0].Name)
+	}
+
+	if err := service.SetEnabled(ctx, excel.SourceLabs, files[0].Name, false); !errors.Is(err, ErrLastLayout) {
+		t.Errorf("disabling the last layout = %v; want ErrLastLayout", err)
+	}
+	if err := service.SetEnabled(ctx, excel.SourceLabs, "otro.json", true); !errors.Is(err, ErrNoLayout) {
+		t.Errorf("enabling a missing layout = %v; want ErrNoLayout", err)
+	}
+}
+
+// TestLayoutServiceSkipsInvalid checks that a stored layout that is no longer valid does not
+// reach the parser.
+func TestLayoutServiceSkipsInvalid(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryLayoutRepository()
+	service := NewLayoutService(repo)
+
+	files, _ := service.Layouts(ctx, excel.SourceLabs)
+
+	repo.SaveLayout(ctx, &excel.ParserLayout{
+		Kind:     excel.SourceLabs,
+		Name:     "viejo.json",
+		JSON:     `{"lista": [{ "encabezado": "renombrado", "patron": ["asignatura"] }]}`,
+		Enabled:  true,
+		Uploaded: true,
+	})
+
+	layouts, err := service.Layouts(ctx, excel.SourceLabs)
+	if err != nil || len(layouts) != len(files) {
+		t.Errorf("Layouts() = %d layouts, %v; want only the %d files", len(layouts), err, len(files))
+	}
+}
@@ -17,6 +17,65 @@ import (
 // Entry Points & Domain Builders
 // ============================================================
 
+// Reasons to skip a row of the sheet
+const (
+	skipNoSubject    = "sin nombre de asignatura"
+	skipNoCareer     = "sin carrera"
+	skipBeforeHeader = "antes del encabezado"
+)
+
+// mapRow maps a row of a schedule sheet to the domain. Every row is imported, rows without a
+// subject name are only reported.
+func mapRow(data parser.SubjectDTO) courseRow {
+	return courseRow{
+		subject:    buildSubject(data),
+		curriculum: buildCurriculum(data),
+		course:     buildOfferingFromDTO(data),
+		teachers:   buildTeachers(data.Teachers, data.TeacherCount),
+	}
+}
+
+// mapLab maps a row of a laboratory sheet to the domain, the same row is imported for each of
+// its careers. Returns a reason when the row has to be skipped instead.
+func mapLab(data parser.LabDTO) (courseRow, string) {
+	if strings.TrimSpace(data.RawSubjectName) == "" {
+		return courseRow{}, skipNoSubject
+	}
+	if len(data.Careers) == 0 {
+		return courseRow{}, skipNoCareer
+	}
+
+	return courseRow{
+		subject: academic.Subject{
+			Name:       normalizeSubjectName(data.RawSubjectName),
+			Department: academic.Department{Code: data.Department},
+		},
+		curriculum: academic.Curriculum{
+			Level:    data.Level,
+			Semester: data.Semester,
+			Plan:     academic.Plan{Code: data.Plan},
+		},
+		course: academic.Course{
+			Name:     labCourseName(data.RawSubjectName),
+			Type:     academic.Laboratory,
+			Section:  data.Section,
+			Shift:    data.Shift,
+			Schedule: generateSchedule(data.WeekSchedule()),
+		},
+		teachers: buildTeachers(data.Teachers, data.TeacherCount),
+	}, ""
+}
+
+// labCourseName names the lab after its subject. Courses are unique by name and section, so
+// a lab group with the same section as the theory course would replace it otherwise.
+func labCourseName(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if strings.Contains(strings.ToLower(raw), "laboratorio") {
+		return raw
+	}
+	return raw + " (Laboratorio)"
+}
+
 func buildCareerFromDTO(code string) academic.Career {
 	return academic.Career{
 		Code: strings.ToUpper(strings.TrimSpace(code)),
@@ -106,7 +165,7 @@ func generateSchedule(s [7]parser.WeekDayData) []academic.ClassSession {
 
 		entries = append(entries, academic.ClassSession{
 			Day:  academic.WeekDay(day),
-			Room: data.Room,
+			Room: classifyRoom(data.Room),
 			Time: academic.TimeSlot{
 				Start: hourToTime(data.Time.Start),
 				End:   hourToTime(data.Time.End),
@@ -117,6 +176,51 @@ func generateSchedule(s [7]parser.WeekDayData) []academic.ClassSession {
 	return entries
 }
 
+// ============================================================
+// Room Classification
+// ============================================================
+
+// Keywords used on the sheets for sessions that are imparted online. Compared against the
+// uppercased room text without accents.
+var virtualRoomKeywords = [...]string{
+	"VIRTUAL", "PLATAFORMA", "TEAMS", "MEET", "ZOOM", "ONLINE", "EN LINEA",
+}
+
+// Values that mean "no room assigned yet"
+var unknownRoomValues = map[string]struct{}{
+	"": {}, "-": {}, "--": {}, "S/A": {}, "N/A": {}, "SIN AULA": {}, "A CONFIRMAR": {}, "A DEFINIR": {},
+}
+
+// Room codes like "A12", "B-05", "E 101" or "LAB 3A"
+var roomCodeRegex = regexp.MustCompile(`^([A-Z]{1,3})\s*[-_.]?\s*(\d{1,4}[A-Z]?)$`)
+
+func classifyRoom(raw string) academic.Location {
+	raw = strings.TrimSpace(raw)
+	loc := academic.Location{Raw: raw}
+
+	normalized := strings.Join(strings.Fields(strings.ToUpper(accentsReplacer.Replace(raw))), " ")
+
+	if _, ok := unknownRoomValues[normalized]; ok {
+		loc.Kind = academic.RoomUnknown
+		return loc
+	}
+
+	for _, keyword := range virtualRoomKeywords {
+		if strings.Contains(normalized, keyword) {
+			loc.Kind = academic.RoomVirtual
+			return loc
+		}
+	}
+
+	loc.Kind = academic.RoomPhysical
+	if m := roomCodeRegex.FindStringSubmatch(normalized); m != nil {
+		loc.Building = m[1]
+		loc.Number = m[2]
+	}
+
+	return loc
+}
+
 // ============================================================
 // Exams Parsing Engine
 // ============================================================
@@ -148,7 +252,7 @@ func buildExams(data parser.SubjectDTO) []academic.Exam {
 		exam := academic.Exam{
 			Type:     cfg.eType,
 			Instance: cfg.inst,
-			Room:     cfg.room,
+			Room:     classifyRoom(cfg.room),
 		}
 		exam.SetDate(examDate)
 		exam.SetRevision(combineDateHour(cfg.rd, cfg.rh))
@@ -7,6 +7,7 @@ import (
 
 	"github.com/elias-gill/poliplanner2/internal/config/timezone"
 	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser"
+	parserCommons "github.com/elias-gill/poliplanner2/internal/infrastructure/parser/commons"
 	"github.com/elias-gill/poliplanner2/internal/model/academic"
 )
 
@@ -15,35 +16,35 @@ func TestNormalizeSubjectName(t *testing.T) {
 		input    string
 		expected string
 	}{
-		{"Fisica 2", "fisica II"},
-		{"calculo 7", "calculo VII"},
-		{"Algebra 10", "algebra X"},
-		{"programacion 1", "programacion I"},
-		{"estadistica 20", "estadistica XX"},
+		{"Fisica 2", "Fisica II"},
+		{"calculo 7", "Calculo VII"},
+		{"Algebra 10", "Algebra X"},
+		{"programacion 1", "Programacion I"},
+		{"estadistica 20", "Estadistica XX"},
 
 		// Spaces and capital letters
-		{"  Fisica   3  ", "fisica III"},
-		{"CALCULO 5", "calculo V"},
-		{"CáLCULO 5", "calculo V"},
+		{"  Fisica   3  ", "Fisica III"},
+		{"CALCULO 5", "Calculo V"},
+		{"CáLCULO 5", "Calculo V"},
 
-		// No modifications needed
-		{"fisica", "fisica"},
-		{"fisica II", "fisica II"},
-		{"fisica 0", "fisica 0"},
-		{"fisica 21", "fisica 21"},
+		// Only the first letter gets capitalized
+		{"fisica", "Fisica"},
+		{"fisica II", "Fisica II"},
+		{"fisica 0", "Fisica 0"},
+		{"fisica 21", "Fisica 21"},
 
 		// Dash delimiters truncation
-		{"Electiva 1 - Machine Learning", "electiva I"},
-		{"electIVa 2 - quien sabe", "electiva II"},
+		{"Electiva 1 - Machine Learning", "Electiva I"},
+		{"electIVa 2 - quien sabe", "Electiva II"},
 
 		// Parenthesis removal
-		{"calculo V (variable vectorial)", "calculo V"},
-		{"calculo V (*)", "calculo V"},
-		{"calculo V (**)", "calculo V"},
+		{"calculo V (variable vectorial)", "Calculo V"},
+		{"calculo V (*)", "Calculo V"},
+		{"calculo V (**)", "Calculo V"},
 
 		// Additional cases
-		{"Álgebra Línea 1", "algebra linea I"},
-		{"Óptica Élite 4", "optica elite IV"},
+		{"Álgebra Línea 1", "Algebra linea I"},
+		{"Óptica Élite 4", "Optica elite IV"},
 	}
 
 	for _, tc := range tests {
@@ -95,7 +96,7 @@ func TestBuildSubject(t *testing.T) {
 	}
 
 	expected := academic.Subject{
-		Name: "quimica organica II",
+		Name: "Quimica organica II",
 		Department: academic.Department{
 			Code: "DEPT-QMC",
 		},
@@ -132,13 +133,13 @@ func TestBuildOfferingFromDTO_ExamsMapping(t *testing.T) {
 		RawSubjectName: "Matematica I",
 		CourseType:     academic.ExamOnly,
 		Section:        "A",
-		Partial1Date:   parser.Date{Year: 2026, Month: 5, Day: 10, Valid: true},
-		Partial1Time:   parser.Hour{Hour: 14, Minute: 30, Valid: true},
+		Partial1Date:   parserCommons.Date{Year: 2026, Month: 5, Day: 10, Valid: true},
+		Partial1Time:   parserCommons.Hour{Hour: 14, Minute: 30, Valid: true},
 		Partial1Room:   "Aula 1",
-		Final1Date:     parser.Date{Year: 2026, Month: 7, Day: 15, Valid: true},
-		Final1Time:     parser.Hour{Hour: 8, Minute: 0, Valid: true},
-		Final1RevDate:  parser.Date{Year: 2026, Month: 7, Day: 18, Valid: true},
-		Final1RevTime:  parser.Hour{Hour: 10, Minute: 0, Valid: true},
+		Final1Date:     parserCommons.Date{Year: 2026, Month: 7, Day: 15, Valid: true},
+		Final1Time:     parserCommons.Hour{Hour: 8, Minute: 0, Valid: true},
+		Final1RevDate:  parserCommons.Date{Year: 2026, Month: 7, Day: 18, Valid: true},
+		Final1RevTime:  parserCommons.Hour{Hour: 10, Minute: 0, Valid: true},
 		Final1Room:     "Aula Magna",
 		// Los campos omitidos (Partial2, Final2) se inicializan en cero por defecto con Valid: false
 	}
@@ -154,7 +155,7 @@ func TestBuildOfferingFromDTO_ExamsMapping(t *testing.T) {
 	}
 
 	p1 := got.Exams[0]
-	if p1.Type != academic.ExamPartial || p1.Instance != 1 || p1.Room != "Aula 1" {
+	if p1.Type != academic.ExamPartial || p1.Instance != 1 || p1.Room.Raw != "Aula 1" {
 		t.Errorf("Partial1 basic fields mismatch: %+v", p1)
 	}
 	expectedP1Date := time.Date(2026, time.May, 10, 14, 30, 0, 0, timezone.ParaguayTZ)
@@ -163,7 +164,7 @@ func TestBuildOfferingFromDTO_ExamsMapping(t *testing.T) {
 	}
 
 	f1 := got.Exams[1]
-	if f1.Type != academic.ExamFinal || f1.Instance != 1 || f1.Room != "Aula Magna" {
+	if f1.Type != academic.ExamFinal || f1.Instance != 1 || f1.Room.Raw != "Aula Magna" {
 		t.Errorf("Final1 basic fields mismatch: %+v", f1)
 	}
 	expectedF1Date := time.Date(2026, time.July, 15, 8, 0, 0, 0, timezone.ParaguayTZ)
@@ -176,3 +177,162 @@ func TestBuildOfferingFromDTO_ExamsMapping(t *testing.T) {
 		t.Errorf("Final1 revision date mismatch: %v; want %v", f1.Revision(), expectedF1Rev)
 	}
 }
+
+func TestClassifyRoom(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected academic.Location
+	}{
+		{
+			name:     "Empty room",
+			input:    "  ",
+			expected: academic.Location{Kind: academic.RoomUnknown},
+		},
+		{
+			name:     "Placeholder",
+			input:    "A confirmar",
+			expected: academic.Location{Raw: "A confirmar", Kind: academic.RoomUnknown},
+		},
+		{
+			name:     "Virtual keyword",
+			input:    "Virtual",
+			expected: academic.Location{Raw: "Virtual", Kind: academic.RoomVirtual},
+		},
+		{
+			name:     "Platform with accents and mixed case",
+			input:    "Plataforma Educa (Teams)",
+			expected: academic.Location{Raw: "Plataforma Educa (Teams)", Kind: academic.RoomVirtual},
+		},
+		{
+			name:     "Online with accent",
+			input:    "En Línea",
+			expected: academic.Location{Raw: "En Línea", Kind: academic.RoomVirtual},
+		},
+		{
+			name:     "Room code with dash",
+			input:    "b-05",
+			expected: academic.Location{Raw: "b-05", Kind: academic.RoomPhysical, Building: "B", Number: "05"},
+		},
+		{
+			name:     "Room code without separator",
+			input:    "A12",
+			expected: academic.Location{Raw: "A12", Kind: academic.RoomPhysical, Building: "A", Number: "12"},
+		},
+		{
+			name:     "Free text physical room",
+			input:    "Aula Magna",
+			expected: academic.Location{Raw: "Aula Magna", Kind: academic.RoomPhysical},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := classifyRoom(tc.input)
+			if got != tc.expected {
+				t.Errorf("classifyRoom(%q) = %+v; want %+v", tc.input, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestMapLab(t *testing.T) {
+	var data parser.LabDTO
+	data.Row = 4
+	data.SetSubjectName("Fisica 1")
+	data.SetCareers("iin, LCIK / IEK")
+	data.SetSection("a")
+	data.SetLab("Lab. Fisica")
+
+	// A row per session and a column per day end up on the same schedule
+	data.SetDay("Miércoles")
+	data.SetTime("07:30 - 09:00")
+	data.SetDayTime(academic.Friday, "10:00 - 11:30")
+
+	if want := []string{"IIN", "LCIK", "IEK"}; !reflect.DeepEqual(data.Careers, want) {
+		t.Errorf("Careers = %v; want %v", data.Careers, want)
+	}
+
+	row, skip := mapLab(data)
+	if skip != "" {
+		t.Fatalf("mapLab skipped the row: %s", skip)
+	}
+
+	if row.course.Name != "Fisica 1 (Laboratorio)" || row.course.Type != academic.Laboratory || row.course.Section != "A" {
+		t.Errorf("Basic fields mapping failed: %+v", row.course)
+	}
+	if row.subject.Name != "Fisica I" {
+		t.Errorf("Subject name = %q; want %q", row.subject.Name, "Fisica I")
+	}
+
+	if len(row.course.Schedule) != 2 {
+		t.Fatalf("Expected 2 sessions, got %d", len(row.course.Schedule))
+	}
+	for i, day := range []academic.WeekDay{academic.Wednesday, academic.Friday} {
+		s := row.course.Schedule[i]
+		if s.Day != day || s.Room.Raw != "Lab. Fisica" || s.Time.Start == nil {
+			t.Errorf("Session %d mismatch: %+v", i, s)
+		}
+	}
+
+	data.SetCareers("")
+	if _, skip := mapLab(data); skip != skipNoCareer {
+		t.Errorf("Row without careers skip = %q; want %q", skip, skipNoCareer)
+	}
+}
+
+func TestLabCourseName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Quimica General", "Quimica General (Laboratorio)"},
+		{"  Fisica I ", "Fisica I (Laboratorio)"},
+		{"Laboratorio de Electronica", "Laboratorio de Electronica"},
+	}
+
+	for _, tc := range tests {
+		if got := labCourseName(tc.input); got != tc.expected {
+			t.Errorf("labCourseName(%q) = %q; want %q", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestMergeLab(t *testing.T) {
+	session := func(day string, slot string) parser.LabDTO {
+		var data parser.LabDTO
+		data.SetSubjectName("Fisica 1")
+		data.SetCareers("IIN")
+		data.SetSection("A")
+		data.SetLab("Lab. Fisica")
+		data.SetDay(day)
+		data.SetTime(slot)
+		data.SetTeachersFirtNames("Juan")
+		data.SetTeachersEmails("jgonzalez@pol.una.py")
+		return data
+	}
+
+	monday, _ := mapLab(session("Lunes", "07:30 - 09:00"))
+	wednesday, _ := mapLab(session("Miércoles", "07:30 - 09:00"))
+	if newLabKey("IIN", monday) != newLabKey("IIN", wednesday) {
+		t.Fatalf("sessions of the same group have different keys")
+	}
+	if newLabKey("IIN", monday) == newLabKey("LCIK", monday) {
+		t.Errorf("the same group on two careers has the same key")
+	}
+
+	merged := mergeLab(monday, wednesday)
+	// Listing the same session again does not repeat it
+	merged = mergeLab(merged, wednesday)
+
+	if len(merged.course.Schedule) != 2 || merged.course.Schedule[0].Day != academic.Monday ||
+		merged.course.Schedule[1].Day != academic.Wednesday {
+		t.Errorf("merged schedule = %+v; want Monday and Wednesday", merged.course.Schedule)
+	}
+	if len(merged.teachers) != 1 {
+		t.Errorf("merged teachers = %+v; want the teacher once", merged.teachers)
+	}
+	if len(monday.course.Schedule) != 1 {
+		t.Errorf("the merged lab was modified: %+v", monday.course.Schedule)
+	}
+}
@@ -0,0 +1,227 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser"
+	parserCommons "github.com/elias-gill/poliplanner2/internal/infrastructure/parser/commons"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/layout"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	"github.com/elias-gill/poliplanner2/internal/model/excel"
+	metaServices "github.com/elias-gill/poliplanner2/internal/service/metadata"
+)
+
+// Data a course can be missing on the import reports
+const (
+	missingTimes    = "horario"
+	missingRooms    = "aula"
+	missingTeachers = "docentes"
+)
+
+// sheetAudit builds the report of a sheet while its rows are mapped, shared by the imports
+// and the dry run.
+type sheetAudit struct {
+	report excel.SheetReport
+	start  time.Time
+
+	subjects map[string]struct{}
+	courses  map[string]struct{}
+}
+
+// newSheetAudit starts the report of a sheet, before it is read. Name is the career code for
+// the schedules and the sheet name for the labs.
+func newSheetAudit(start time.Time, name string) *sheetAudit {
+	return &sheetAudit{
+		report: excel.SheetReport{
+			Career: name,
+		},
+		start:    start,
+		subjects: make(map[string]struct{}),
+		courses:  make(map[string]struct{}),
+	}
+}
+
+// newScheduleAudit starts the report of a career sheet. The metadata service is nil when the
+// sheet failed before its rows could be mapped.
+func newScheduleAudit(start time.Time, code string, metadata *metaServices.MetadataService) *sheetAudit {
+	a := newSheetAudit(start, code)
+	if metadata != nil {
+		a.checkCareer(code, metadata)
+	}
+	return a
+}
+
+// count counts a row read from the sheet.
+func (a *sheetAudit) count() {
+	a.report.Rows++
+}
+
+// parsed records the layout that matched the sheet once it was read, along with a warning
+// for each header column that was matched with doubts. These go before the row warnings.
+func (a *sheetAudit) parsed(layoutName string, headerRow int, unsure []layout.UnsureColumn) {
+	a.report.Layout = layoutName
+
+	rows := a.report.Warnings
+	a.report.Warnings = nil
+	for _, u := range unsure {
+		switch u.Kind {
+		case layout.UnsureSimilar:
+			a.warn(headerRow, excel.WarningHeader, "columna %s (%q) leída como %s con %.0f%% de similitud",
+				u.ColumnName(), u.Cell, u.Header, u.Similarity*100)
+		case layout.UnsureSwapped:
+			a.warn(headerRow, excel.WarningHeader, "columna %s (%q) leída como %s fuera del orden del formato",
+				u.ColumnName(), u.Cell, u.Header)
+		case layout.UnsureMissing:
+			a.warn(headerRow, excel.WarningHeader, "no se encontró la columna %s, queda vacía", u.Header)
+		}
+	}
+	a.report.Warnings = append(a.report.Warnings, rows...)
+}
+
+func (a *sheetAudit) warn(row int, kind excel.WarningKind, format string, args ...any) {
+	a.report.Warnings = append(a.report.Warnings, excel.ImportWarning{
+		Row:     row,
+		Kind:    kind,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (a *sheetAudit) skip(row int, reason string) {
+	a.report.Skipped = append(a.report.Skipped, excel.SkippedRow{Row: row, Reason: reason})
+}
+
+// ignored records the rows with text that the parser did not read, which go before the rows
+// skipped by the import.
+func (a *sheetAudit) ignored(rows []parserCommons.IgnoredRow) {
+	skipped := make([]excel.SkippedRow, 0, len(rows)+len(a.report.Skipped))
+	for _, r := range rows {
+		skipped = append(skipped, excel.SkippedRow{Row: r.Row, Reason: fmt.Sprintf("%s: %q", skipBeforeHeader, r.Text)})
+	}
+	a.report.Skipped = append(skipped, a.report.Skipped...)
+}
+
+// checkCareer reports once that the career has no metadata, instead of a warning for each of
+// its rows.
+func (a *sheetAudit) checkCareer(code string, metadata *metaServices.MetadataService) {
+	if !metadata.HasCareerInfo() {
+		a.warn(0, excel.WarningMetadata, "la carrera %s no tiene metadatos, no se completan semestres ni énfasis", code)
+	}
+}
+
+// add checks a row of a career sheet, after it was enriched with the metadata.
+func (a *sheetAudit) add(data parser.SubjectDTO, row courseRow, metadata *metaServices.MetadataService) {
+	if strings.TrimSpace(data.RawSubjectName) == "" {
+		a.warn(data.Row, excel.WarningSubject, "fila sin nombre de asignatura, se importa igual")
+	}
+	for _, v := range data.InvalidDates {
+		a.warn(data.Row, excel.WarningDate, "fecha de %s no reconocida: %q", v.Field, v.Value)
+	}
+	a.checkTeachers(data.Row, data.TeacherLinesMismatch())
+	a.checkRow(data.Row, row, metadata)
+}
+
+func (a *sheetAudit) checkTeachers(row int, mismatch bool) {
+	if mismatch {
+		a.warn(row, excel.WarningTeachers, "las columnas de docentes tienen distinta cantidad de líneas")
+	}
+}
+
+// checkRow checks the enriched data of a row and counts its course.
+func (a *sheetAudit) checkRow(rowNumber int, row courseRow, metadata *metaServices.MetadataService) {
+	if row.subject.Department.Code != "" && row.subject.Department.Name == "" {
+		a.warn(rowNumber, excel.WarningMetadata, "departamento desconocido: %s", row.subject.Department.Code)
+	}
+
+	// Without the career metadata nothing can be resolved, it is reported once by checkCareer
+	if metadata.HasCareerInfo() {
+		for _, emphasis := range row.curriculum.Emphases {
+			if emphasis.Name == "" {
+				a.warn(rowNumber, excel.WarningEmphasis, "énfasis desconocido: %s", emphasis.Code)
+			}
+		}
+
+		if row.curriculum.Semester == 0 {
+			a.warn(rowNumber, excel.WarningMetadata, "semestre desconocido, la materia no figura en los metadatos")
+		}
+	}
+
+	a.subjects[row.subject.Name] = struct{}{}
+
+	// The same course is repeated for every plan that includes it, check it once
+	key := row.course.Name + "|" + row.course.Section + "|" + row.course.Shift
+	if _, ok := a.courses[key]; ok {
+		return
+	}
+	a.courses[key] = struct{}{}
+
+	if missing := missingData(row); len(missing) > 0 {
+		a.report.Incomplete = append(a.report.Incomplete, excel.IncompleteCourse{
+			Row:     rowNumber,
+			Course:  row.course.Name,
+			Section: row.course.Section,
+			Missing: missing,
+		})
+	}
+}
+
+// finish closes the report of a sheet that was fully processed.
+func (a *sheetAudit) finish() excel.SheetReport {
+	a.report.Subjects = len(a.subjects)
+	a.report.Courses = len(a.courses)
+	a.report.Duration = time.Since(a.start)
+	return a.report
+}
+
+// fail closes the report of a sheet that could not be processed.
+func (a *sheetAudit) fail(err error) excel.SheetReport {
+	a.report.Error = err.Error()
+	return a.finish()
+}
+
+// enrichRow completes the subject and curriculum of a row with the known metadata.
+func enrichRow(metadata *metaServices.MetadataService, row courseRow) courseRow {
+	metadata.EnrichSubject(&row.subject)
+	metadata.EnrichCurriculum(row.subject, &row.curriculum)
+
+	return row
+}
+
+// missingData lists the data students need that the course does not have. Exam only courses
+// have no classes, so only their teachers are checked.
+func missingData(row courseRow) []string {
+	var missing []string
+
+	if row.course.Type != academic.ExamOnly {
+		hasTimes, hasRooms := false, true
+		for _, s := range row.course.Schedule {
+			if s.Time.Start == nil || s.Time.End == nil {
+				continue
+			}
+			hasTimes = true
+			if s.Room.Kind == academic.RoomUnknown {
+				hasRooms = false
+			}
+		}
+
+		if !hasTimes {
+			missing = append(missing, missingTimes)
+		} else if !hasRooms {
+			missing = append(missing, missingRooms)
+		}
+	}
+
+	hasTeachers := false
+	for _, t := range row.teachers {
+		if !t.IsPlaceholder() {
+			hasTeachers = true
+			break
+		}
+	}
+	if !hasTeachers {
+		missing = append(missing, missingTeachers)
+	}
+
+	return missing
+}
@@ -0,0 +1,153 @@
+package excel
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser"
+	parserCommons "github.com/elias-gill/poliplanner2/internal/infrastructure/parser/commons"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/layout"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	"github.com/elias-gill/poliplanner2/internal/model/excel"
+	metaServices "github.com/elias-gill/poliplanner2/internal/service/metadata"
+)
+
+func TestMapRowKeepsEmptySubject(t *testing.T) {
+	// Rows without a subject are imported, as they always were, and only reported
+	row := mapRow(parser.SubjectDTO{Row: 12, RawSubjectName: "  ", Section: "TQ"})
+	if row.course.Section != "TQ" {
+		t.Errorf("row was not mapped: %+v", row.course)
+	}
+}
+
+func TestMissingData(t *testing.T) {
+	start := time.Date(0, 1, 1, 7, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+	slot := academic.TimeSlot{Start: &start, End: &end}
+
+	room := academic.Location{Raw: "A53", Kind: academic.RoomPhysical}
+	teacher := academic.Teacher{FirstName: "Juan", LastName: "Pérez"}
+	placeholder := academic.Teacher{FirstName: "A CONFIRMAR"}
+
+	tests := []struct {
+		name     string
+		row      courseRow
+		expected []string
+	}{
+		{
+			name: "complete",
+			row: courseRow{
+				course:   academic.Course{Schedule: []academic.ClassSession{{Day: academic.Monday, Time: slot, Room: room}}},
+				teachers: []academic.Teacher{teacher},
+			},
+		},
+		{
+			name: "no sessions nor teachers",
+			row: courseRow{
+				course:   academic.Course{Schedule: []academic.ClassSession{{Day: academic.Monday}}},
+				teachers: []academic.Teacher{placeholder},
+			},
+			expected: []string{missingTimes, missingTeachers},
+		},
+		{
+			name: "session without room",
+			row: courseRow{
+				course: academic.Course{Schedule: []academic.ClassSession{
+					{Day: academic.Monday, Time: slot, Room: room},
+					{Day: academic.Wednesday, Time: slot, Room: academic.Location{Raw: "A CONFIRMAR"}},
+				}},
+				teachers: []academic.Teacher{placeholder, teacher},
+			},
+			expected: []string{missingRooms},
+		},
+		{
+			name: "exam only course has no classes",
+			row: courseRow{
+				course:   academic.Course{Type: academic.ExamOnly},
+				teachers: []academic.Teacher{teacher},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := missingData(tc.row)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("missingData() = %v; want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSheetAudit(t *testing.T) {
+	metadata, err := metaServices.NewMetadataService("test_metadata")
+	if err != nil {
+		t.Fatalf("cannot load metadata: %v", err)
+	}
+
+	rows := []parser.SubjectDTO{
+		{Row: 5, RawSubjectName: "Cálculo I", Section: "TQ", Department: "DCB"},
+		{Row: 6, RawSubjectName: "Cálculo I", Section: "TQ", Department: "DCB", Plan: "2013"}, // Same course, other plan
+		{Row: 7, RawSubjectName: ""},
+		{Row: 8, RawSubjectName: "Materia Nueva", Section: "NA", Department: "XYZ"},
+	}
+	rows[0].SetPartial1Date("a confirmar")
+	rows[0].SetTeachersFirtNames("Juan\nAna")
+	rows[0].SetTeachersLastNames("Pérez\nGómez")
+	rows[0].SetTeachersEmails("jperez@pol.una.py")
+	rows[3].SetEmphases("ZZ")
+
+	audit := newScheduleAudit(time.Now(), "IIN", metadata)
+	for _, data := range rows {
+		audit.count()
+		audit.add(data, enrichRow(metadata, mapRow(data)), metadata)
+	}
+	audit.skip(9, skipNoCareer)
+	audit.parsed("test.json", 3, []layout.UnsureColumn{
+		{Kind: layout.UnsureSimilar, Column: 2, Cell: "Secion", Header: "seccion", Similarity: 0.86},
+		{Kind: layout.UnsureMissing, Column: -1, Header: "aulaLunes"},
+	})
+	audit.ignored([]parserCommons.IgnoredRow{{Row: 1, Text: "HORARIO DE CLASES"}})
+	report := audit.finish()
+
+	if report.Career != "IIN" || report.Layout != "test.json" || report.Rows != 4 {
+		t.Errorf("unexpected sheet data: %+v", report)
+	}
+	// The row without a subject is imported too
+	if report.Subjects != 3 || report.Courses != 3 {
+		t.Errorf("subjects = %d, courses = %d; want 3 and 3", report.Subjects, report.Courses)
+	}
+
+	// Rows the parser did not read go first
+	skipped := []excel.SkippedRow{
+		{Row: 1, Reason: `antes del encabezado: "HORARIO DE CLASES"`},
+		{Row: 9, Reason: skipNoCareer},
+	}
+	if !reflect.DeepEqual(report.Skipped, skipped) {
+		t.Errorf("skipped = %+v; want %+v", report.Skipped, skipped)
+	}
+
+	var got []string
+	for _, w := range report.Warnings {
+		got = append(got, fmt.Sprintf("%d %s", w.Row, w.Kind))
+	}
+	expected := []string{
+		"3 encabezado", // Header warnings go first
+		"3 encabezado",
+		"5 fecha",
+		"5 docentes",
+		"7 asignatura",
+		"7 metadatos", // Semester
+		"8 metadatos", // Department
+		"8 énfasis",
+		"8 metadatos", // Semester
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("warnings = %v; want %v", got, expected)
+	}
+	if msg := report.Warnings[0].Message; msg != `columna C ("Secion") leída como seccion con 86% de similitud` {
+		t.Errorf("unexpected header warning: %s", msg)
+	}
+}
@@ -0,0 +1,377 @@
+package excel
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	"github.com/elias-gill/poliplanner2/internal/model/excel"
+)
+
+const (
+	snapshotHourLayout = "15:04"
+	snapshotDateLayout = "2006-01-02 15:04"
+)
+
+// ============================================================
+// Snapshot Builders
+// ============================================================
+
+// buildCourseSnapshot keeps the values of a sheet row after the mapping and the metadata
+// enrichment, the same ones that are persisted.
+func buildCourseSnapshot(
+	sub academic.Subject,
+	curriculum academic.Curriculum,
+	course academic.Course,
+	teachers []academic.Teacher,
+) excel.CourseSnapshot {
+	snap := excel.CourseSnapshot{
+		Subject:       sub.Name,
+		Department:    sub.Department.Code,
+		Plan:          curriculum.Plan.Code,
+		Level:         curriculum.Level,
+		Semester:      curriculum.Semester,
+		Name:          course.Name,
+		Type:          int(course.Type),
+		Section:       course.Section,
+		Shift:         course.Shift,
+		SaturdayDates: course.SaturdayDates,
+		Committee:     [3]string{course.Comitee.President, course.Comitee.Member1, course.Comitee.Member2},
+	}
+
+	for _, e := range curriculum.Emphases {
+		snap.Emphases = append(snap.Emphases, e.Code)
+	}
+
+	for _, s := range course.Schedule {
+		session := excel.SessionSnapshot{Day: int(s.Day), Room: s.Room.Raw}
+		if s.Time.Start != nil {
+			session.Start = s.Time.Start.Format(snapshotHourLayout)
+		}
+		if s.Time.End != nil {
+			session.End = s.Time.End.Format(snapshotHourLayout)
+		}
+		snap.Sessions = append(snap.Sessions, session)
+	}
+
+	for _, e := range course.Exams {
+		exam := excel.ExamSnapshot{
+			Type:     string(e.Type),
+			Instance: int(e.Instance),
+			Date:     e.Date().In(timezone.ParaguayTZ).Format(snapshotDateLayout),
+			Room:     e.Room.Raw,
+		}
+		if e.HasRevisionDate() {
+			exam.Revision = e.Revision().In(timezone.ParaguayTZ).Format(snapshotDateLayout)
+		}
+		snap.Exams = append(snap.Exams, exam)
+	}
+
+	for _, t := range teachers {
+		snap.Teachers = append(snap.Teachers, excel.TeacherSnapshot{
+			Title:     t.Title,
+			FirstName: t.FirstName,
+			LastName:  t.LastName,
+			Email:     t.Email,
+		})
+	}
+
+	return snap
+}
+
+// restoreRow maps a snapshot back to the domain, the inverse of buildCourseSnapshot.
+func restoreRow(snap excel.CourseSnapshot) courseRow {
+	row := courseRow{
+		subject: academic.Subject{
+			Name:       snap.Subject,
+			Department: academic.Department{Code: snap.Department},
+		},
+		curriculum: academic.Curriculum{
+			Level:    snap.Level,
+			Semester: snap.Semester,
+			Plan:     academic.Plan{Code: snap.Plan},
+		},
+		course: academic.Course{
+			Name:          snap.Name,
+			Type:          academic.CourseType(snap.Type),
+			Section:       snap.Section,
+			Shift:         snap.Shift,
+			SaturdayDates: snap.SaturdayDates,
+			Comitee: academic.Committee{
+				President: snap.Committee[0],
+				Member1:   snap.Committee[1],
+				Member2:   snap.Committee[2],
+			},
+		},
+	}
+
+	for _, code := range snap.Emphases {
+		row.curriculum.Emphases = append(row.curriculum.Emphases, academic.Emphasis{Code: code})
+	}
+
+	for _, s := range snap.Sessions {
+		row.course.Schedule = append(row.course.Schedule, academic.ClassSession{
+			Day:  academic.WeekDay(s.Day),
+			Room: classifyRoom(s.Room),
+			Time: academic.TimeSlot{
+				Start: parseSnapshotTime(snapshotHourLayout, s.Start),
+				End:   parseSnapshotTime(snapshotHourLayout, s.End),
+			},
+		})
+	}
+
+	for _, e := range snap.Exams {
+		exam := academic.Exam{
+			Type:     academic.ExamType(e.Type),
+			Instance: academic.ExamInstance(e.Instance),
+			Room:     classifyRoom(e.Room),
+		}
+		exam.SetDate(parseSnapshotTime(snapshotDateLayout, e.Date))
+		exam.SetRevision(parseSnapshotTime(snapshotDateLayout, e.Revision))
+
+		// Exams without a date are not persisted, the same as on the import
+		if exam.HasDate() {
+			row.course.Exams = append(row.course.Exams, exam)
+		}
+	}
+
+	for _, t := range snap.Teachers {
+		row.teachers = append(row.teachers, academic.Teacher{
+			Title:     t.Title,
+			FirstName: t.FirstName,
+			LastName:  t.LastName,
+			Email:     t.Email,
+		})
+	}
+
+	return row
+}
+
+// parseSnapshotTime returns nil for empty or invalid values.
+func parseSnapshotTime(layout, value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.ParseInLocation(layout, value, timezone.ParaguayTZ)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// ============================================================
+// Snapshot Diff
+// ============================================================
+
+// careerCourses indexes the courses of a career by key, keeping the order of the sheet.
+type careerCourses struct {
+	keys    []string
+	courses map[string]excel.CourseSnapshot
+}
+
+func indexSnapshot(s *excel.Snapshot) map[string]*careerCourses {
+	careers := make(map[string]*careerCourses, len(s.Careers))
+
+	for _, c := range s.Careers {
+		idx, ok := careers[c.Code]
+		if !ok {
+			idx = &careerCourses{courses: make(map[string]excel.CourseSnapshot)}
+			careers[c.Code] = idx
+		}
+
+		// Only the first row of a course counts, the rest are the same course for other plans
+		for _, course := range c.Courses {
+			key := course.Key()
+			if _, ok := idx.courses[key]; ok {
+				continue
+			}
+			idx.keys = append(idx.keys, key)
+			idx.courses[key] = course
+		}
+	}
+
+	return careers
+}
+
+// diffSnapshots compares two snapshots career by career. Careers are sorted by code, and
+// sections keep the order of the sheet.
+func diffSnapshots(from, to *excel.Snapshot) []excel.CareerDiff {
+	before := indexSnapshot(from)
+	after := indexSnapshot(to)
+
+	codes := make([]string, 0, len(before)+len(after))
+	for code := range before {
+		codes = append(codes, code)
+	}
+	for code := range after {
+		if _, ok := before[code]; !ok {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	empty := &careerCourses{courses: map[string]excel.CourseSnapshot{}}
+
+	var diffs []excel.CareerDiff
+	for _, code := range codes {
+		a, ok := before[code]
+		if !ok {
+			a = empty
+		}
+		b, ok := after[code]
+		if !ok {
+			b = empty
+		}
+
+		diff := excel.CareerDiff{Career: code}
+
+		for _, key := range a.keys {
+			if _, ok := b.courses[key]; !ok {
+				diff.Removed = append(diff.Removed, sectionRef(a.courses[key]))
+			}
+		}
+
+		for _, key := range b.keys {
+			course := b.courses[key]
+
+			old, ok := a.courses[key]
+			if !ok {
+				diff.Added = append(diff.Added, sectionRef(course))
+				continue
+			}
+
+			if changes := courseChanges(old, course); len(changes) > 0 {
+				diff.Changed = append(diff.Changed, excel.SectionChanges{
+					Section: sectionRef(course),
+					Changes: changes,
+				})
+			}
+		}
+
+		if len(diff.Added)+len(diff.Removed)+len(diff.Changed) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	return diffs
+}
+
+func sectionRef(c excel.CourseSnapshot) excel.SectionRef {
+	return excel.SectionRef{Course: c.Name, Section: c.Section, Shift: c.Shift}
+}
+
+// courseChanges lists the class times, rooms, exam dates and teachers that differ between
+// two versions of the same course.
+func courseChanges(a, b excel.CourseSnapshot) []excel.FieldChange {
+	var changes []excel.FieldChange
+
+	// Classes, compared weekday by weekday
+	sessionsA := sessionsByDay(a.Sessions)
+	sessionsB := sessionsByDay(b.Sessions)
+
+	for day := range 7 {
+		sa, okA := sessionsA[day]
+		sb, okB := sessionsB[day]
+		weekday := academic.WeekDay(day).String()
+
+		if timeA, timeB := sessionTime(sa, okA), sessionTime(sb, okB); timeA != timeB {
+			changes = append(changes, excel.FieldChange{
+				Kind: excel.ChangeTime, Field: weekday, Before: timeA, After: timeB,
+			})
+		}
+
+		if okA && okB && !sameRoom(sa.Room, sb.Room) {
+			changes = append(changes, excel.FieldChange{
+				Kind: excel.ChangeRoom, Field: weekday, Before: sa.Room, After: sb.Room,
+			})
+		}
+	}
+
+	// Exams, compared by label
+	examsA := examsByLabel(a.Exams)
+	examsB := examsByLabel(b.Exams)
+
+	for _, examType := range []academic.ExamType{academic.ExamPartial, academic.ExamFinal} {
+		for _, instance := range []academic.ExamInstance{academic.Instance1, academic.Instance2} {
+			label := academic.ExamLabel(examType, instance)
+			ea, okA := examsA[label]
+			eb, okB := examsB[label]
+
+			if dateA, dateB := examDate(ea, okA), examDate(eb, okB); dateA != dateB {
+				changes = append(changes, excel.FieldChange{
+					Kind: excel.ChangeExam, Field: label, Before: dateA, After: dateB,
+				})
+			}
+
+			if okA && okB && !sameRoom(ea.Room, eb.Room) {
+				changes = append(changes, excel.FieldChange{
+					Kind: excel.ChangeRoom, Field: label, Before: ea.Room, After: eb.Room,
+				})
+			}
+		}
+	}
+
+	// Teachers, as a whole
+	if teachersA, teachersB := teacherNames(a.Teachers), teacherNames(b.Teachers); teachersA != teachersB {
+		changes = append(changes, excel.FieldChange{
+			Kind: excel.ChangeTeacher, Before: teachersA, After: teachersB,
+		})
+	}
+
+	return changes
+}
+
+func sessionsByDay(sessions []excel.SessionSnapshot) map[int]excel.SessionSnapshot {
+	days := make(map[int]excel.SessionSnapshot, len(sessions))
+	for _, s := range sessions {
+		days[s.Day] = s
+	}
+	return days
+}
+
+func sessionTime(s excel.SessionSnapshot, ok bool) string {
+	if !ok || (s.Start == "" && s.End == "") {
+		return ""
+	}
+	return s.Start + " - " + s.End
+}
+
+func examsByLabel(exams []excel.ExamSnapshot) map[string]excel.ExamSnapshot {
+	labels := make(map[string]excel.ExamSnapshot, len(exams))
+	for _, e := range exams {
+		labels[academic.ExamLabel(academic.ExamType(e.Type), academic.ExamInstance(e.Instance))] = e
+	}
+	return labels
+}
+
+// examDate formats the exam date as shown on the site (eg: "25/11/2024 15:00").
+func examDate(e excel.ExamSnapshot, ok bool) string {
+	if !ok {
+		return ""
+	}
+
+	date, hour, _ := strings.Cut(e.Date, " ")
+	if parts := strings.Split(date, "-"); len(parts) == 3 {
+		date = parts[2] + "/" + parts[1] + "/" + parts[0]
+	}
+	if hour == "" || hour == "00:00" {
+		return date
+	}
+	return date + " " + hour
+}
+
+// sameRoom ignores case and spacing differences on the room text.
+func sameRoom(a, b string) bool {
+	return strings.EqualFold(strings.Join(strings.Fields(a), ""), strings.Join(strings.Fields(b), ""))
+}
+
+func teacherNames(teachers []excel.TeacherSnapshot) string {
+	names := make([]string, len(teachers))
+	for i, t := range teachers {
+		names[i] = t.FullName()
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,100 @@
+package excel
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	"github.com/elias-gill/poliplanner2/internal/model/excel"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	calculo := excel.CourseSnapshot{
+		Name: "Cálculo I", Section: "TQ", Shift: "T",
+		Sessions: []excel.SessionSnapshot{{Day: 1, Start: "07:00", End: "08:30", Room: "A53"}},
+		Exams:    []excel.ExamSnapshot{{Type: "partial", Instance: 1, Date: "2026-04-10 08:00", Room: "A53"}},
+		Teachers: []excel.TeacherSnapshot{{Title: "Dr.", FirstName: "Juan", LastName: "Pérez"}},
+	}
+	fisica := excel.CourseSnapshot{Name: "Física I", Section: "TR", Shift: "T"}
+	algebra := excel.CourseSnapshot{Name: "Álgebra", Section: "NA", Shift: "N"}
+
+	changed := calculo
+	changed.Sessions = []excel.SessionSnapshot{
+		{Day: 1, Start: "07:00", End: "08:30", Room: "a 53"}, // Same room, different spelling
+		{Day: 3, Start: "19:00", End: "20:30", Room: "B12"},
+	}
+	changed.Exams = []excel.ExamSnapshot{{Type: "partial", Instance: 1, Date: "2026-04-17 08:00", Room: "F14"}}
+	changed.Teachers = []excel.TeacherSnapshot{{FirstName: "Ana", LastName: "Gómez"}}
+
+	from := &excel.Snapshot{Careers: []excel.CareerSnapshot{
+		{Code: "IIN", Courses: []excel.CourseSnapshot{calculo, fisica}},
+		{Code: "LCIK", Courses: []excel.CourseSnapshot{calculo}},
+	}}
+	to := &excel.Snapshot{Careers: []excel.CareerSnapshot{
+		// Repeated rows for other plans are the same course
+		{Code: "IIN", Courses: []excel.CourseSnapshot{changed, algebra, changed}},
+		{Code: "LCIK", Courses: []excel.CourseSnapshot{calculo}},
+	}}
+
+	diffs := diffSnapshots(from, to)
+
+	if len(diffs) != 1 || diffs[0].Career != "IIN" {
+		t.Fatalf("diffSnapshots() = %+v; want only IIN", diffs)
+	}
+
+	diff := diffs[0]
+	if len(diff.Added) != 1 || diff.Added[0].String() != "Álgebra (NA)" {
+		t.Errorf("added = %v; want Álgebra", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Course != "Física I" {
+		t.Errorf("removed = %v; want Física I", diff.Removed)
+	}
+	if len(diff.Changed) != 1 {
+		t.Fatalf("changed = %+v; want Cálculo I", diff.Changed)
+	}
+
+	want := []excel.FieldChange{
+		{Kind: excel.ChangeTime, Field: "Miércoles", Before: "", After: "19:00 - 20:30"},
+		{Kind: excel.ChangeExam, Field: "1° Parcial", Before: "10/04/2026 08:00", After: "17/04/2026 08:00"},
+		{Kind: excel.ChangeRoom, Field: "1° Parcial", Before: "A53", After: "F14"},
+		{Kind: excel.ChangeTeacher, Before: "Dr. Juan Pérez", After: "Ana Gómez"},
+	}
+
+	got := diff.Changed[0].Changes
+	if len(got) != len(want) {
+		t.Fatalf("changes = %+v; want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("change %d = %+v; want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRestoreRow(t *testing.T) {
+	snap := excel.CourseSnapshot{
+		Subject: "Calculo I", Department: "DCB", Plan: "2013", Level: 1, Semester: 2,
+		Emphases: []string{"SC"},
+		Name:     "Cálculo I", Type: 2, Section: "TQ", Shift: "T", SaturdayDates: "14/03, 21/03",
+		Committee: [3]string{"Dr. Juan Pérez", "Ing. Ana Gómez", ""},
+		Sessions: []excel.SessionSnapshot{
+			{Day: 1, Start: "07:00", End: "08:30", Room: "A53"},
+			{Day: 6, Start: "08:00", End: "11:00", Room: "Virtual"},
+		},
+		Exams: []excel.ExamSnapshot{
+			{Type: "partial", Instance: 1, Date: "2026-04-10 08:00", Room: "A53"},
+			{Type: "final", Instance: 1, Date: "2026-07-01 00:00", Revision: "2026-07-10 14:00", Room: "F14"},
+		},
+		Teachers: []excel.TeacherSnapshot{{Title: "Dr.", FirstName: "Juan", LastName: "Pérez", Email: "jperez@pol.una.py"}},
+	}
+
+	row := restoreRow(snap)
+	got := buildCourseSnapshot(row.subject, row.curriculum, row.course, row.teachers)
+
+	if !reflect.DeepEqual(got, snap) {
+		t.Errorf("buildCourseSnapshot(restoreRow()) = %+v; want %+v", got, snap)
+	}
+	if row.course.Schedule[1].Room.Kind != academic.RoomVirtual {
+		t.Errorf("Saturday room kind = %v; want virtual", row.course.Schedule[1].Room.Kind)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/elias-gill/poliplanner2/internal/config/timezone"
 	"github.com/elias-gill/poliplanner2/internal/infrastructure/source"
+	excelModel "github.com/elias-gill/poliplanner2/internal/model/excel"
 	"github.com/elias-gill/poliplanner2/internal/repository/excel"
 	"github.com/elias-gill/poliplanner2/logger"
 )
@@ -64,7 +65,16 @@ func (s *SyncService) AutoSync(ctx context.Context) error {
 	return nil
 }
 
+// Sync imports the newest schedule and lab sources when they are newer than the imported
+// ones. Labs are synced even when the schedules fail, they do not depend on each other.
 func (s *SyncService) Sync(ctx context.Context) error {
+	schedulesErr := s.syncSchedules(ctx)
+	labsErr := s.syncLabs(ctx)
+
+	return errors.Join(schedulesErr, labsErr)
+}
+
+func (s *SyncService) syncSchedules(ctx context.Context) error {
 	logger.Info("Starting schedule sources sync")
 
 	webSources, err := s.importService.FindLatestSources(ctx)
@@ -78,7 +88,7 @@ func (s *SyncService) Sync(ctx context.Context) error {
 		return fmt.Errorf("no schedule sources found on web")
 	}
 
-	serverVersion, err := s.excelService.GetLatestValidVersion(ctx)
+	serverVersion, err := s.excelService.GetLatestValidVersion(ctx, excelModel.SourceSchedules)
 	if err != nil && !errors.Is(err, ErrNoSheetVersion) {
 		logger.Error("Failed to get newest version from database", "error", err)
 		return fmt.Errorf("error retrieving latest version from db: %w", err)
@@ -118,7 +128,12 @@ func (s *SyncService) persistAllSources(ctx context.Context, sources []source.Sc
 	var errs []error
 	for i, src := range sources {
 		logger.Info("Persisting source", "index", i, "uri", src.Metadata().URI, "name", src.Metadata().Name)
-		if err := s.excelService.PersistSource(ctx, src); err != nil {
+		err := s.excelService.PersistSource(ctx, src)
+		if errors.Is(err, ErrAlreadyImported) {
+			logger.Info("Source already imported, skipping", "index", i, "uri", src.Metadata().URI, "reason", err)
+			continue
+		}
+		if err != nil {
 			logger.Error("Failed to persist source", "index", i, "uri", src.Metadata().URI, "error", err)
 			errs = append(errs, fmt.Errorf("source %d (%s): %w", i, src.Metadata().URI, err))
 		}
@@ -130,3 +145,50 @@ func (s *SyncService) persistAllSources(ctx context.Context, sources []source.Sc
 
 	return nil
 }
+
+func (s *SyncService) syncLabs(ctx context.Context) error {
+	logger.Info("Starting lab sources sync")
+
+	webSources, err := s.importService.FindLatestLabSources(ctx)
+	if err != nil {
+		return fmt.Errorf("error retrieving latest lab sources from web: %w", err)
+	}
+
+	if webSources == nil || len(webSources.Sources) == 0 {
+		return nil
+	}
+
+	serverVersion, err := s.excelService.GetLatestValidVersion(ctx, excelModel.SourceLabs)
+	if err != nil && !errors.Is(err, ErrNoSheetVersion) {
+		return fmt.Errorf("error retrieving latest lab version from db: %w", err)
+	}
+
+	if serverVersion != nil && !webSources.Date.After(serverVersion.ParsedAt) {
+		logger.Info(
+			"Current lab sources are up to date",
+			"web_source_date", webSources.Date,
+			"db_source_date", serverVersion.ParsedAt,
+		)
+		return nil
+	}
+
+	var errs []error
+	for i, src := range webSources.Sources {
+		logger.Info("Persisting lab source", "index", i, "uri", src.Metadata().URI, "name", src.Metadata().Name)
+		err := s.excelService.PersistLabSource(ctx, src)
+		if errors.Is(err, ErrAlreadyImported) {
+			logger.Info("Lab source already imported, skipping", "index", i, "uri", src.Metadata().URI, "reason", err)
+			continue
+		}
+		if err != nil {
+			logger.Error("Failed to persist lab source", "index", i, "uri", src.Metadata().URI, "error", err)
+			errs = append(errs, fmt.Errorf("lab source %d (%s): %w", i, src.Metadata().URI, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("errors persisting lab sources: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package excel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/exceptions"
+	"github.com/elias-gill/poliplanner2/internal/infrastructure/parser/layout"
+	"github.com/elias-gill/poliplanner2/internal/model/excel"
+	"github.com/elias-gill/poliplanner2/logger"
+)
+
+var ErrNoUnmatchedHeader = errors.New("unmatched header not found")
+
+// ListUnmatchedHeaders lists the header rows that no layout matched, latest first.
+func (e ExcelService) ListUnmatchedHeaders(ctx context.Context) ([]*excel.UnmatchedHeader, error) {
+	return e.excelRepository.ListUnmatchedHeaders(ctx)
+}
+
+// LayoutDraft compares an unmatched header with the enabled layouts of its kind and drafts a
+// layout for it, starting from the closest one. Returns ErrNoUnmatchedHeader when there is no
+// such header.
+func (e ExcelService) LayoutDraft(ctx context.Context, id excel.UnmatchedHeaderID) (*excel.LayoutDraft, error) {
+	header, err := e.excelRepository.GetUnmatchedHeader(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get unmatched header %d: %w", id, err)
+	}
+	if header == nil {
+		return nil, ErrNoUnmatchedHeader
+	}
+
+	layouts, err := e.layoutService.Layouts(ctx, header.Kind)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load layouts: %w", err)
+	}
+
+	match, matched := layout.BestMatch(layouts, header.Cells)
+	draftLayout, draftColumns := layout.Draft(match, header.Cells)
+
+	data, err := draftLayout.EncodeJSON()
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode layout draft: %w", err)
+	}
+
+	draft := &excel.LayoutDraft{
+		Header:  header,
+		Matched: matched,
+		JSON:    string(data),
+	}
+
+	unsure := make(map[int]layout.UnsureColumn)
+	if match != nil {
+		draft.Closest = match.Layout.FileName
+		draft.Score = match.Score
+
+		for _, u := range match.Unsure {
+			if u.Kind == layout.UnsureMissing {
+				draft.Missing = append(draft.Missing, u.Header)
+			} else {
+				unsure[u.Column] = u
+			}
+		}
+	}
+
+	for i, cell := range header.Cells {
+		if draftColumns[i] == "" {
+			continue
+		}
+
+		column := excel.DraftColumn{
+			Column: layout.ColumnName(i),
+			Cell:   strings.TrimSpace(cell),
+			Draft:  draftColumns[i],
+		}
+		if match != nil && match.Columns[i] != "" {
+			column.Header = match.Columns[i]
+			column.Similarity = 1
+			if u, ok := unsure[i]; ok {
+				column.Similarity = u.Similarity
+				column.Swapped = u.Kind == layout.UnsureSwapped
+			}
+		}
+
+		draft.Columns = append(draft.Columns, column)
+	}
+
+	return draft, nil
+}
+
+// unmatchedHeader returns the header row of a sheet that failed because no layout matched it,
+// nil when the sheet failed for another reason.
+func unmatchedHeader(err error, sheet string) *excel.UnmatchedHeader {
+	var layoutErr exceptions.LayoutMatchException
+	if !errors.As(err, &layoutErr) {
+		return nil
+	}
+
+	return &excel.UnmatchedHeader{
+		Sheet: strings.TrimSpace(sheet),
+		Row:   layoutErr.Row,
+		Cells: layoutErr.Header,
+	}
+}
+
+// saveUnmatchedHeader keeps the header that made the version fail. The version already has
+// the error, so failures are only logged.
+func (e ExcelService) saveUnmatchedHeader(ctx context.Context, version *excel.SheetVersion, header *excel.UnmatchedHeader) {
+	if header == nil {
+		return
+	}
+
+	header.VersionID = version.ID
+	if err := e.excelRepository.SaveUnmatchedHeader(ctx, header); err != nil {
+		logger.Warn("cannot save unmatched sheet header", "version", version.ID, "sheet", header.Sheet, "error", err)
+	}
+}
@@ -0,0 +1,157 @@
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/elias-gill/poliplanner2/internal/config"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+type campusBuilding struct {
+	Code     string   `json:"code"`
+	Name     string   `json:"name"`
+	Prefixes []string `json:"prefixes"`
+}
+
+type walkingTime struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Minutes int    `json:"minutes"`
+}
+
+type campusInfo struct {
+	MarginMinutes         int              `json:"margin_minutes"`
+	DefaultWalkingMinutes int              `json:"default_walking_minutes"`
+	Buildings             []campusBuilding `json:"buildings"`
+	WalkingTimes          []walkingTime    `json:"walking_times"`
+}
+
+// ===================================
+// =          Public API             =
+// ===================================
+
+// Building is a campus building resolved from a room code.
+type Building struct {
+	Code string
+	Name string
+}
+
+// CampusMap maps room codes to campus buildings and knows the walking time between them.
+// Values are loaded from "campus.json" inside the metadata directory and are meant to be
+// tuned by hand, as the sheets do not publish anything about the campus layout.
+type CampusMap struct {
+	// Extra minutes required on top of the walking time between two classes
+	MarginMinutes int
+
+	defaultWalking int
+	buildings      []campusBuilding
+	walking        map[[2]string]int
+}
+
+// LoadCampusMap reads the campus definition from the metadata directory.
+func LoadCampusMap() (*CampusMap, error) {
+	file := path.Join(config.Get().Paths.MetadataDir, "campus.json")
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read campus file %s: %w", file, err)
+	}
+
+	var info campusInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse campus JSON %s: %w", file, err)
+	}
+
+	return newCampusMap(info), nil
+}
+
+func newCampusMap(info campusInfo) *CampusMap {
+	m := &CampusMap{
+		MarginMinutes:  info.MarginMinutes,
+		defaultWalking: info.DefaultWalkingMinutes,
+		buildings:      info.Buildings,
+		walking:        make(map[[2]string]int, len(info.WalkingTimes)*2),
+	}
+
+	for i := range m.buildings {
+		m.buildings[i].Code = strings.ToUpper(m.buildings[i].Code)
+		for j, prefix := range m.buildings[i].Prefixes {
+			m.buildings[i].Prefixes[j] = strings.ToUpper(strings.TrimSpace(prefix))
+		}
+	}
+
+	// Walking times are symmetric
+	for _, w := range info.WalkingTimes {
+		from := strings.ToUpper(w.From)
+		to := strings.ToUpper(w.To)
+		m.walking[[2]string{from, to}] = w.Minutes
+		m.walking[[2]string{to, from}] = w.Minutes
+	}
+
+	return m
+}
+
+// BuildingOf resolves the building of a room. Parsed room codes already know their building
+// letters, which must be one of the prefixes. Other rooms use the longest prefix followed by
+// a delimiter, so "A. Magna" is on the Aula Magna but "Auditorio" is not on the block A.
+// Returns false for virtual or unknown locations and for rooms that match no building.
+func (m *CampusMap) BuildingOf(loc academic.Location) (Building, bool) {
+	if !loc.IsPhysical() {
+		return Building{}, false
+	}
+
+	matches := func(prefix string) bool {
+		if loc.Building != "" {
+			return loc.Building == prefix
+		}
+		return hasWordPrefix(loc.Code(), prefix)
+	}
+
+	var (
+		best    *campusBuilding
+		bestLen int
+	)
+	for i := range m.buildings {
+		for _, prefix := range m.buildings[i].Prefixes {
+			if len(prefix) > bestLen && matches(prefix) {
+				best = &m.buildings[i]
+				bestLen = len(prefix)
+			}
+		}
+	}
+
+	if best == nil {
+		return Building{}, false
+	}
+
+	return Building{Code: best.Code, Name: best.Name}, true
+}
+
+// hasWordPrefix reports if the room starts with the prefix and the prefix is not the start of
+// a longer word, eg: "A 12" and "A-12" start with "A" but "AB12" or "AULA" do not.
+func hasWordPrefix(room, prefix string) bool {
+	if !strings.HasPrefix(room, prefix) {
+		return false
+	}
+
+	next, _ := utf8.DecodeRuneInString(room[len(prefix):])
+	return next == utf8.RuneError || !unicode.IsLetter(next)
+}
+
+// WalkingMinutes returns the walking time between two buildings. Pairs missing on the
+// campus file use the default walking time.
+func (m *CampusMap) WalkingMinutes(from, to Building) int {
+	if from.Code == to.Code {
+		return 0
+	}
+	if minutes, ok := m.walking[[2]string{from.Code, to.Code}]; ok {
+		return minutes
+	}
+	return m.defaultWalking
+}
@@ -0,0 +1,117 @@
+package metadata
+
+import (
+	"testing"
+
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+)
+
+func testCampus() *CampusMap {
+	return newCampusMap(campusInfo{
+		MarginMinutes:         2,
+		DefaultWalkingMinutes: 6,
+		Buildings: []campusBuilding{
+			{Code: "A", Name: "Bloque A", Prefixes: []string{"A"}},
+			{Code: "F", Name: "Bloque F", Prefixes: []string{"F"}},
+			{Code: "magna", Name: "Aula Magna", Prefixes: []string{"a. magna"}},
+			{Code: "LAB", Name: "Laboratorios", Prefixes: []string{"LAB"}},
+		},
+		WalkingTimes: []walkingTime{
+			{From: "A", To: "F", Minutes: 7},
+		},
+	})
+}
+
+func TestCampusMap_BuildingOf(t *testing.T) {
+	campus := testCampus()
+
+	tests := []struct {
+		name     string
+		loc      academic.Location
+		expected string
+		found    bool
+	}{
+		{
+			name:     "Parsed room code",
+			loc:      academic.Location{Raw: "A56", Kind: academic.RoomPhysical, Building: "A", Number: "56"},
+			expected: "A",
+			found:    true,
+		},
+		{
+			name:     "Longest prefix wins",
+			loc:      academic.Location{Raw: "A. Magna", Kind: academic.RoomPhysical},
+			expected: "MAGNA",
+			found:    true,
+		},
+		{
+			name:     "Free text room",
+			loc:      academic.Location{Raw: "Lab MS", Kind: academic.RoomPhysical},
+			expected: "LAB",
+			found:    true,
+		},
+		{
+			name:     "Free text room code",
+			loc:      academic.Location{Raw: "A - 12 bis", Kind: academic.RoomPhysical},
+			expected: "A",
+			found:    true,
+		},
+		{
+			name:  "Free text starting with a building letter",
+			loc:   academic.Location{Raw: "Auditorio", Kind: academic.RoomPhysical},
+			found: false,
+		},
+		{
+			name:  "Parsed room code of an unknown building",
+			loc:   academic.Location{Raw: "AB-12", Kind: academic.RoomPhysical, Building: "AB", Number: "12"},
+			found: false,
+		},
+		{
+			name:  "Free text starting with a prefix",
+			loc:   academic.Location{Raw: "Laboratorio de Física", Kind: academic.RoomPhysical},
+			found: false,
+		},
+		{
+			name:  "Unknown building",
+			loc:   academic.Location{Raw: "CETUNA", Kind: academic.RoomPhysical},
+			found: false,
+		},
+		{
+			name:  "Virtual session",
+			loc:   academic.Location{Raw: "Virtual", Kind: academic.RoomVirtual},
+			found: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := campus.BuildingOf(tc.loc)
+			if ok != tc.found {
+				t.Fatalf("BuildingOf(%+v) found = %v; want %v", tc.loc, ok, tc.found)
+			}
+			if ok && got.Code != tc.expected {
+				t.Errorf("BuildingOf(%+v) = %q; want %q", tc.loc, got.Code, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCampusMap_WalkingMinutes(t *testing.T) {
+	campus := testCampus()
+
+	a := Building{Code: "A"}
+	f := Building{Code: "F"}
+	lab := Building{Code: "LAB"}
+
+	if got := campus.WalkingMinutes(a, a); got != 0 {
+		t.Errorf("same building = %d; want 0", got)
+	}
+	if got := campus.WalkingMinutes(a, f); got != 7 {
+		t.Errorf("A -> F = %d; want 7", got)
+	}
+	if got := campus.WalkingMinutes(f, a); got != 7 {
+		t.Errorf("F -> A = %d; want 7 (symmetric)", got)
+	}
+	if got := campus.WalkingMinutes(a, lab); got != 6 {
+		t.Errorf("A -> LAB = %d; want default 6", got)
+	}
+}
@@ -69,6 +69,12 @@ func NewMetadataService(careerCode string) (*MetadataService, error) {
 	return s, nil
 }
 
+// HasCareerInfo reports whether the career has a curriculum file. Without it only the
+// departments can be enriched.
+func (s *MetadataService) HasCareerInfo() bool {
+	return s.hasCareerInfo
+}
+
 // EnrichCareer replaces or populates the career name with the canonical
 // string defined in the static metadata configuration.
 func (s *MetadataService) EnrichCareer(data *academic.Career) {
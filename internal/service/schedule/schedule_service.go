@@ -4,12 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
+	"time"
 
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
 	"github.com/elias-gill/poliplanner2/internal/model/academic"
 	"github.com/elias-gill/poliplanner2/internal/model/schedule"
 	"github.com/elias-gill/poliplanner2/internal/model/user"
 	schedRepository "github.com/elias-gill/poliplanner2/internal/repository/schedule"
+	academicSrv "github.com/elias-gill/poliplanner2/internal/service/academic"
+	"github.com/elias-gill/poliplanner2/internal/service/metadata"
 	"github.com/elias-gill/poliplanner2/logger"
 )
 
@@ -21,13 +26,35 @@ var (
 	ErrNotFound          = errors.New("ErrNotFound")
 )
 
+const (
+	// nextClassSearchDays bounds the search of the next class, long enough to cross a
+	// recess week
+	nextClassSearchDays = 14
+	// upcomingExamDays is how far ahead the dashboard lists the exams
+	upcomingExamDays = 14
+)
+
+// outsideClasses marks the days of the current week that are not part of the weeks of
+// classes of the period (eg: the exam weeks).
+var outsideClasses = academic.DayOff{Description: "Fuera del período de clases", Kind: academic.DayOffOther}
+
 type ScheduleService struct {
 	scheduleRepository schedRepository.ScheduleRepository
+	calendarService    *academicSrv.CalendarService
+
+	// Optional, walking distance warnings are disabled when nil
+	campus *metadata.CampusMap
 }
 
-func New(scheduleRepo schedRepository.ScheduleRepository) *ScheduleService {
+func New(
+	scheduleRepo schedRepository.ScheduleRepository,
+	calendarService *academicSrv.CalendarService,
+	campus *metadata.CampusMap,
+) *ScheduleService {
 	return &ScheduleService{
 		scheduleRepository: scheduleRepo,
+		calendarService:    calendarService,
+		campus:             campus,
 	}
 }
 
@@ -46,28 +73,80 @@ func (s ScheduleService) ListUserSchedules(ctx context.Context, userID user.User
 // ListUserSchedules returns the details for the dashboard view of a given schedule
 func (s ScheduleService) GetScheduleOverview(ctx context.Context, userID user.UserID, scheduleID schedule.ScheduleID) (*schedule.StudentScheduleView, error) {
 	logger.Debug("GetSchedule called", "userID", userID, "scheduleID", scheduleID)
-	sche, err := s.scheduleRepository.GetDetailsByID(ctx, scheduleID)
+	sche, err := s.getOwnedSchedule(ctx, userID, scheduleID)
 	if err != nil {
-		logger.Debug("cannot get schedule details", "scheduleID", scheduleID, "error", err)
-		return nil, ErrNotFound
-	}
-
-	if sche.Owner != userID {
-		logger.Debug("permission denied for schedule", "scheduleID", scheduleID, "userID", userID)
-		return nil, ErrPermissionDenied
+		return nil, err
 	}
 
 	// Map info into our view models
 	view := &schedule.StudentScheduleView{
+		ID:     sche.ID,
 		Weekly: s.buildWeeklySchedule(sche.Courses),
 		Exams:  s.extractExams(sche.Courses),
 		Info:   s.buildCoursesInfo(sche.Courses),
 	}
+	view.ThisWeek = s.buildThisWeek(ctx, view.Weekly, time.Now().In(timezone.ParaguayTZ))
 
 	logger.Debug("GetSchedule successful", "scheduleID", scheduleID, "userID", userID)
 	return view, nil
 }
 
+// GetClassCalendar returns the classes of a schedule as weekly events over the weeks of
+// classes of the current period, excluding the holidays and days without classes.
+func (s ScheduleService) GetClassCalendar(ctx context.Context, userID user.UserID, scheduleID schedule.ScheduleID) (*schedule.ClassCalendarView, error) {
+	sche, err := s.getOwnedSchedule(ctx, userID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	classes, err := s.calendarService.ClassDates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get class dates: %w", err)
+	}
+
+	daysOff, err := s.calendarService.DaysOffBetween(ctx, classes)
+	if err != nil {
+		return nil, fmt.Errorf("list days off: %w", err)
+	}
+
+	weekly := s.buildWeeklySchedule(sche.Courses)
+
+	return &schedule.ClassCalendarView{
+		Title:   sche.Title,
+		Dates:   classes,
+		Events:  classEvents(weekly, classes, daysOff),
+		DaysOff: daysOff,
+	}, nil
+}
+
+// GetToday returns the classes left for today, the next class and the exams of the following
+// days for the given moment. Holidays and days outside the weeks of classes are skipped.
+func (s ScheduleService) GetToday(ctx context.Context, userID user.UserID, scheduleID schedule.ScheduleID, now time.Time) (*schedule.TodayView, error) {
+	sche, err := s.getOwnedSchedule(ctx, userID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := startOfDay(now)
+	horizon := academic.DateRange{Start: today, End: today.AddDate(0, 0, nextClassSearchDays)}
+
+	// The academic calendar is optional here, without it every day has classes
+	classes, err := s.calendarService.ClassDates(ctx)
+	if err != nil {
+		logger.Warn("cannot get class dates", "error", err)
+	}
+
+	daysOff, err := s.calendarService.DaysOffBetween(ctx, horizon)
+	if err != nil {
+		logger.Warn("cannot list days off", "error", err)
+	}
+
+	view := todayClasses(s.buildWeeklySchedule(sche.Courses), classes, daysOff, now)
+	view.Exams = upcomingExams(sche.Courses, now)
+
+	return view, nil
+}
+
 // Save persists a schedule and returns its ID
 func (s ScheduleService) CreateSchedule(ctx context.Context, userID user.UserID, title string, courseIDs []academic.CourseID) (schedule.ScheduleID, error) {
 	logger.Debug("CreateSchedule called", "title", title, "owner", userID)
@@ -120,6 +199,22 @@ func (s ScheduleService) Delete(ctx context.Context, userID user.UserID, schedul
 	return nil
 }
 
+// getOwnedSchedule returns the details of a schedule, checking that it belongs to the user.
+func (s ScheduleService) getOwnedSchedule(ctx context.Context, userID user.UserID, scheduleID schedule.ScheduleID) (*schedule.ScheduleDetails, error) {
+	sche, err := s.scheduleRepository.GetDetailsByID(ctx, scheduleID)
+	if err != nil {
+		logger.Debug("cannot get schedule details", "scheduleID", scheduleID, "error", err)
+		return nil, ErrNotFound
+	}
+
+	if sche.Owner != userID {
+		logger.Debug("permission denied for schedule", "scheduleID", scheduleID, "userID", userID)
+		return nil, ErrPermissionDenied
+	}
+
+	return sche, nil
+}
+
 // TitleIsAvailable checks if the user has a schedule with the same title
 func (s ScheduleService) TitleIsAvailable(ctx context.Context, userID user.UserID, title string) (bool, error) {
 	logger.Debug("TitleIsAvailable called", "userID", userID, "title", title)
@@ -175,15 +270,255 @@ func (s ScheduleService) buildWeeklySchedule(courses []academic.CourseSummaryVie
 	sortSlots(weekly.Friday)
 	sortSlots(weekly.Saturday)
 
+	s.flagTransfers(weekly.Monday)
+	s.flagTransfers(weekly.Tuesday)
+	s.flagTransfers(weekly.Wednesday)
+	s.flagTransfers(weekly.Thursday)
+	s.flagTransfers(weekly.Friday)
+	s.flagTransfers(weekly.Saturday)
+
 	return weekly
 }
 
+// flagTransfers marks the classes of a sorted day whose previous class ends too close to
+// walk between both buildings. Virtual sessions and rooms of unknown buildings are skipped,
+// in that case the comparison continues from the last known physical room.
+func (s ScheduleService) flagTransfers(slots []schedule.ClassSlotView) {
+	if s.campus == nil {
+		return
+	}
+
+	var (
+		prev         *schedule.ClassSlotView
+		prevBuilding metadata.Building
+	)
+
+	for i := range slots {
+		current := &slots[i]
+		if current.Time.Start == nil || current.Time.End == nil {
+			continue
+		}
+
+		building, ok := s.campus.BuildingOf(current.Room)
+		if !ok {
+			continue
+		}
+
+		if prev != nil {
+			gap := int(current.Time.Start.Sub(*prev.Time.End).Minutes())
+			walk := s.campus.WalkingMinutes(prevBuilding, building)
+
+			if walk > 0 && gap < walk+s.campus.MarginMinutes {
+				current.Transfer = &schedule.TransferWarning{
+					FromBuilding: prevBuilding.Name,
+					ToBuilding:   building.Name,
+					GapMinutes:   max(gap, 0),
+					WalkMinutes:  walk,
+				}
+			}
+		}
+
+		prev = current
+		prevBuilding = building
+	}
+}
+
 func sortSlots(slots []schedule.ClassSlotView) {
 	sort.Slice(slots, func(i, j int) bool {
 		return slots[i].Time.Start.Before(*slots[j].Time.Start)
 	})
 }
 
+// buildThisWeek lays the weekly classes over the dates of the current week. The academic
+// calendar is optional here, when it fails the week is shown without days off.
+func (s ScheduleService) buildThisWeek(ctx context.Context, weekly schedule.WeekScheduleView, now time.Time) []schedule.ClassDayView {
+	week := academic.WeekOf(now)
+
+	classes, err := s.calendarService.ClassDates(ctx)
+	if err != nil {
+		logger.Warn("cannot get class dates", "error", err)
+	}
+
+	daysOff, err := s.calendarService.DaysOffBetween(ctx, week)
+	if err != nil {
+		logger.Warn("cannot list days off", "error", err)
+	}
+
+	return weekClasses(weekly, week, classes, daysOff, now)
+}
+
+// weekClasses returns the days from Monday to Saturday of the week with their classes.
+func weekClasses(
+	weekly schedule.WeekScheduleView,
+	week academic.DateRange,
+	classes academic.DateRange,
+	daysOff []academic.DayOff,
+	now time.Time,
+) []schedule.ClassDayView {
+	days := make([]schedule.ClassDayView, 0, 6)
+
+	for i := range 6 {
+		date := week.Start.AddDate(0, 0, i)
+		day := schedule.ClassDayView{
+			Date:  date,
+			Today: academic.DateRange{Start: date, End: date}.Contains(now),
+		}
+
+		if off := classDayOff(date, classes, daysOff); off != nil {
+			day.DayOff = off
+		} else {
+			day.Classes = weekly.Day(date.Weekday())
+		}
+
+		days = append(days, day)
+	}
+
+	return days
+}
+
+// todayClasses returns the classes of the day of now that did not finish yet, and the first
+// class that did not start, looking up to nextClassSearchDays ahead.
+func todayClasses(
+	weekly schedule.WeekScheduleView,
+	classes academic.DateRange,
+	daysOff []academic.DayOff,
+	now time.Time,
+) *schedule.TodayView {
+	view := &schedule.TodayView{Now: now}
+	today := startOfDay(now)
+
+	for i := 0; i <= nextClassSearchDays && view.Next == nil; i++ {
+		date := today.AddDate(0, 0, i)
+
+		off := classDayOff(date, classes, daysOff)
+		if i == 0 {
+			view.DayOff = off
+		}
+		if off != nil {
+			continue
+		}
+
+		for _, slot := range weekly.Day(date.Weekday()) {
+			if slot.Time.Start == nil || slot.Time.End == nil {
+				continue
+			}
+
+			class := schedule.ClassOccurrenceView{
+				Course: slot.Course,
+				Room:   slot.Room,
+				Start:  atTime(date, *slot.Time.Start),
+				End:    atTime(date, *slot.Time.End),
+			}
+
+			if i == 0 && class.End.After(now) {
+				class.InProgress = !class.Start.After(now)
+				view.Remaining = append(view.Remaining, class)
+			}
+			if view.Next == nil && class.Start.After(now) {
+				view.Next = &class
+			}
+		}
+	}
+
+	return view
+}
+
+// upcomingExams returns the dated exams from now to upcomingExamDays ahead, sorted by date.
+// Exams without hour are kept during the whole day.
+func upcomingExams(courses []academic.CourseSummaryView, now time.Time) []schedule.UpcomingExamView {
+	today := startOfDay(now)
+
+	var exams []schedule.UpcomingExamView
+	for _, course := range courses {
+		for _, exam := range course.Exams {
+			if !exam.HasDate() {
+				continue
+			}
+
+			date := exam.Date().In(now.Location())
+			daysLeft := int(math.Round(startOfDay(date).Sub(today).Hours() / 24))
+
+			if daysLeft < 0 || daysLeft > upcomingExamDays || (exam.HasHour() && date.Before(now)) {
+				continue
+			}
+
+			exams = append(exams, schedule.UpcomingExamView{
+				Course:   course.Name,
+				Label:    academic.ExamLabel(exam.Type, exam.Instance),
+				Date:     date,
+				HasHour:  exam.HasHour(),
+				Room:     exam.Room.String(),
+				DaysLeft: daysLeft,
+			})
+		}
+	}
+
+	sort.SliceStable(exams, func(i, j int) bool {
+		return exams[i].Date.Before(exams[j].Date)
+	})
+
+	return exams
+}
+
+// classDayOff returns why there are no classes on the date, or nil when there are.
+func classDayOff(date time.Time, classes academic.DateRange, daysOff []academic.DayOff) *academic.DayOff {
+	if off := academic.DayOffAt(daysOff, date); off != nil {
+		return off
+	}
+	if !classes.IsZero() && !classes.Contains(date) {
+		off := outsideClasses
+		return &off
+	}
+	return nil
+}
+
+// classEvents turns each weekly class into a recurring event over the classes dates. The
+// occurrences that fall on days off are listed as exclusions.
+func classEvents(weekly schedule.WeekScheduleView, classes academic.DateRange, daysOff []academic.DayOff) []schedule.ClassEventView {
+	var events []schedule.ClassEventView
+
+	for weekday := time.Monday; weekday <= time.Saturday; weekday++ {
+		first := classes.Start.AddDate(0, 0, (int(weekday)-int(classes.Start.Weekday())+7)%7)
+		if first.After(classes.End) {
+			continue
+		}
+		last := classes.End.AddDate(0, 0, -((int(classes.End.Weekday()) - int(weekday) + 7) % 7))
+
+		for _, slot := range weekly.Day(weekday) {
+			if slot.Time.Start == nil || slot.Time.End == nil {
+				continue
+			}
+
+			event := schedule.ClassEventView{
+				Course: slot.Course,
+				Room:   slot.Room,
+				Start:  atTime(first, *slot.Time.Start),
+				End:    atTime(first, *slot.Time.End),
+				Until:  atTime(last, *slot.Time.End),
+			}
+
+			for date := first; !date.After(last); date = date.AddDate(0, 0, 7) {
+				if academic.DayOffAt(daysOff, date) != nil {
+					event.Exclusions = append(event.Exclusions, atTime(date, *slot.Time.Start))
+				}
+			}
+
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// atTime combines the date of day with the hour of clock.
+func atTime(day, clock time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, day.Location())
+}
+
 func (s ScheduleService) extractExams(courses []academic.CourseSummaryView) schedule.ExamMapView {
 	var examMap schedule.ExamMapView
 
@@ -191,7 +526,7 @@ func (s ScheduleService) extractExams(courses []academic.CourseSummaryView) sche
 		for _, exam := range course.Exams {
 			slot := schedule.ExamSlotView{
 				CourseName: course.Name,
-				Room:       exam.Room,
+				Room:       exam.Room.Raw,
 			}
 
 			if exam.HasDate() {
@@ -0,0 +1,234 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/elias-gill/poliplanner2/internal/config/timezone"
+	"github.com/elias-gill/poliplanner2/internal/model/academic"
+	"github.com/elias-gill/poliplanner2/internal/model/schedule"
+	"github.com/elias-gill/poliplanner2/internal/service/metadata"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, timezone.ParaguayTZ)
+}
+
+func slot(course string, start, end string) schedule.ClassSlotView {
+	s, _ := time.Parse("15:04", start)
+	e, _ := time.Parse("15:04", end)
+	return schedule.ClassSlotView{Course: course, Time: academic.TimeSlot{Start: &s, End: &e}}
+}
+
+func TestWeekClasses(t *testing.T) {
+	weekly := schedule.WeekScheduleView{
+		Monday:   []schedule.ClassSlotView{slot("Cálculo", "07:00", "08:30")},
+		Thursday: []schedule.ClassSlotView{slot("Física", "19:00", "20:30")},
+		Friday:   []schedule.ClassSlotView{slot("Álgebra", "07:00", "08:30")},
+	}
+
+	// Wednesday, June 10 2026
+	now := time.Date(2026, time.June, 10, 9, 0, 0, 0, timezone.ParaguayTZ)
+	week := academic.WeekOf(now)
+	classes := academic.DateRange{Start: date(2026, time.February, 9), End: date(2026, time.June, 11)}
+	daysOff := []academic.DayOff{
+		{Dates: academic.DateRange{Start: date(2026, time.June, 8), End: date(2026, time.June, 8)}, Description: "Feriado"},
+	}
+
+	days := weekClasses(weekly, week, classes, daysOff, now)
+
+	if len(days) != 6 {
+		t.Fatalf("weekClasses() returned %d days; want Monday to Saturday", len(days))
+	}
+	if days[0].Name() != "Lunes" || days[0].DayOff == nil || len(days[0].Classes) != 0 {
+		t.Errorf("Monday = %s, day off %v, %d classes; want the holiday", days[0].Name(), days[0].DayOff, len(days[0].Classes))
+	}
+	if !days[2].Today || days[1].Today {
+		t.Errorf("today flags = %v, %v; want only Wednesday", days[1].Today, days[2].Today)
+	}
+	if len(days[3].Classes) != 1 || days[3].DayOff != nil {
+		t.Errorf("Thursday has %d classes; want Física", len(days[3].Classes))
+	}
+	if off := days[4].DayOff; off == nil || off.Description != outsideClasses.Description {
+		t.Errorf("Friday day off = %v; want outside of the classes", off)
+	}
+}
+
+func TestClassEvents(t *testing.T) {
+	weekly := schedule.WeekScheduleView{
+		Monday: []schedule.ClassSlotView{slot("Cálculo", "07:00", "08:30")},
+		Friday: []schedule.ClassSlotView{slot("Álgebra", "18:00", "19:30")},
+	}
+
+	// Wednesday to Monday, five weeks later
+	classes := academic.DateRange{Start: date(2026, time.February, 11), End: date(2026, time.March, 16)}
+	daysOff := []academic.DayOff{
+		{Dates: academic.DateRange{Start: date(2026, time.March, 1), End: date(2026, time.March, 2)}},
+	}
+
+	events := classEvents(weekly, classes, daysOff)
+
+	if len(events) != 2 {
+		t.Fatalf("classEvents() returned %d events; want 2", len(events))
+	}
+
+	monday := events[0]
+	if want := time.Date(2026, time.February, 16, 7, 0, 0, 0, timezone.ParaguayTZ); !monday.Start.Equal(want) {
+		t.Errorf("Monday starts %v; want %v", monday.Start, want)
+	}
+	if want := time.Date(2026, time.March, 16, 8, 30, 0, 0, timezone.ParaguayTZ); !monday.Until.Equal(want) {
+		t.Errorf("Monday until %v; want %v", monday.Until, want)
+	}
+	if len(monday.Exclusions) != 1 || monday.Exclusions[0].Day() != 2 || monday.Exclusions[0].Hour() != 7 {
+		t.Errorf("Monday exclusions = %v; want March 2 at 07:00", monday.Exclusions)
+	}
+
+	friday := events[1]
+	if friday.Start.Day() != 13 || friday.Until.Day() != 13 || friday.Until.Month() != time.March {
+		t.Errorf("Friday from %v until %v; want February 13 to March 13", friday.Start, friday.Until)
+	}
+	if len(friday.Exclusions) != 0 {
+		t.Errorf("Friday exclusions = %v; want none", friday.Exclusions)
+	}
+}
+
+func TestTodayClasses(t *testing.T) {
+	weekly := schedule.WeekScheduleView{
+		Wednesday: []schedule.ClassSlotView{slot("Cálculo", "07:00", "08:30"), slot("Física", "08:30", "10:00"), slot("Álgebra", "19:00", "20:30")},
+		Monday:    []schedule.ClassSlotView{slot("Lógica", "07:00", "08:30")},
+	}
+	daysOff := []academic.DayOff{
+		{Dates: academic.DateRange{Start: date(2026, time.June, 15), End: date(2026, time.June, 15)}, Description: "Feriado"},
+	}
+
+	// Wednesday, June 10 2026, during Física
+	now := time.Date(2026, time.June, 10, 9, 0, 0, 0, timezone.ParaguayTZ)
+	view := todayClasses(weekly, academic.DateRange{}, daysOff, now)
+
+	if len(view.Remaining) != 2 || view.Remaining[0].Course != "Física" || !view.Remaining[0].InProgress || view.Remaining[1].InProgress {
+		t.Fatalf("remaining = %+v; want Física in progress and Álgebra", view.Remaining)
+	}
+	if view.Next == nil || view.Next.Course != "Álgebra" {
+		t.Fatalf("next = %+v; want Álgebra", view.Next)
+	}
+	if got := view.Countdown(); got != "en 10 h" {
+		t.Errorf("Countdown() = %q; want %q", got, "en 10 h")
+	}
+
+	// Thursday night: Monday 15 is a holiday, so the next class is Wednesday 17
+	now = time.Date(2026, time.June, 11, 22, 0, 0, 0, timezone.ParaguayTZ)
+	view = todayClasses(weekly, academic.DateRange{}, daysOff, now)
+
+	if len(view.Remaining) != 0 || view.DayOff != nil {
+		t.Errorf("remaining = %+v, day off %v; want nothing", view.Remaining, view.DayOff)
+	}
+	if want := time.Date(2026, time.June, 17, 7, 0, 0, 0, timezone.ParaguayTZ); view.Next == nil || !view.Next.Start.Equal(want) {
+		t.Errorf("next = %+v; want Cálculo at %v", view.Next, want)
+	}
+
+	// After the last day of classes there is no next class
+	classes := academic.DateRange{Start: date(2026, time.February, 9), End: date(2026, time.June, 10)}
+	view = todayClasses(weekly, classes, nil, time.Date(2026, time.June, 10, 21, 0, 0, 0, timezone.ParaguayTZ))
+
+	if view.Next != nil || len(view.Remaining) != 0 {
+		t.Errorf("next = %+v, remaining %+v; want none", view.Next, view.Remaining)
+	}
+}
+
+func TestUpcomingExams(t *testing.T) {
+	exam := func(examType academic.ExamType, instance academic.ExamInstance, at time.Time) academic.Exam {
+		e := academic.Exam{Type: examType, Instance: instance}
+		e.SetDate(&at)
+		return e
+	}
+
+	courses := []academic.CourseSummaryView{
+		{Name: "Cálculo", Exams: []academic.Exam{
+			exam(academic.ExamPartial, academic.Instance1, time.Date(2026, time.June, 10, 7, 0, 0, 0, timezone.ParaguayTZ)),
+			exam(academic.ExamPartial, academic.Instance2, time.Date(2026, time.June, 20, 0, 0, 0, 0, timezone.ParaguayTZ)),
+			exam(academic.ExamFinal, academic.Instance1, time.Date(2026, time.July, 20, 8, 0, 0, 0, timezone.ParaguayTZ)),
+		}},
+		{Name: "Física", Exams: []academic.Exam{
+			{Type: academic.ExamPartial, Instance: academic.Instance1},
+			exam(academic.ExamPartial, academic.Instance2, time.Date(2026, time.June, 10, 0, 0, 0, 0, timezone.ParaguayTZ)),
+			exam(academic.ExamFinal, academic.Instance1, time.Date(2026, time.June, 11, 19, 0, 0, 0, timezone.ParaguayTZ)),
+		}},
+	}
+
+	now := time.Date(2026, time.June, 10, 9, 0, 0, 0, timezone.ParaguayTZ)
+	exams := upcomingExams(courses, now)
+
+	// The partial of this morning already passed, the final of July is too far
+	if len(exams) != 3 {
+		t.Fatalf("upcomingExams() returned %+v; want 3 exams", exams)
+	}
+	if exams[0].Course != "Física" || exams[0].When() != "hoy" || exams[0].HasHour {
+		t.Errorf("first exam = %+v; want the partial of Física today", exams[0])
+	}
+	if exams[1].Label != "1° Final" || exams[1].When() != "mañana" {
+		t.Errorf("second exam = %+v; want the final of Física tomorrow", exams[1])
+	}
+	if exams[2].Course != "Cálculo" || exams[2].DaysLeft != 10 {
+		t.Errorf("third exam = %+v; want Cálculo in 10 days", exams[2])
+	}
+}
+
+func TestFlagTransfers(t *testing.T) {
+	campus, err := metadata.LoadCampusMap()
+	if err != nil {
+		t.Fatalf("LoadCampusMap() = %v", err)
+	}
+
+	physical := func(raw, building, number string) academic.Location {
+		return academic.Location{Raw: raw, Kind: academic.RoomPhysical, Building: building, Number: number}
+	}
+	at := func(course, start, end string, room academic.Location) schedule.ClassSlotView {
+		s := slot(course, start, end)
+		s.Room = room
+		return s
+	}
+
+	newDay := func() []schedule.ClassSlotView {
+		return []schedule.ClassSlotView{
+			at("Cálculo", "07:00", "08:30", physical("A-12", "A", "12")),
+			// No time to walk from the block A to the F
+			at("Física", "08:30", "10:00", physical("F-3", "F", "3")),
+			// Not on the block A, unknown rooms are skipped
+			at("Seminario", "10:00", "11:00", physical("Auditorio", "", "")),
+			at("Ética", "11:00", "12:00", academic.Location{Raw: "Virtual", Kind: academic.RoomVirtual}),
+			// Same building as the last known room
+			at("Álgebra", "12:00", "13:00", physical("F 10", "", "")),
+			at("Química", "13:00", "14:00", physical("A-5", "A", "5")),
+			// Enough time to walk from the block A to the B
+			at("Inglés", "14:10", "15:00", physical("B-1", "B", "1")),
+		}
+	}
+
+	day := newDay()
+	ScheduleService{campus: campus}.flagTransfers(day)
+
+	want := map[string]schedule.TransferWarning{
+		"Física":  {FromBuilding: "Bloque A", ToBuilding: "Bloque F", GapMinutes: 0, WalkMinutes: 7},
+		"Química": {FromBuilding: "Bloque F", ToBuilding: "Bloque A", GapMinutes: 0, WalkMinutes: 7},
+	}
+	for _, s := range day {
+		expected, flagged := want[s.Course]
+		switch {
+		case flagged && s.Transfer == nil:
+			t.Errorf("%s has no transfer; want %+v", s.Course, expected)
+		case !flagged && s.Transfer != nil:
+			t.Errorf("%s transfer = %+v; want none", s.Course, *s.Transfer)
+		case flagged && *s.Transfer != expected:
+			t.Errorf("%s transfer = %+v; want %+v", s.Course, *s.Transfer, expected)
+		}
+	}
+
+	// Without a campus map nothing is flagged
+	day = newDay()
+	ScheduleService{}.flagTransfers(day)
+	for _, s := range day {
+		if s.Transfer != nil {
+			t.Errorf("%s transfer = %+v without campus; want none", s.Course, *s.Transfer)
+		}
+	}
+}
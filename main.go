@@ -8,10 +8,17 @@ import (
 	"github.com/elias-gill/poliplanner2/internal/http/middleware"
 	"github.com/elias-gill/poliplanner2/internal/http/routes"
 	"github.com/elias-gill/poliplanner2/internal/http/routes/auth"
+	"github.com/elias-gill/poliplanner2/internal/http/routes/calendar"
+	"github.com/elias-gill/poliplanner2/internal/http/routes/careers"
+	"github.com/elias-gill/poliplanner2/internal/http/routes/committees"
 	"github.com/elias-gill/poliplanner2/internal/http/routes/dashboard"
 	"github.com/elias-gill/poliplanner2/internal/http/routes/excel"
 	"github.com/elias-gill/poliplanner2/internal/http/routes/guides"
+	"github.com/elias-gill/poliplanner2/internal/http/routes/periods"
+	"github.com/elias-gill/poliplanner2/internal/http/routes/rooms"
 	"github.com/elias-gill/poliplanner2/internal/http/routes/schedules"
+	"github.com/elias-gill/poliplanner2/internal/http/routes/search"
+	"github.com/elias-gill/poliplanner2/internal/http/routes/teachers"
 	"github.com/elias-gill/poliplanner2/internal/http/routes/tools"
 	"github.com/elias-gill/poliplanner2/internal/http/routes/user"
 	"github.com/elias-gill/poliplanner2/internal/infrastructure/persistence"
@@ -56,12 +63,17 @@ func main() {
 	servs := services.NewAppServices(services.RepositoriesInput{
 		ExcelRepo:      sqliteStore.ExcelRepo,
 		SyncRepo:       sqliteStore.SyncRepo,
+		LayoutRepo:     sqliteStore.LayoutRepo,
 		CourseRepo:     sqliteStore.CourseRepo,
 		TeacherRepo:    sqliteStore.TeacherRepo,
 		CurriculumRepo: sqliteStore.CurriculumRepo,
 		PeriodRepo:     sqliteStore.PeriodRepo,
 		SubjectRepo:    sqliteStore.SubjectRepo,
 		CareerRepo:     sqliteStore.CareerRepo,
+		RoomRepo:       sqliteStore.RoomRepo,
+		ExaminerRepo:   sqliteStore.ExaminerRepo,
+		SearchRepo:     sqliteStore.SearchRepo,
+		CalendarRepo:   sqliteStore.CalendarRepo,
 		AuthRepo:       sqliteStore.AuthRepo,
 		UserRepo:       sqliteStore.UserRepo,
 		TxManager:      sqliteStore.TxManager,
@@ -73,6 +85,16 @@ func main() {
 
 	// Auto import new excel versions on startup (concurrently)
 	go func() {
+		// Courses imported before examiners existed only have the committee text columns
+		if err := servs.ExaminerService.SyncExaminers(context.Background()); err != nil {
+			log.Warn("cannot sync examiners", "error", err)
+		}
+
+		// Databases imported before the search index existed start with an empty one
+		if err := servs.SearchService.RebuildIndex(context.Background()); err != nil {
+			log.Warn("cannot rebuild search index", "error", err)
+		}
+
 		// 30 seconds has to be more than enough, even when google drive is slow
 		ctx, cancel := context.WithTimeout(context.Background(), config.Get().Excel.ScraperTimeout)
 		defer cancel()
@@ -111,16 +133,29 @@ func initRouter(srvs *services.AppServices) chi.Router {
 		srvs.CareerService,
 		srvs.CourseService,
 		srvs.CurriculumService,
+		srvs.PeriodService,
 	).Routes())
 
 	r.Mount("/user", user.NewHandler(tmplManager, srvs.SessionService).Routes())
 
+	r.Mount("/rooms", rooms.NewHandler(tmplManager, srvs.RoomService).Routes())
+
+	r.Mount("/teachers", teachers.NewHandler(tmplManager, srvs.TeacherService).Routes())
+
+	r.Mount("/committees", committees.NewHandler(tmplManager, srvs.ExaminerService).Routes())
+
+	r.Mount("/careers", careers.NewHandler(tmplManager, srvs.CareerService).Routes())
+
+	r.Mount("/search", search.NewHandler(tmplManager, srvs.SearchService).Routes())
+
 	// Misc routers
 	r.Mount("/tools", tools.NewHandler(tmplManager).Routes())
 	r.Mount("/guides", guides.NewHandler(tmplManager).Routes())
 
 	// Admin routers
-	r.Mount("/excel", excel.NewHandler(tmplManager, srvs.ExcelService, srvs.SyncService).Routes())
+	r.Mount("/excel", excel.NewHandler(tmplManager, srvs.ExcelService, srvs.SyncService, srvs.LayoutService).Routes())
+	r.Mount("/periods", periods.NewHandler(tmplManager, srvs.PeriodService).Routes())
+	r.Mount("/calendar", calendar.NewHandler(tmplManager, srvs.CalendarService).Routes())
 
 	// Static files and assets mapping
 	staticDir := http.Dir(config.Get().Paths.AssetsDir)
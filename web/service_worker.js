@@ -3,7 +3,7 @@
  * @description PoliPlanner Service Worker
  */
 
-const CACHE_NAME = 'poliplanner-cache-v2';
+const CACHE_NAME = 'poliplanner-cache-v3';
 
 self.addEventListener('install', (event) => {
 	self.skipWaiting();
@@ -71,6 +71,7 @@ self.addEventListener('fetch', (event) => {
 	const path = url.pathname;
 
 	const isStatic = path.startsWith('/static/');
+	const isJSON = path.endsWith('.json');
 	const isTarget =
 		path.startsWith('/dashboard') ||
 		path.startsWith('/guides') ||
@@ -126,6 +127,10 @@ self.addEventListener('fetch', (event) => {
 				if (isTarget) {
 					// Serve cached target
 					return caches.match(event.request).then((cachedResponse) => {
+						// JSON is served as is, clients know the moment it was generated
+						if (cachedResponse && isJSON) {
+							return cachedResponse;
+						}
 						if (cachedResponse) {
 							return cachedResponse.text().then((html) => {
 								const modifiedHtml = html.replace(
@@ -146,6 +151,12 @@ self.addEventListener('fetch', (event) => {
 							});
 						}
 						// No cache found
+						if (isJSON) {
+							return new Response(JSON.stringify({ error: 'offline' }), {
+								status: 503,
+								headers: { 'Content-Type': 'application/json; charset=utf-8' },
+							});
+						}
 						return new Response(ERROR_HTML, {
 							status: 503,
 							headers: { 'Content-Type': 'text/html; charset=utf-8' },